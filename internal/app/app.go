@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,14 +14,22 @@ import (
 	"github.com/l-orlov/task-tracker/internal/handler"
 	"github.com/l-orlov/task-tracker/internal/repository"
 	"github.com/l-orlov/task-tracker/internal/repository/postgres"
+	"github.com/l-orlov/task-tracker/internal/scheduler"
 	"github.com/l-orlov/task-tracker/internal/server"
 	"github.com/l-orlov/task-tracker/internal/service"
+	"github.com/l-orlov/task-tracker/pkg/blobstore"
 	"github.com/l-orlov/task-tracker/pkg/logger"
 	"github.com/l-orlov/task-tracker/pkg/mailer"
+	"github.com/l-orlov/task-tracker/pkg/webhook"
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	storageLocal = "local"
+	storageS3    = "s3"
+)
+
 // Run initializes whole application.
 func Run(configPath string) {
 	cfg, err := config.Init(configPath)
@@ -65,21 +74,58 @@ func Run(configPath string) {
 	m.Init()
 	defer m.Shutdown()
 
+	ws := webhook.New(
+		webhook.Config{
+			Timeout:          cfg.Webhook.Timeout.Duration(),
+			DeliveryChanSize: cfg.Webhook.DeliveryChanSize,
+			WorkersNum:       cfg.Webhook.WorkersNum,
+			MaxAttempts:      cfg.Webhook.MaxAttempts,
+			InitialBackoff:   cfg.Webhook.InitialBackoff.Duration(),
+		},
+		logrus.NewEntry(lg).WithFields(logrus.Fields{"source": "webhook"}),
+	)
+	ws.Init()
+	defer ws.Shutdown()
+
+	bs, err := newBlobStore(cfg.Attachment)
+	if err != nil {
+		lg.Fatalf("failed to create blob store: %v", err)
+	}
+
 	// Repo, Service & API Handlers
 	repo, err := repository.NewRepository(cfg, lg, db)
 	if err != nil {
 		log.Fatalf("failed to create repository: %v", err)
 	}
 
-	svc, err := service.NewService(cfg, lg, repo, m)
+	svc, err := service.NewService(cfg, lg, repo, m, ws, bs)
 	if err != nil {
 		log.Fatalf("failed to create service: %v", err)
 	}
 
+	if cfg.Admin.Email != "" {
+		if err = svc.User.BootstrapAdmin(context.Background()); err != nil {
+			lg.Warnf("user %s is not made administrator on start: %v. "+
+				"It will be made administrator after confirmation of the email", cfg.Admin.Email, err)
+		}
+	}
+
+	// Board events from other app instances
+	boardEventsCtx, stopBoardEvents := context.WithCancel(context.Background())
+	defer stopBoardEvents()
+	go svc.BoardEvents.Run(boardEventsCtx)
+
+	// Background Jobs
+	sch := scheduler.New(logrus.NewEntry(lg).WithFields(logrus.Fields{"source": "scheduler"}), repo.JobLock)
+	addSchedulerJobs(cfg, sch, svc)
+	sch.Start()
+	defer sch.Shutdown()
+
 	h := handler.New(cfg, lg, svc)
 
 	// HTTP Server
 	srv := server.New(cfg.Port, h.InitRoutes())
+	srv.RegisterOnShutdown(h.CloseStreams)
 	go func() {
 		if err = srv.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			lg.Fatalf("error occurred while running http server: %v", err)
@@ -99,3 +145,56 @@ func Run(configPath string) {
 		lg.Errorf("failed to shut down: %v", err)
 	}
 }
+
+func addSchedulerJobs(cfg *config.Config, sch *scheduler.Scheduler, svc *service.Service) {
+	dailyDigestInterval := cfg.Scheduler.DailyDigestInterval.Duration()
+	sch.AddJob(scheduler.Job{
+		Name:     "dailyDigest",
+		Interval: dailyDigestInterval,
+		Run: func(ctx context.Context, period scheduler.Period) error {
+			// digest covers all changes since the last sent digest, including time when the app was down
+			since := period.From
+			if since.IsZero() {
+				since = period.To.Add(-dailyDigestInterval)
+			}
+
+			return svc.Notification.SendDailyDigests(ctx, since, period.To)
+		},
+	})
+
+	sch.AddJob(scheduler.Job{
+		Name:     "dueReminder",
+		Interval: cfg.Scheduler.DueReminderInterval.Duration(),
+		Run: func(ctx context.Context, _ scheduler.Period) error {
+			return svc.Notification.SendDueSoonReminders(ctx)
+		},
+	})
+
+	sch.AddJob(scheduler.Job{
+		Name:     "blobCleanup",
+		Interval: cfg.Scheduler.BlobCleanupInterval.Duration(),
+		Run: func(ctx context.Context, _ scheduler.Period) error {
+			return svc.Blob.DeleteQueuedBlobs(ctx)
+		},
+	})
+}
+
+// newBlobStore creates storage of attachment files configured by storage type.
+func newBlobStore(cfg config.Attachment) (blobstore.BlobStore, error) {
+	switch cfg.Storage {
+	case storageLocal:
+		return blobstore.NewLocal(cfg.LocalDir)
+	case storageS3:
+		return blobstore.NewS3(blobstore.S3Config{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+			Timeout:         cfg.S3.Timeout.Duration(),
+		})
+	default:
+		return nil, fmt.Errorf("not supported storage type %q", cfg.Storage)
+	}
+}
@@ -15,6 +15,11 @@ type (
 		UserBlocking UserBlocking `yaml:"userBlocking"`
 		Verification Verification `yaml:"verification"`
 		Mailer       Mailer       `yaml:"mailer"`
+		Scheduler    Scheduler    `yaml:"scheduler"`
+		Webhook      Webhook      `yaml:"webhook"`
+		Attachment   Attachment   `yaml:"attachment"`
+		Avatar       Avatar       `yaml:"avatar"`
+		Admin        Admin        `yaml:"admin"`
 	}
 	Logger struct {
 		Level  string `yaml:"level" env:"LOGGER_LEVEL,default=info"`
@@ -58,6 +63,7 @@ type (
 	Verification struct {
 		EmailConfirmTokenLifetime         cr.DurationConfig `yaml:"emailConfirmTokenLifetime"`
 		PasswordResetConfirmTokenLifetime cr.DurationConfig `yaml:"passwordResetConfirmTokenLifetime"`
+		ProjectInvitationTokenLifetime    cr.DurationConfig `yaml:"projectInvitationTokenLifetime"`
 	}
 	Mailer struct {
 		ServerAddress     cr.AddressConfig  `yaml:"serverAddress" env:"EMAIL_SERVER_ADDRESS,default=smtp.gmail.com:587"`
@@ -68,6 +74,49 @@ type (
 		MsgToSendChanSize int               `yaml:"msgToSendChanSize"`
 		WorkersNum        int               `yaml:"workersNum"`
 	}
+	Scheduler struct {
+		DailyDigestInterval cr.DurationConfig `yaml:"dailyDigestInterval"`
+		DueReminderInterval cr.DurationConfig `yaml:"dueReminderInterval"`
+		DueReminderDays     int               `yaml:"dueReminderDays"`
+		BlobCleanupInterval cr.DurationConfig `yaml:"blobCleanupInterval"`
+	}
+	Webhook struct {
+		Timeout          cr.DurationConfig `yaml:"timeout"`
+		DeliveryChanSize int               `yaml:"deliveryChanSize"`
+		WorkersNum       int               `yaml:"workersNum"`
+		MaxAttempts      int               `yaml:"maxAttempts"`
+		InitialBackoff   cr.DurationConfig `yaml:"initialBackoff"`
+	}
+	Attachment struct {
+		// MaxSize is max size of attachment file in bytes
+		MaxSize int64 `yaml:"maxSize"`
+		// AllowedContentTypes are media types of attachments like "image/png" or "image/*". Empty list allows any type.
+		AllowedContentTypes []string `yaml:"allowedContentTypes"`
+		MaxFilesPerUpload   int      `yaml:"maxFilesPerUpload"`
+		// Storage is type of blob storage of attachments and avatars: "local" or "s3"
+		Storage  string       `yaml:"storage" env:"ATTACHMENT_STORAGE"`
+		LocalDir string       `yaml:"localDir" env:"ATTACHMENT_LOCAL_DIR"`
+		S3       AttachmentS3 `yaml:"s3"`
+	}
+	AttachmentS3 struct {
+		Endpoint        string            `yaml:"endpoint" env:"S3_ENDPOINT"`
+		Region          string            `yaml:"region" env:"S3_REGION"`
+		Bucket          string            `yaml:"bucket" env:"S3_BUCKET"`
+		AccessKeyID     string            `yaml:"accessKeyId" env:"S3_ACCESS_KEY_ID"`
+		SecretAccessKey string            `yaml:"secretAccessKey" env:"S3_SECRET_ACCESS_KEY"`
+		UsePathStyle    bool              `yaml:"usePathStyle"`
+		Timeout         cr.DurationConfig `yaml:"timeout"`
+	}
+	Avatar struct {
+		// MaxSize is max size of uploaded image in bytes
+		MaxSize int64 `yaml:"maxSize"`
+		// MaxDimension is max width and height of uploaded image in pixels
+		MaxDimension int `yaml:"maxDimension"`
+	}
+	Admin struct {
+		// Email is email of user who is made system administrator once the email is confirmed
+		Email string `yaml:"email" env:"ADMIN_EMAIL"`
+	}
 )
 
 func Init(path string) (*Config, error) {
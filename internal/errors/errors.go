@@ -3,11 +3,15 @@ package errors
 type ErrorLevel int
 
 const (
-	Business ErrorLevel = 0
-	Server   ErrorLevel = 1
+	Business  ErrorLevel = 0
+	Server    ErrorLevel = 1
+	Forbidden ErrorLevel = 2
+	Conflict  ErrorLevel = 3
 
-	DetailBusiness = "check the input parameters"
-	DetailServer   = "something went wrong"
+	DetailBusiness  = "check the input parameters"
+	DetailServer    = "something went wrong"
+	DetailForbidden = "not enough rights"
+	DetailConflict  = "entity was changed by another request"
 )
 
 type Error struct {
@@ -42,3 +46,33 @@ func NewBusiness(err error, detail string) *Error {
 
 	return businessErr
 }
+
+func NewForbidden(err error, detail string) *Error {
+	forbiddenErr := &Error{
+		Err:   err,
+		Level: Forbidden,
+	}
+
+	if detail == "" {
+		forbiddenErr.Detail = DetailForbidden
+	} else {
+		forbiddenErr.Detail = detail
+	}
+
+	return forbiddenErr
+}
+
+func NewConflict(err error, detail string) *Error {
+	conflictErr := &Error{
+		Err:   err,
+		Level: Conflict,
+	}
+
+	if detail == "" {
+		conflictErr.Detail = DetailConflict
+	} else {
+		conflictErr.Detail = detail
+	}
+
+	return conflictErr
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) GetTaskActivity(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTaskActivity")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	pageParams, err := getPageParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	page, err := h.svc.Activity.GetTaskActivity(c, taskID, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *Handler) GetProjectActivity(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetProjectActivity")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	pageParams, err := getPageParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	page, err := h.svc.Activity.GetProjectActivity(c, projectID, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
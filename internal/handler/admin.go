@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// SetUserAdmin grants or revokes system administrator rights of user.
+func (h *Handler) SetUserAdmin(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "SetUserAdmin")
+
+	var flag models.UserAdminFlag
+	if err := c.BindJSON(&flag); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, ok := h.getOtherUserByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.User.SetUserAdmin(c, user.ID, flag.IsAdmin); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// DisableUser forbids user to sign in and logs user out on all devices.
+func (h *Handler) DisableUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DisableUser")
+
+	user, ok := h.getOtherUserByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.User.SetUserDisabled(c, user.ID, true); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.RevokeAllUserSessions(strconv.FormatUint(user.ID, 10)); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) EnableUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "EnableUser")
+
+	user, ok := h.getUserByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.User.SetUserDisabled(c, user.ID, false); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// LogoutUser revokes all sessions of user.
+func (h *Handler) LogoutUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "LogoutUser")
+
+	user, ok := h.getUserByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeAllUserSessions(strconv.FormatUint(user.ID, 10)); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ConfirmUserEmail confirms email of user without confirmation link.
+func (h *Handler) ConfirmUserEmail(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "ConfirmUserEmail")
+
+	user, ok := h.getUserByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.User.ConfirmEmail(c, user.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ReassignProjectOwnership makes user from userId query param the project owner.
+// Unlike TransferProjectOwnership, the user does not have to be a member of the project.
+func (h *Handler) ReassignProjectOwnership(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "ReassignProjectOwnership")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Query("userId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidUserIDQueryParam)
+		return
+	}
+
+	project, err := h.svc.Project.GetProjectByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if project == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrProjectNotFound, ""))
+		return
+	}
+
+	user, err := h.svc.User.GetUserByID(c, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrUserNotFound, ""))
+		return
+	}
+
+	if err = h.svc.Project.ReassignProjectOwnership(c, id, userID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// checkUserIsAdmin checks that user from context is system administrator.
+func (h *Handler) checkUserIsAdmin(c *gin.Context) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.svc.User.GetUserByID(c, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || !user.IsAdmin {
+		return ierrors.NewForbidden(ErrNotAdmin, "")
+	}
+
+	return nil
+}
+
+func (h *Handler) getUserByParams(c *gin.Context) (*models.User, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(
+			c, http.StatusBadRequest, ierrors.NewBusiness(ErrNotValidIDParameter, ""),
+		)
+		return nil, false
+	}
+
+	user, err := h.svc.User.GetUserByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if user == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrUserNotFound, ""))
+		return nil, false
+	}
+
+	return user, true
+}
+
+// getOtherUserByParams returns user from params which is not user from context,
+// so administrator can not lock out own account.
+func (h *Handler) getOtherUserByParams(c *gin.Context) (*models.User, bool) {
+	user, ok := h.getUserByParams(c)
+	if !ok {
+		return nil, false
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if user.ID == userID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrAdminSelfChange, ""))
+		return nil, false
+	}
+
+	return user, true
+}
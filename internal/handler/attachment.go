@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+const (
+	attachmentFormField = "file"
+	// multipartOverhead is allowance for multipart headers and boundaries in size limit of request body
+	multipartOverhead = 1 << 20
+)
+
+// CreateAttachments uploads files of multipart form field "file" to the task.
+// Files are checked before uploading and already attached files are deleted if next file fails,
+// so either all files are attached or none of them.
+func (h *Handler) CreateAttachments(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateAttachments")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionUpdateTask); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	uploaderID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	maxFiles := h.cfg.Attachment.MaxFilesPerUpload
+	c.Request.Body = http.MaxBytesReader(
+		c.Writer, c.Request.Body, h.cfg.Attachment.MaxSize*int64(maxFiles)+multipartOverhead,
+	)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	defer func() {
+		if err = form.RemoveAll(); err != nil {
+			h.getLogEntry(c).Errorf("failed to remove multipart form files: %v", err)
+		}
+	}()
+
+	files := form.File[attachmentFormField]
+	if len(files) == 0 {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrEmptyAttachmentForm)
+		return
+	}
+
+	if len(files) > maxFiles {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(
+			ErrTooManyAttachmentFiles, fmt.Sprintf("max number of files is %d", maxFiles),
+		))
+		return
+	}
+
+	attachments := make([]models.AttachmentToCreate, 0, len(files))
+	for _, file := range files {
+		attachment, err := h.svc.Attachment.ValidateAttachment(models.AttachmentToCreate{
+			TaskID:      taskID,
+			FileName:    file.Filename,
+			ContentType: file.Header.Get("Content-Type"),
+			Size:        file.Size,
+		})
+		if err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	ids := make([]uint64, 0, len(files))
+	for i, file := range files {
+		content, err := file.Open()
+		if err != nil {
+			h.deleteNotCompletedAttachments(c, ids)
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		id, err := h.svc.Attachment.CreateAttachment(c, attachments[i], content, uploaderID)
+		if closeErr := content.Close(); closeErr != nil {
+			h.getLogEntry(c).Errorf("failed to close multipart file: %v", closeErr)
+		}
+		if err != nil {
+			h.deleteNotCompletedAttachments(c, ids)
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		ids = append(ids, id)
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"ids": ids,
+	})
+}
+
+func (h *Handler) GetAttachmentByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAttachmentByID")
+
+	attachment, ok := h.getTaskAttachmentByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+func (h *Handler) GetAllTaskAttachments(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllTaskAttachments")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	attachments, err := h.svc.Attachment.GetAllTaskAttachments(c, taskID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if attachments == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// DownloadAttachment streams attachment content from blob storage.
+func (h *Handler) DownloadAttachment(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DownloadAttachment")
+
+	attachment, ok := h.getTaskAttachmentByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	content, err := h.svc.Attachment.GetAttachmentContent(c, *attachment)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{
+			"filename": attachment.FileName,
+		}),
+		// content type is set by uploader, so browser should not guess another one
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteAttachment")
+
+	attachment, ok := h.getTaskAttachmentByParams(c, models.ProjectPermissionUpdateTask)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Attachment.DeleteAttachment(c, attachment.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// deleteNotCompletedAttachments deletes attachments created by upload which failed on next file.
+func (h *Handler) deleteNotCompletedAttachments(c *gin.Context, ids []uint64) {
+	if err := h.svc.Attachment.DeleteAttachments(c, ids); err != nil {
+		h.getLogEntry(c).Errorf("failed to delete attachments %v of failed upload: %v", ids, err)
+	}
+}
+
+// getTaskAttachmentByParams gets attachment by task id and attachment id params and checks permission
+// in the task project. On failure it writes error response and returns false.
+func (h *Handler) getTaskAttachmentByParams(
+	c *gin.Context, permission models.ProjectPermission,
+) (*models.Attachment, bool) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return nil, false
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidAttachmentIDParameter)
+		return nil, false
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, permission); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	attachment, err := h.svc.Attachment.GetAttachmentByID(c, attachmentID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if attachment == nil || attachment.TaskID != taskID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrAttachmentNotFound, ""))
+		return nil, false
+	}
+
+	return attachment, true
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+const (
+	avatarFormField = "avatar"
+	// url of uploaded avatar has version changed on every upload, so it can be cached for a long time
+	versionedAvatarCacheControl = "private, max-age=86400"
+	avatarCacheControl          = "private, max-age=3600"
+)
+
+// UploadUserAvatar sets avatar of user from context to image of multipart form field "avatar".
+func (h *Handler) UploadUserAvatar(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UploadUserAvatar")
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.cfg.Avatar.MaxSize+multipartOverhead)
+
+	file, err := c.FormFile(avatarFormField)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer content.Close()
+
+	avatarURL, err := h.svc.Avatar.UploadUserAvatar(c, userID, content)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"avatarURL": avatarURL,
+	})
+}
+
+// GetUserAvatar returns avatar image of the size from size query param.
+func (h *Handler) GetUserAvatar(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetUserAvatar")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(
+			c, http.StatusBadRequest, ierrors.NewBusiness(ErrNotValidIDParameter, ""),
+		)
+		return
+	}
+
+	size := models.DefaultAvatarSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		if size, err = strconv.Atoi(sizeStr); err != nil {
+			h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidSizeQueryParam)
+			return
+		}
+	}
+
+	user, err := h.svc.User.GetUserByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	avatar, err := h.svc.Avatar.GetUserAvatar(c, *user, size)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer avatar.Content.Close()
+
+	cacheControl := avatarCacheControl
+	if !avatar.IsGenerated && c.Query("v") != "" {
+		cacheControl = versionedAvatarCacheControl
+	}
+
+	c.DataFromReader(http.StatusOK, -1, avatar.ContentType, avatar.Content, map[string]string{
+		"Cache-Control":          cacheControl,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteUserAvatar deletes uploaded avatar of user from context, so generated avatar is used.
+func (h *Handler) DeleteUserAvatar(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteUserAvatar")
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Avatar.DeleteUserAvatar(c, userID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
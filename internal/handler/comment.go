@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateComment(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateComment")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var comment models.CommentToCreate
+	if err = c.BindJSON(&comment); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	comment.TaskID = taskID
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionCreateComment); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	authorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.Comment.CreateComment(c, comment, authorID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetAllTaskComments(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllTaskComments")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	comments, err := h.svc.Comment.GetAllTaskComments(c, taskID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if comments == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (h *Handler) GetCommentEdits(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetCommentEdits")
+
+	comment, ok := h.getTaskCommentByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	edits, err := h.svc.Comment.GetCommentEdits(c, comment.ID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if edits == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, edits)
+}
+
+func (h *Handler) UpdateComment(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateComment")
+
+	comment, ok := h.getTaskCommentByParams(c, models.ProjectPermissionCreateComment)
+	if !ok {
+		return
+	}
+
+	var commentToUpdate models.CommentToUpdate
+	if err := c.BindJSON(&commentToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	authorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Comment.UpdateComment(c, comment.ID, authorID, commentToUpdate.Text); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteComment(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteComment")
+
+	comment, ok := h.getTaskCommentByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// author can delete own comment, others need permission to delete any comment
+	if comment.AuthorID != userID {
+		if _, err = h.checkTaskProjectPermission(
+			c, comment.TaskID, models.ProjectPermissionDeleteAnyComment,
+		); err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if err = h.svc.Comment.DeleteComment(c, comment.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// getTaskCommentByParams gets comment by task id and comment id params and checks permission in the task project.
+// On failure it writes error response and returns false.
+func (h *Handler) getTaskCommentByParams(
+	c *gin.Context, permission models.ProjectPermission,
+) (*models.Comment, bool) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return nil, false
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidCommentIDParameter)
+		return nil, false
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, permission); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	comment, err := h.svc.Comment.GetCommentByID(c, commentID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if comment == nil || comment.TaskID != taskID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrCommentNotFound, ""))
+		return nil, false
+	}
+
+	return comment, true
+}
@@ -5,12 +5,50 @@ import (
 )
 
 var (
-	ErrNotValidAuthorizationHeader = errors.New("not valid Authorization header")
-	ErrNotValidIDParameter         = errors.New("not valid id parameter")
-	ErrNotValidProjectIDQueryParam = errors.New("not valid projectId query param")
-	ErrNotValidUserIDQueryParam    = errors.New("not valid userId query param")
-	ErrNotValidTaskIDQueryParam    = errors.New("not valid taskId query param")
-	ErrEmptyEmailParameter         = errors.New("empty email parameter")
-	ErrEmptyTokenParameter         = errors.New("empty token parameter")
-	ErrUserNotFound                = errors.New("user not found")
+	ErrNotValidAuthorizationHeader   = errors.New("not valid Authorization header")
+	ErrNotValidIDParameter           = errors.New("not valid id parameter")
+	ErrNotValidProjectIDQueryParam   = errors.New("not valid projectId query param")
+	ErrNotValidUserIDQueryParam      = errors.New("not valid userId query param")
+	ErrNotValidTaskIDQueryParam      = errors.New("not valid taskId query param")
+	ErrNotValidCommentIDParameter    = errors.New("not valid commentId parameter")
+	ErrNotValidLimitQueryParam       = errors.New("not valid limit query param")
+	ErrNotValidWithTotalQueryParam   = errors.New("not valid withTotal query param")
+	ErrNotValidFromQueryParam        = errors.New("not valid from query param")
+	ErrNotValidToQueryParam          = errors.New("not valid to query param")
+	ErrNotValidFormatQueryParam      = errors.New("not valid format query param")
+	ErrNotValidSprintIDQueryParam    = errors.New("not valid sprintId query param")
+	ErrNotValidWebhookIDParameter    = errors.New("not valid webhookId parameter")
+	ErrNotValidFilterIDParameter     = errors.New("not valid filterId parameter")
+	ErrNotValidLabelIDParameter      = errors.New("not valid labelId parameter")
+	ErrNotValidLinkIDParameter       = errors.New("not valid linkId parameter")
+	ErrNotValidWorklogIDParameter    = errors.New("not valid worklogId parameter")
+	ErrNotValidAttachmentIDParameter = errors.New("not valid attachmentId parameter")
+	ErrEmptyAttachmentForm           = errors.New("no file in multipart form")
+	ErrTooManyAttachmentFiles        = errors.New("too many files in multipart form")
+	ErrNotValidSizeQueryParam        = errors.New("not valid size query param")
+	ErrNotValidIfMatchHeader         = errors.New("not valid If-Match header")
+	ErrEmptyIfMatchHeader            = errors.New("empty If-Match header. should be ETag of project board")
+	ErrEmptyEmailParameter           = errors.New("empty email parameter")
+	ErrEmptyTokenParameter           = errors.New("empty token parameter")
+	ErrUserNotFound                  = errors.New("user not found")
+	ErrProjectNotFound               = errors.New("project not found")
+	ErrTaskNotFound                  = errors.New("task not found")
+	ErrCommentNotFound               = errors.New("comment not found")
+	ErrWebhookNotFound               = errors.New("webhook not found")
+	ErrTaskFilterNotFound            = errors.New("task filter not found")
+	ErrTaskLinkNotFound              = errors.New("task link not found")
+	ErrNotAccountOwner               = errors.New("account can be changed only by its owner")
+	ErrNotAdmin                      = errors.New("action is allowed only to system administrator")
+	ErrAdminSelfChange               = errors.New("administrator can not disable or demote own account")
+	ErrUserIsDisabled                = errors.New("user is disabled")
+	ErrNotTaskFilterOwner            = errors.New("task filter can be changed only by its owner")
+	ErrImportanceStatusNotFound      = errors.New("importance status not found")
+	ErrProgressStatusNotFound        = errors.New("progress status not found")
+	ErrLabelNotFound                 = errors.New("label not found")
+	ErrStatusTransitionNotFound      = errors.New("status transition not found")
+	ErrSprintNotFound                = errors.New("sprint not found")
+	ErrAttachmentNotFound            = errors.New("attachment not found")
+	ErrWorklogNotFound               = errors.New("worklog not found")
+	ErrUserIsAlreadyProjectMember    = errors.New("user is already a member of the project")
+	ErrInvitationEmailMismatch       = errors.New("email does not match the invitation")
 )
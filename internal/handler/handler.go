@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/contrib/static"
 	"github.com/gin-gonic/gin"
@@ -22,6 +23,9 @@ type (
 		log     *logrus.Logger
 		options Options
 		svc     *service.Service
+		// streamsDone is closed on shutdown to end event streams which are not ended by server shutdown
+		streamsDone      chan struct{}
+		closeStreamsOnce sync.Once
 	}
 )
 
@@ -36,12 +40,20 @@ func New(
 			RefreshTokenCookieMaxAge: int(cfg.JWT.RefreshTokenLifetime.Duration().Seconds()),
 			SecureCookie:             securecookie.New(cfg.Cookie.HashKey, cfg.Cookie.BlockKey),
 		},
-		svc: svc,
+		svc:         svc,
+		streamsDone: make(chan struct{}),
 	}
 
 	return c
 }
 
+// CloseStreams ends event streams, so server can be shut down gracefully.
+func (h *Handler) CloseStreams() {
+	h.closeStreamsOnce.Do(func() {
+		close(h.streamsDone)
+	})
+}
+
 func (h *Handler) InitRoutes() http.Handler {
 	router := gin.New()
 
@@ -63,20 +75,34 @@ func (h *Handler) InitRoutes() http.Handler {
 	}
 
 	router.POST("/confirm-email", h.ConfirmEmail)
+	router.POST("/confirm-email-change", h.ConfirmEmailChange)
 	router.POST("/confirm-reset-password", h.ConfirmPasswordReset)
+	router.POST("/accept-invitation", h.AcceptProjectInvitation)
+	router.POST("/decline-invitation", h.DeclineProjectInvitation)
 
 	api := router.Group("/api/v1", h.UserAuthorizationMiddleware)
 	{
+		api.GET("/search", h.Search)
+		api.GET("/timesheet", h.GetTimesheet)
+
 		users := api.Group("/users")
 		{
 			users.POST("/", h.CreateUser)
+			users.GET("/me", h.GetCurrentUser)
+			users.PATCH("/me", h.PatchCurrentUser)
+			users.DELETE("/me", h.DeleteCurrentUser)
 			users.GET("/:id", h.GetUserByID)
-			users.GET("/", h.GetAllUsers)
-			users.GET("/with-params", h.GetAllUsersWithParameters)
-			users.PUT("/", h.UpdateUser)
-			users.PUT("/set-password", h.SetUserPassword)
+			users.GET("/", h.AdminAuthorizationMiddleware, h.GetAllUsers)
+			users.GET("/with-params", h.AdminAuthorizationMiddleware, h.GetAllUsersWithParameters)
+			users.PUT("/", h.AdminAuthorizationMiddleware, h.UpdateUser)
+			users.PUT("/set-password", h.AdminAuthorizationMiddleware, h.SetUserPassword)
 			users.PUT("/change-password", h.ChangeUserPassword)
-			users.DELETE("/:id", h.DeleteUser)
+			users.DELETE("/:id", h.AdminAuthorizationMiddleware, h.DeleteUser)
+			users.GET("/notification-settings", h.GetNotificationSettings)
+			users.GET("/:id/avatar", h.GetUserAvatar)
+			users.PUT("/me/avatar", h.UploadUserAvatar)
+			users.DELETE("/me/avatar", h.DeleteUserAvatar)
+			users.PUT("/notification-settings", h.UpdateNotificationSettings)
 		}
 
 		projects := api.Group("/projects")
@@ -84,19 +110,41 @@ func (h *Handler) InitRoutes() http.Handler {
 			projects.POST("/", h.CreateProject)
 			projects.GET("/:id", h.GetProjectByID)
 			projects.GET("/:id/to-user", h.GetProjectByIDToUser)
-			projects.GET("/", h.GetAllProjects)
+			projects.GET("/:id/task-counts", h.GetProjectTaskCounts)
+			projects.GET("/", h.AdminAuthorizationMiddleware, h.GetAllProjects)
 			projects.GET("/to-user", h.GetAllProjectsToUser)
-			projects.GET("/with-params", h.GetAllProjectsWithParameters)
+			projects.GET("/with-params", h.AdminAuthorizationMiddleware, h.GetAllProjectsWithParameters)
 			projects.PUT("/", h.UpdateProject)
 			projects.DELETE("/:id", h.DeleteProject)
 			projects.POST("/:id/users", h.AddUserToProject)
 			projects.GET("/:id/users", h.GetAllProjectUsers)
+			projects.PUT("/:id/users/role", h.UpdateProjectUserRole)
 			projects.DELETE("/:id/users", h.DeleteUserFromProject)
+			projects.PUT("/:id/owner", h.TransferProjectOwnership)
+			projects.POST("/:id/invitations", h.CreateProjectInvitation)
+			projects.GET("/:id/activity", h.GetProjectActivity)
+			projects.POST("/:id/webhooks", h.CreateWebhook)
+			projects.GET("/:id/webhooks", h.GetAllProjectWebhooks)
+			projects.GET("/:id/webhooks/:webhookId", h.GetWebhookByID)
+			projects.PUT("/:id/webhooks/:webhookId", h.UpdateWebhook)
+			projects.DELETE("/:id/webhooks/:webhookId", h.DeleteWebhook)
+			projects.GET("/:id/webhooks/:webhookId/deliveries", h.GetWebhookDeliveries)
+			projects.POST("/:id/filters", h.CreateTaskFilter)
+			projects.GET("/:id/filters", h.GetAllProjectTaskFilters)
+			projects.GET("/:id/filters/:filterId", h.GetTaskFilterByID)
+			projects.PUT("/:id/filters/:filterId", h.UpdateTaskFilter)
+			projects.DELETE("/:id/filters/:filterId", h.DeleteTaskFilter)
+			projects.GET("/:id/filters/:filterId/tasks", h.GetTasksByTaskFilter)
+			projects.GET("/:id/blocked-tasks", h.GetBlockedProjectTasks)
+			projects.GET("/:id/reports/cumulative-flow", h.GetCumulativeFlowReport)
+			projects.GET("/:id/reports/lead-cycle-time", h.GetLeadCycleTimeReport)
+			projects.GET("/:id/reports/throughput", h.GetThroughputReport)
 		}
 
 		projectBoard := api.Group("/project-board")
 		{
 			projectBoard.GET("/", h.GetProjectBoard)
+			projectBoard.GET("/stream", h.StreamProjectBoard)
 			projectBoard.PUT("/parts", h.UpdateProjectBoardParts)
 			projectBoard.PUT("/statuses", h.UpdateProjectBoardProgressStatuses)
 			projectBoard.PUT("/status-tasks", h.UpdateProjectBoardProgressStatusTasks)
@@ -106,7 +154,7 @@ func (h *Handler) InitRoutes() http.Handler {
 		{
 			importanceStatuses.POST("/", h.CreateImportanceStatus)
 			importanceStatuses.GET("/:id", h.GetImportanceStatusByID)
-			importanceStatuses.GET("/", h.GetAllImportanceStatuses)
+			importanceStatuses.GET("/", h.AdminAuthorizationMiddleware, h.GetAllImportanceStatuses)
 			importanceStatuses.GET("/to-project", h.GetAllImportanceStatusesToProject)
 			importanceStatuses.PUT("/", h.UpdateImportanceStatus)
 			importanceStatuses.DELETE("/:id", h.DeleteImportanceStatus)
@@ -116,20 +164,86 @@ func (h *Handler) InitRoutes() http.Handler {
 		{
 			progressStatuses.POST("/", h.CreateProgressStatus)
 			progressStatuses.GET("/:id", h.GetProgressStatusByID)
-			progressStatuses.GET("/", h.GetAllProgressStatuses)
+			progressStatuses.GET("/", h.AdminAuthorizationMiddleware, h.GetAllProgressStatuses)
 			progressStatuses.GET("/to-project", h.GetAllProgressStatusesToProject)
 			progressStatuses.PUT("/", h.UpdateProgressStatus)
 			progressStatuses.DELETE("/:id", h.DeleteProgressStatus)
 		}
 
+		statusTransitions := api.Group("/project-transitions")
+		{
+			statusTransitions.POST("/", h.CreateStatusTransition)
+			statusTransitions.GET("/:id", h.GetStatusTransitionByID)
+			statusTransitions.GET("/to-project", h.GetAllStatusTransitionsToProject)
+			statusTransitions.PUT("/", h.UpdateStatusTransition)
+			statusTransitions.DELETE("/:id", h.DeleteStatusTransition)
+		}
+
+		labels := api.Group("/project-labels")
+		{
+			labels.POST("/", h.CreateLabel)
+			labels.GET("/:id", h.GetLabelByID)
+			labels.GET("/to-project", h.GetAllLabelsToProject)
+			labels.PUT("/", h.UpdateLabel)
+			labels.DELETE("/:id", h.DeleteLabel)
+		}
+
+		sprints := api.Group("/sprints")
+		{
+			sprints.POST("/", h.CreateSprint)
+			sprints.GET("/:id", h.GetSprintByID)
+			sprints.GET("/to-project", h.GetAllSprintsToProject)
+			sprints.PUT("/", h.UpdateSprint)
+			sprints.DELETE("/:id", h.DeleteSprint)
+			sprints.POST("/:id/start", h.StartSprint)
+			sprints.POST("/:id/close", h.CloseSprint)
+			sprints.GET("/:id/tasks", h.GetAllSprintTasks)
+			sprints.GET("/:id/burndown", h.GetSprintBurndown)
+		}
+
 		tasks := api.Group("tasks")
 		{
 			tasks.POST("/", h.CreateTaskToProject)
 			tasks.GET("/:id", h.GetTaskByID)
 			tasks.GET("/", h.GetAllTasksToProject)
-			tasks.GET("/with-params", h.GetAllTasksWithParameters)
+			tasks.GET("/with-params", h.AdminAuthorizationMiddleware, h.GetAllTasksWithParameters)
+			tasks.GET("/overdue-to-user", h.GetOverdueTasksToUser)
+			tasks.GET("/filter", h.GetTasksByFilter)
 			tasks.PUT("/", h.UpdateTask)
 			tasks.DELETE("/:id", h.DeleteTask)
+			tasks.GET("/:id/tree", h.GetTaskTree)
+			tasks.POST("/:id/comments", h.CreateComment)
+			tasks.GET("/:id/comments", h.GetAllTaskComments)
+			tasks.GET("/:id/comments/:commentId/edits", h.GetCommentEdits)
+			tasks.PUT("/:id/comments/:commentId", h.UpdateComment)
+			tasks.DELETE("/:id/comments/:commentId", h.DeleteComment)
+			tasks.GET("/:id/activity", h.GetTaskActivity)
+			tasks.GET("/:id/labels", h.GetAllTaskLabels)
+			tasks.POST("/:id/labels/:labelId", h.AddLabelToTask)
+			tasks.DELETE("/:id/labels/:labelId", h.DeleteLabelFromTask)
+			tasks.POST("/:id/links", h.CreateTaskLink)
+			tasks.GET("/:id/links", h.GetAllTaskLinks)
+			tasks.DELETE("/:id/links/:linkId", h.DeleteTaskLink)
+			tasks.POST("/:id/worklogs", h.CreateWorklog)
+			tasks.GET("/:id/worklogs", h.GetAllTaskWorklogs)
+			tasks.GET("/:id/worklogs/:worklogId", h.GetWorklogByID)
+			tasks.PUT("/:id/worklogs/:worklogId", h.UpdateWorklog)
+			tasks.DELETE("/:id/worklogs/:worklogId", h.DeleteWorklog)
+			tasks.POST("/:id/attachments", h.CreateAttachments)
+			tasks.GET("/:id/attachments", h.GetAllTaskAttachments)
+			tasks.GET("/:id/attachments/:attachmentId", h.GetAttachmentByID)
+			tasks.GET("/:id/attachments/:attachmentId/content", h.DownloadAttachment)
+			tasks.DELETE("/:id/attachments/:attachmentId", h.DeleteAttachment)
+		}
+
+		admin := api.Group("/admin", h.AdminAuthorizationMiddleware)
+		{
+			admin.PUT("/users/:id/admin", h.SetUserAdmin)
+			admin.POST("/users/:id/disable", h.DisableUser)
+			admin.POST("/users/:id/enable", h.EnableUser)
+			admin.POST("/users/:id/logout", h.LogoutUser)
+			admin.POST("/users/:id/confirm-email", h.ConfirmUserEmail)
+			admin.PUT("/projects/:id/owner", h.ReassignProjectOwnership)
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
 	"github.com/l-orlov/task-tracker/internal/models"
 )
 
@@ -15,6 +16,11 @@ func (h *Handler) CreateImportanceStatus(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkProjectPermission(c, status.ProjectID, models.ProjectPermissionCreateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	id, err := h.svc.ImportanceStatus.Create(c, status)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -44,6 +50,11 @@ func (h *Handler) GetImportanceStatusByID(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, status.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, status)
 }
 
@@ -54,6 +65,11 @@ func (h *Handler) UpdateImportanceStatus(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkImportanceStatusProjectPermission(c, status.ID, models.ProjectPermissionUpdateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	if err := h.svc.ImportanceStatus.Update(c, status); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -84,6 +100,11 @@ func (h *Handler) GetAllImportanceStatusesToProject(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	statuses, err := h.svc.ImportanceStatus.GetAllToProject(c, projectID)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -105,10 +126,31 @@ func (h *Handler) DeleteImportanceStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ImportanceStatus.Delete(c, id); err != nil {
+	if err = h.checkImportanceStatusProjectPermission(c, id, models.ProjectPermissionDeleteStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.ImportanceStatus.Delete(c, id); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
+
+// checkImportanceStatusProjectPermission checks that user from context has the permission in the status project.
+func (h *Handler) checkImportanceStatusProjectPermission(
+	c *gin.Context, id int64, permission models.ProjectPermission,
+) error {
+	status, err := h.svc.ImportanceStatus.GetByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if status == nil {
+		return ierrors.NewBusiness(ErrImportanceStatusNotFound, "")
+	}
+
+	return h.checkProjectPermission(c, status.ProjectID, permission)
+}
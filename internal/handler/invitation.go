@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateProjectInvitation(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateProjectInvitation")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var invitation models.ProjectInvitationToCreate
+	if err = c.BindJSON(&invitation); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if invitation.Role == "" {
+		invitation.Role = models.ProjectRoleMember
+	}
+
+	var inviteeID uint64
+	invitee, err := h.svc.User.GetUserByEmail(c, invitation.Email)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if invitee != nil {
+		inviteeID = invitee.ID
+	}
+
+	if err = h.checkProjectUserManagement(c, id, inviteeID, invitation.Role); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if invitee != nil {
+		projectUser, err := h.svc.Project.GetProjectUser(c, id, invitee.ID)
+		if err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		if projectUser != nil {
+			h.newErrorResponse(
+				c, http.StatusBadRequest, ierrors.NewBusiness(ErrUserIsAlreadyProjectMember, ""),
+			)
+			return
+		}
+	}
+
+	project, err := h.svc.Project.GetProjectByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if project == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrProjectNotFound, ""))
+		return
+	}
+
+	inviterID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	invitationToken, err := h.svc.Verification.CreateProjectInvitationToken(models.ProjectInvitation{
+		ProjectID: id,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		InviterID: inviterID,
+	})
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// send token by email
+	h.svc.Mailer.SendProjectInvitation(invitation.Email, project.Name, invitationToken)
+
+	c.Status(http.StatusOK)
+}
+
+// AcceptProjectInvitation adds invited user to the project.
+// If there is no user with invited email yet, it responds that sign up is required.
+// Sign up with invitationToken query param accepts the invitation after creating the user.
+func (h *Handler) AcceptProjectInvitation(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "AcceptProjectInvitation")
+
+	token, ok := c.GetQuery("token")
+	if !ok || token == "" {
+		h.newErrorResponse(
+			c, http.StatusBadRequest, ierrors.NewBusiness(ErrEmptyTokenParameter, ""),
+		)
+		return
+	}
+
+	invitation, err := h.svc.Verification.GetProjectInvitation(token)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.svc.User.GetUserByEmail(c, invitation.Email)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user == nil {
+		c.JSON(http.StatusOK, map[string]interface{}{
+			"signUpRequired": true,
+			"email":          invitation.Email,
+		})
+		return
+	}
+
+	if err = h.acceptProjectInvitation(c, token, invitation, user.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"projectId": invitation.ProjectID,
+	})
+}
+
+func (h *Handler) DeclineProjectInvitation(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeclineProjectInvitation")
+
+	token, ok := c.GetQuery("token")
+	if !ok || token == "" {
+		h.newErrorResponse(
+			c, http.StatusBadRequest, ierrors.NewBusiness(ErrEmptyTokenParameter, ""),
+		)
+		return
+	}
+
+	if _, err := h.svc.Verification.GetProjectInvitation(token); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.svc.Verification.DeleteProjectInvitationToken(token); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) acceptProjectInvitation(
+	c *gin.Context, token string, invitation *models.ProjectInvitation, userID uint64,
+) error {
+	if err := h.svc.Project.AddUserToProject(c, invitation.ProjectID, userID, invitation.Role); err != nil {
+		return err
+	}
+
+	h.notifyProjectMemberAdded(c, invitation.ProjectID, userID)
+
+	return h.svc.Verification.DeleteProjectInvitationToken(token)
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateLabel(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateLabel")
+
+	var label models.LabelToCreate
+	if err := c.BindJSON(&label); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.checkProjectPermission(c, label.ProjectID, models.ProjectPermissionCreateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.Label.Create(c, label)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetLabelByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetLabelByID")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	label, err := h.svc.Label.GetByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if label == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, label.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+func (h *Handler) UpdateLabel(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateLabel")
+
+	var label models.Label
+	if err := c.BindJSON(&label); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := h.checkLabelProjectPermission(c, label.ID, models.ProjectPermissionUpdateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.Label.Update(c, label); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) GetAllLabelsToProject(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllLabelsToProject")
+
+	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	labels, err := h.svc.Label.GetAllToProject(c, projectID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if labels == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+func (h *Handler) DeleteLabel(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteLabel")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkLabelProjectPermission(c, id, models.ProjectPermissionDeleteStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Label.Delete(c, id); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) GetAllTaskLabels(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllTaskLabels")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	labels, err := h.svc.Label.GetAllToTask(c, taskID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if labels == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+func (h *Handler) AddLabelToTask(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "AddLabelToTask")
+
+	taskID, labelID, projectID, ok := h.getTaskLabelByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Label.AddToTask(c, taskID, labelID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.publishTaskLabelsChanged(c, projectID, taskID)
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteLabelFromTask(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteLabelFromTask")
+
+	taskID, labelID, projectID, ok := h.getTaskLabelByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Label.DeleteFromTask(c, taskID, labelID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.publishTaskLabelsChanged(c, projectID, taskID)
+
+	c.Status(http.StatusOK)
+}
+
+// getTaskLabelByParams gets task id and label id params, checks that user can update the task
+// and that label belongs to the task project. On failure it writes error response and returns false.
+func (h *Handler) getTaskLabelByParams(c *gin.Context) (taskID uint64, labelID int64, projectID uint64, ok bool) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return 0, 0, 0, false
+	}
+
+	labelID, err = strconv.ParseInt(c.Param("labelId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidLabelIDParameter)
+		return 0, 0, 0, false
+	}
+
+	projectID, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionUpdateTask)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return 0, 0, 0, false
+	}
+
+	label, err := h.svc.Label.GetByID(c, labelID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return 0, 0, 0, false
+	}
+
+	if label == nil || label.ProjectID != projectID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrLabelNotFound, ""))
+		return 0, 0, 0, false
+	}
+
+	return taskID, labelID, projectID, true
+}
+
+// publishTaskLabelsChanged publishes current labels of the task to project board subscribers.
+func (h *Handler) publishTaskLabelsChanged(c *gin.Context, projectID, taskID uint64) {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return
+	}
+
+	labels, err := h.svc.Label.GetAllToTask(c, taskID)
+	if err != nil {
+		h.getLogEntry(c).Errorf("failed to get task labels: %v", err)
+		return
+	}
+
+	if labels == nil {
+		labels = []models.Label{}
+	}
+
+	h.svc.BoardEvents.PublishBoardEvent(projectID, actorID, models.BoardEventTaskUpdated, map[string]interface{}{
+		"id":     taskID,
+		"labels": labels,
+	})
+}
+
+// checkLabelProjectPermission checks that user from context has the permission in the label project
+// and returns project id.
+func (h *Handler) checkLabelProjectPermission(
+	c *gin.Context, id int64, permission models.ProjectPermission,
+) (uint64, error) {
+	label, err := h.svc.Label.GetByID(c, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if label == nil {
+		return 0, ierrors.NewBusiness(ErrLabelNotFound, "")
+	}
+
+	if err = h.checkProjectPermission(c, label.ProjectID, permission); err != nil {
+		return 0, err
+	}
+
+	return label.ProjectID, nil
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/service"
+)
+
+type fakeTaskService struct {
+	service.Task
+	tasks map[uint64]models.Task
+}
+
+func (s *fakeTaskService) GetTaskByID(_ context.Context, id uint64) (*models.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &task, nil
+}
+
+// fakeProjectAccessService allows any action.
+type fakeProjectAccessService struct {
+	service.ProjectAccess
+}
+
+func (s *fakeProjectAccessService) CheckProjectPermission(
+	_ context.Context, _, _ uint64, _ models.ProjectPermission,
+) error {
+	return nil
+}
+
+type fakeLabelService struct {
+	service.Label
+	labels map[int64]models.Label
+	// taskLabelIDs are ids of labels added to tasks by task ids
+	taskLabelIDs map[uint64][]int64
+}
+
+func (s *fakeLabelService) GetByID(_ context.Context, id int64) (*models.Label, error) {
+	label, ok := s.labels[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &label, nil
+}
+
+func (s *fakeLabelService) AddToTask(_ context.Context, taskID uint64, labelID int64) error {
+	s.taskLabelIDs[taskID] = append(s.taskLabelIDs[taskID], labelID)
+	return nil
+}
+
+func (s *fakeLabelService) GetAllToTask(_ context.Context, _ uint64) ([]models.Label, error) {
+	return nil, nil
+}
+
+type fakeBoardEventsService struct {
+	service.BoardEvents
+}
+
+func (s *fakeBoardEventsService) PublishBoardEvent(_, _ uint64, _ models.BoardEventType, _ interface{}) {
+}
+
+func TestHandler_AddLabelToTask(t *testing.T) {
+	const projectID, otherProjectID, taskID = 1, 2, 10
+	const labelID, otherProjectLabelID = 100, 200
+
+	tests := []struct {
+		name       string
+		labelID    int64
+		wantStatus int
+	}{
+		{
+			name:       "label of task project",
+			labelID:    labelID,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "label of other project",
+			labelID:    otherProjectLabelID,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not existing label",
+			labelID:    300,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := &fakeLabelService{
+				labels: map[int64]models.Label{
+					labelID:             {ID: labelID, ProjectID: projectID},
+					otherProjectLabelID: {ID: otherProjectLabelID, ProjectID: otherProjectID},
+				},
+				taskLabelIDs: make(map[uint64][]int64),
+			}
+			h := newTestHandler()
+			h.svc = &service.Service{
+				Task:          &fakeTaskService{tasks: map[uint64]models.Task{taskID: {ID: taskID, ProjectID: projectID}}},
+				ProjectAccess: &fakeProjectAccessService{},
+				Label:         labels,
+				BoardEvents:   &fakeBoardEventsService{},
+			}
+
+			c, w := newTestContext(httptest.NewRequest(http.MethodPut, "/api/v1/tasks/labels", nil))
+			c.Set(ctxUserID, uint64(1))
+			c.Params = gin.Params{
+				{Key: "id", Value: strconv.Itoa(taskID)},
+				{Key: "labelId", Value: strconv.FormatInt(tt.labelID, 10)},
+			}
+
+			h.AddLabelToTask(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			wantLabelsNum := 0
+			if tt.wantStatus == http.StatusOK {
+				wantLabelsNum = 1
+			}
+
+			if len(labels.taskLabelIDs[taskID]) != wantLabelsNum {
+				t.Errorf("task labels = %v, want %d labels", labels.taskLabelIDs[taskID], wantLabelsNum)
+			}
+		})
+	}
+}
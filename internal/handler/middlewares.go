@@ -22,10 +22,11 @@ func CORS(h http.Handler) http.Handler {
 		origin := r.Header.Get("Origin")
 		w.Header().Set("Access-Control-Allow-Origin", origin)
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 		if r.Method == http.MethodOptions {
 			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, If-Match")
 			return
 		} else {
 			h.ServeHTTP(w, r)
@@ -52,6 +53,17 @@ func (h *Handler) UserAuthorizationMiddleware(c *gin.Context) {
 	c.Next()
 }
 
+// AdminAuthorizationMiddleware allows request only to system administrator.
+// It has to be used after UserAuthorizationMiddleware.
+func (h *Handler) AdminAuthorizationMiddleware(c *gin.Context) {
+	if err := h.checkUserIsAdmin(c); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Next()
+}
+
 // validateTokenCookieAndRefreshIfNeeded gets accessToken from cookie and validate it.
 // on success it puts accessToken data to ctx and returns nil.
 // else it tries to refresh session by refresh token from cookie:
@@ -152,6 +164,10 @@ func (h *Handler) validateUserID(c *gin.Context, userID uint64) error {
 		return ErrUserNotFound
 	}
 
+	if user.IsDisabled {
+		return ErrUserIsDisabled
+	}
+
 	return nil
 }
 
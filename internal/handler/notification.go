@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) GetNotificationSettings(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetNotificationSettings")
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	settings, err := h.svc.Notification.GetNotificationSettings(c, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if settings == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (h *Handler) UpdateNotificationSettings(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateNotificationSettings")
+
+	var settings models.NotificationSettings
+	if err := c.BindJSON(&settings); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	settings.UserID = userID
+
+	if err = h.svc.Notification.UpdateNotificationSettings(c, settings); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// getPageParams returns list page params from limit, cursor, sort and withTotal query params.
+func getPageParams(c *gin.Context) (models.PageParams, error) {
+	limit, err := getLimitQueryParam(c)
+	if err != nil {
+		return models.PageParams{}, err
+	}
+
+	var withTotal bool
+	if withTotalStr := c.Query("withTotal"); withTotalStr != "" {
+		if withTotal, err = strconv.ParseBool(withTotalStr); err != nil {
+			return models.PageParams{}, ErrNotValidWithTotalQueryParam
+		}
+	}
+
+	return models.PageParams{
+		Limit:     limit,
+		Cursor:    c.Query("cursor"),
+		Sort:      c.Query("sort"),
+		WithTotal: withTotal,
+	}, nil
+}
+
+// getLimitQueryParam returns limit query param. Zero means default limit.
+func getLimitQueryParam(c *gin.Context) (int, error) {
+	limitStr, ok := c.GetQuery("limit")
+	if !ok || limitStr == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		return 0, ErrNotValidLimitQueryParam
+	}
+
+	return limit, nil
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
 	"github.com/l-orlov/task-tracker/internal/models"
 )
 
@@ -15,6 +16,11 @@ func (h *Handler) CreateProgressStatus(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkProjectPermission(c, status.ProjectID, models.ProjectPermissionCreateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	id, err := h.svc.ProgressStatus.Create(c, status)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -44,6 +50,11 @@ func (h *Handler) GetProgressStatusByID(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, status.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, status)
 }
 
@@ -54,6 +65,11 @@ func (h *Handler) UpdateProgressStatus(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkProgressStatusProjectPermission(c, status.ID, models.ProjectPermissionUpdateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	if err := h.svc.ProgressStatus.Update(c, status); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -84,6 +100,11 @@ func (h *Handler) GetAllProgressStatusesToProject(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	statuses, err := h.svc.ProgressStatus.GetAllToProject(c, projectID)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -105,10 +126,31 @@ func (h *Handler) DeleteProgressStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ProgressStatus.Delete(c, id); err != nil {
+	if err = h.checkProgressStatusProjectPermission(c, id, models.ProjectPermissionDeleteStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.ProgressStatus.Delete(c, id); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
+
+// checkProgressStatusProjectPermission checks that user from context has the permission in the status project.
+func (h *Handler) checkProgressStatusProjectPermission(
+	c *gin.Context, id int64, permission models.ProjectPermission,
+) error {
+	status, err := h.svc.ProgressStatus.GetByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if status == nil {
+		return ierrors.NewBusiness(ErrProgressStatusNotFound, "")
+	}
+
+	return h.checkProjectPermission(c, status.ProjectID, permission)
+}
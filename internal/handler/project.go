@@ -39,6 +39,11 @@ func (h *Handler) GetProjectByID(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	project, err := h.svc.Project.GetProjectByID(c, id)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -53,6 +58,30 @@ func (h *Handler) GetProjectByID(c *gin.Context) {
 	c.JSON(http.StatusOK, project)
 }
 
+// GetProjectTaskCounts returns counts of completed and open tasks of the project.
+func (h *Handler) GetProjectTaskCounts(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetProjectTaskCounts")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	counts, err := h.svc.Project.GetProjectTaskCounts(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
 func (h *Handler) GetProjectByIDToUser(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -60,6 +89,11 @@ func (h *Handler) GetProjectByIDToUser(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	project, err := h.svc.Project.GetProjectByID(c, id)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -81,6 +115,11 @@ func (h *Handler) UpdateProject(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkProjectPermission(c, project.ID, models.ProjectPermissionUpdateProject); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	if err := h.svc.Project.UpdateProject(c, project); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -90,18 +129,19 @@ func (h *Handler) UpdateProject(c *gin.Context) {
 }
 
 func (h *Handler) GetAllProjects(c *gin.Context) {
-	projects, err := h.svc.Project.GetAllProjects(c)
+	pageParams, err := getPageParams(c)
 	if err != nil {
-		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		h.newErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if projects == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.Project.GetAllProjects(c, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, projects)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) GetAllProjectsToUser(c *gin.Context) {
@@ -111,18 +151,19 @@ func (h *Handler) GetAllProjectsToUser(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.svc.Project.GetAllProjectsToUser(c, userID)
+	pageParams, err := getPageParams(c)
 	if err != nil {
-		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		h.newErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if projects == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.Project.GetAllProjectsToUser(c, userID, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, projects)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) GetAllProjectsWithParameters(c *gin.Context) {
@@ -132,18 +173,19 @@ func (h *Handler) GetAllProjectsWithParameters(c *gin.Context) {
 		return
 	}
 
-	projects, err := h.svc.Project.GetAllProjectsWithParameters(c, params)
+	pageParams, err := getPageParams(c)
 	if err != nil {
-		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		h.newErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if projects == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.Project.GetAllProjectsWithParameters(c, params, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, projects)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) DeleteProject(c *gin.Context) {
@@ -153,7 +195,12 @@ func (h *Handler) DeleteProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.Project.DeleteProject(c, id); err != nil {
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionDeleteProject); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Project.DeleteProject(c, id); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
@@ -174,11 +221,20 @@ func (h *Handler) AddUserToProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.Project.AddUserToProject(c, id, userID); err != nil {
+	role := models.ProjectRole(c.Query("role"))
+
+	if err = h.checkProjectUserManagement(c, id, userID, role); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Project.AddUserToProject(c, id, userID, role); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	h.notifyProjectMemberAdded(c, id, userID)
+
 	c.Status(http.StatusOK)
 }
 
@@ -189,6 +245,11 @@ func (h *Handler) GetAllProjectUsers(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	users, err := h.svc.Project.GetAllProjectUsers(c, id)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -203,6 +264,58 @@ func (h *Handler) GetAllProjectUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, users)
 }
 
+func (h *Handler) UpdateProjectUserRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var user models.ProjectUserRole
+	if err = c.BindJSON(&user); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.checkProjectUserManagement(c, id, user.UserID, user.Role); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Project.UpdateProjectUserRole(c, id, user.UserID, user.Role); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) TransferProjectOwnership(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Query("userId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidUserIDQueryParam)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, id, models.ProjectPermissionTransferOwnership); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Project.TransferProjectOwnership(c, id, userID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
 func (h *Handler) DeleteUserFromProject(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -216,10 +329,32 @@ func (h *Handler) DeleteUserFromProject(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.Project.DeleteUserFromProject(c, id, userID); err != nil {
+	currentUserID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// any member can leave the project
+	if userID == currentUserID {
+		err = h.checkProjectPermission(c, id, models.ProjectPermissionRead)
+	} else {
+		err = h.checkProjectUserManagement(c, id, userID, "")
+	}
+
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	if err = h.svc.Project.DeleteUserFromProject(c, id, userID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.svc.Webhook.NotifyProjectEvent(c, id, models.WebhookEventMemberRemoved, map[string]interface{}{
+		"userId": userID,
+	})
+
 	c.Status(http.StatusOK)
 }
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// checkProjectPermission checks that user from context has the permission in the project.
+func (h *Handler) checkProjectPermission(
+	c *gin.Context, projectID uint64, permission models.ProjectPermission,
+) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	return h.svc.ProjectAccess.CheckProjectPermission(c, projectID, userID, permission)
+}
+
+// checkProjectUserManagement checks that user from context can manage the project user.
+// role is the new role of the project user, it is empty on deleting.
+func (h *Handler) checkProjectUserManagement(
+	c *gin.Context, projectID, userID uint64, role models.ProjectRole,
+) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	return h.svc.ProjectAccess.CheckProjectUserManagement(c, projectID, actorID, userID, role)
+}
+
+// checkProjectBoardPermission resolves the project which given board entities belong to,
+// checks that user from context has the permission in it and returns project id.
+func (h *Handler) checkProjectBoardPermission(
+	c *gin.Context, permission models.ProjectPermission, progressStatusIDs []int64, taskIDs []uint64,
+) (uint64, error) {
+	projectID, err := h.svc.ProjectAccess.GetProjectIDByBoardEntities(c, progressStatusIDs, taskIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = h.checkProjectPermission(c, projectID, permission); err != nil {
+		return 0, err
+	}
+
+	return projectID, nil
+}
+
+func boardTaskIDs(tasks []models.ProjectBoardTask) []uint64 {
+	ids := make([]uint64, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.TaskID)
+	}
+
+	return ids
+}
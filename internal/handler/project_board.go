@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/l-orlov/task-tracker/internal/models"
 )
 
+const boardStreamHeartbeatInterval = 30 * time.Second
+
 func (h *Handler) GetProjectBoard(c *gin.Context) {
 	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
 	if err != nil {
@@ -15,12 +20,36 @@ func (h *Handler) GetProjectBoard(c *gin.Context) {
 		return
 	}
 
-	board, err := h.svc.ProjectBoard.GetProjectBoardBytes(c, projectID)
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// board shows tasks of one sprint if sprint is set
+	var sprintID *uint64
+	if sprintIDStr := c.Query("sprintId"); sprintIDStr != "" {
+		id, err := strconv.ParseUint(sprintIDStr, 10, 64)
+		if err != nil {
+			h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidSprintIDQueryParam)
+			return
+		}
+
+		if err = h.checkSprintInProject(c, id, projectID); err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		sprintID = &id
+	}
+
+	board, version, err := h.svc.ProjectBoard.GetProjectBoardBytes(c, projectID, sprintID)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	setBoardVersionToETag(c, version)
+
 	if board == nil {
 		c.JSON(http.StatusOK, []struct{}{})
 		return
@@ -29,6 +58,65 @@ func (h *Handler) GetProjectBoard(c *gin.Context) {
 	c.Data(200, "application/json", board)
 }
 
+// StreamProjectBoard streams board events of the project as server-sent events.
+// Stream is closed on access token expiration, so client reconnects and session is refreshed.
+func (h *Handler) StreamProjectBoard(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "StreamProjectBoard")
+
+	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	events, unsubscribe := h.svc.BoardEvents.SubscribeBoardEvents(projectID)
+	defer unsubscribe()
+
+	stream, err := newSSEStream(c)
+	if err != nil {
+		h.getLogEntry(c).Debugf("failed to start board stream: %v", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(boardStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	expiration := time.NewTimer(time.Duration(h.options.AccessTokenCookieMaxAge) * time.Second)
+	defer expiration.Stop()
+
+	for {
+		select {
+		case <-stream.Done():
+			return
+		case <-h.streamsDone:
+			return
+		case <-expiration.C:
+			return
+		case event := <-events:
+			if err = stream.Send(event); err != nil {
+				h.getLogEntry(c).Debugf("failed to send board event: %v", err)
+				return
+			}
+		case <-heartbeat.C:
+			// user can be removed from the project while streaming
+			if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+				h.getLogEntry(c).Debugf("board stream is closed: %v", err)
+				return
+			}
+
+			if err = stream.Ping(); err != nil {
+				h.getLogEntry(c).Debugf("failed to ping board stream: %v", err)
+				return
+			}
+		}
+	}
+}
+
 func (h *Handler) UpdateProjectBoardParts(c *gin.Context) {
 	var board models.ProjectBoard
 	if err := c.BindJSON(&board); err != nil {
@@ -36,11 +124,48 @@ func (h *Handler) UpdateProjectBoardParts(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ProjectBoard.UpdateProjectBoardParts(c, board); err != nil {
+	expectedVersion, ok := h.getBoardVersionFromIfMatch(c)
+	if !ok {
+		return
+	}
+
+	progressStatusIDs := make([]int64, 0, len(board))
+	var taskIDs []uint64
+	for _, part := range board {
+		progressStatusIDs = append(progressStatusIDs, part.ProgressStatusId)
+		taskIDs = append(taskIDs, boardTaskIDs(part.Tasks)...)
+	}
+
+	projectID, err := h.checkProjectBoardPermission(
+		c, models.ProjectPermissionUpdateBoardOrder, progressStatusIDs, taskIDs,
+	)
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	version, err := h.svc.ProjectBoard.UpdateProjectBoardParts(c, projectID, board, expectedVersion, actorID)
+	if err != nil {
+		if isConflictError(err) {
+			h.newProjectBoardConflictResponse(c, projectID, err)
+			return
+		}
+
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	setBoardVersionToETag(c, version)
+
+	h.svc.Webhook.NotifyProjectEvent(c, projectID, models.WebhookEventTaskMoved, board)
+	h.svc.BoardEvents.PublishBoardEvent(projectID, actorID, models.BoardEventTaskMoved, board)
+
 	c.Status(http.StatusOK)
 }
 
@@ -51,11 +176,45 @@ func (h *Handler) UpdateProjectBoardProgressStatuses(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ProjectBoard.UpdateProjectBoardProgressStatuses(c, statuses); err != nil {
+	expectedVersion, ok := h.getBoardVersionFromIfMatch(c)
+	if !ok {
+		return
+	}
+
+	progressStatusIDs := make([]int64, 0, len(statuses))
+	for _, status := range statuses {
+		progressStatusIDs = append(progressStatusIDs, status.ProgressStatusId)
+	}
+
+	projectID, err := h.checkProjectBoardPermission(
+		c, models.ProjectPermissionUpdateStatus, progressStatusIDs, nil,
+	)
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	version, err := h.svc.ProjectBoard.UpdateProjectBoardProgressStatuses(c, projectID, statuses, expectedVersion)
+	if err != nil {
+		if isConflictError(err) {
+			h.newProjectBoardConflictResponse(c, projectID, err)
+			return
+		}
+
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	setBoardVersionToETag(c, version)
+
+	h.svc.BoardEvents.PublishBoardEvent(projectID, actorID, models.BoardEventStatusesReordered, statuses)
+
 	c.Status(http.StatusOK)
 }
 
@@ -66,10 +225,76 @@ func (h *Handler) UpdateProjectBoardProgressStatusTasks(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.ProjectBoard.UpdateProjectBoardProgressStatusTasks(c, tasks); err != nil {
+	expectedVersion, ok := h.getBoardVersionFromIfMatch(c)
+	if !ok {
+		return
+	}
+
+	projectID, err := h.checkProjectBoardPermission(
+		c, models.ProjectPermissionUpdateBoardOrder, nil, boardTaskIDs(tasks),
+	)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	version, err := h.svc.ProjectBoard.UpdateProjectBoardProgressStatusTasks(
+		c, projectID, tasks, expectedVersion, actorID,
+	)
+	if err != nil {
+		if isConflictError(err) {
+			h.newProjectBoardConflictResponse(c, projectID, err)
+			return
+		}
+
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	setBoardVersionToETag(c, version)
+
+	h.svc.Webhook.NotifyProjectEvent(c, projectID, models.WebhookEventTaskMoved, tasks)
+	h.svc.BoardEvents.PublishBoardEvent(projectID, actorID, models.BoardEventTaskMoved, tasks)
+
 	c.Status(http.StatusOK)
 }
+
+// newProjectBoardConflictResponse responds with current state of project board which was changed by another request.
+func (h *Handler) newProjectBoardConflictResponse(c *gin.Context, projectID uint64, err error) {
+	board, version, getErr := h.svc.ProjectBoard.GetProjectBoardBytes(c, projectID, nil)
+	if getErr != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, getErr)
+		return
+	}
+
+	setBoardVersionToETag(c, version)
+	h.newConflictResponse(c, err, json.RawMessage(board))
+}
+
+// getBoardVersionFromIfMatch returns expected version of project board from If-Match header.
+// The header is required, so board is not overwritten by client which has not seen its current version.
+func (h *Handler) getBoardVersionFromIfMatch(c *gin.Context) (*uint64, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		h.newErrorResponse(c, http.StatusPreconditionRequired, ErrEmptyIfMatchHeader)
+		return nil, false
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(ifMatch, `"`), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIfMatchHeader)
+		return nil, false
+	}
+
+	return &version, true
+}
+
+func setBoardVersionToETag(c *gin.Context, version uint64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatUint(version, 10)))
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestHandler() *Handler {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	return &Handler{log: log}
+}
+
+func newTestContext(req *http.Request) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	return c, w
+}
+
+func TestHandler_getBoardVersionFromIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		wantVersion uint64
+		wantOK      bool
+		wantStatus  int
+	}{
+		{
+			name:       "missing header",
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "any version",
+			ifMatch:    "*",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not valid version",
+			ifMatch:    `"abc"`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "quoted version",
+			ifMatch:     `"42"`,
+			wantVersion: 42,
+			wantOK:      true,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "not quoted version",
+			ifMatch:     "7",
+			wantVersion: 7,
+			wantOK:      true,
+			wantStatus:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/project-board/parts", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			c, w := newTestContext(req)
+
+			version, ok := newTestHandler().getBoardVersionFromIfMatch(c)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			if tt.wantOK && *version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", *version, tt.wantVersion)
+			}
+		})
+	}
+}
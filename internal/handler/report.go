@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+	// csvFormulaChars are first characters of cell which make spreadsheet applications treat it as formula
+	csvFormulaChars = "=+-@\t\r"
+)
+
+// GetCumulativeFlowReport returns numbers of project tasks in each progress status by days.
+func (h *Handler) GetCumulativeFlowReport(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetCumulativeFlowReport")
+
+	projectID, period, format, err := h.getProjectReportParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report, err := h.svc.Report.GetCumulativeFlow(c, projectID, period)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format != reportFormatCSV {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	header := []string{"date"}
+	for _, status := range report.Statuses {
+		header = append(header, status.Name)
+	}
+
+	records := [][]string{header}
+	for _, day := range report.Days {
+		record := []string{day.Date.String()}
+		for _, count := range day.Counts {
+			record = append(record, strconv.FormatInt(count, 10))
+		}
+
+		records = append(records, record)
+	}
+
+	h.writeCSVResponse(c, "cumulative-flow", records)
+}
+
+// GetLeadCycleTimeReport returns lead and cycle time percentiles of tasks completed in the period.
+func (h *Handler) GetLeadCycleTimeReport(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetLeadCycleTimeReport")
+
+	projectID, period, format, err := h.getProjectReportParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report, err := h.svc.Report.GetLeadCycleTime(c, projectID, period)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format != reportFormatCSV {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	records := [][]string{
+		{"metric", "count", "p50Hours", "p75Hours", "p85Hours", "p95Hours"},
+		durationPercentilesRecord("leadTime", report.LeadTime),
+		durationPercentilesRecord("cycleTime", report.CycleTime),
+	}
+
+	h.writeCSVResponse(c, "lead-cycle-time", records)
+}
+
+// GetThroughputReport returns numbers of tasks completed in the period by weeks.
+func (h *Handler) GetThroughputReport(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetThroughputReport")
+
+	projectID, period, format, err := h.getProjectReportParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report, err := h.svc.Report.GetThroughput(c, projectID, period)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format != reportFormatCSV {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	records := [][]string{{"weekStart", "completed"}}
+	for _, week := range report.Weeks {
+		records = append(records, []string{week.WeekStart.String(), strconv.FormatInt(week.Completed, 10)})
+	}
+
+	h.writeCSVResponse(c, "throughput", records)
+}
+
+// getProjectReportParams returns project id, period and format of report
+// after checking that user can read the project.
+func (h *Handler) getProjectReportParams(c *gin.Context) (uint64, models.ReportPeriod, string, error) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, models.ReportPeriod{}, "", ierrors.NewBusiness(ErrNotValidIDParameter, "")
+	}
+
+	period, format, err := getReportParams(c)
+	if err != nil {
+		return 0, models.ReportPeriod{}, "", err
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		return 0, models.ReportPeriod{}, "", err
+	}
+
+	return projectID, period, format, nil
+}
+
+// getReportParams returns period from `from` and `to` query params and format of report from `format` query param.
+// Period bounds are not set if query params are empty.
+func getReportParams(c *gin.Context) (models.ReportPeriod, string, error) {
+	var period models.ReportPeriod
+	var err error
+	if from := c.Query("from"); from != "" {
+		if period.From, err = models.ParseDate(from); err != nil {
+			return models.ReportPeriod{}, "", ierrors.NewBusiness(ErrNotValidFromQueryParam, "")
+		}
+	}
+
+	if to := c.Query("to"); to != "" {
+		if period.To, err = models.ParseDate(to); err != nil {
+			return models.ReportPeriod{}, "", ierrors.NewBusiness(ErrNotValidToQueryParam, "")
+		}
+	}
+
+	format := c.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatCSV {
+		return models.ReportPeriod{}, "", ierrors.NewBusiness(ErrNotValidFormatQueryParam, "")
+	}
+
+	return period, format, nil
+}
+
+// writeCSVResponse responds with csv file of the records. Cells are escaped by escapeCSVCell.
+func (h *Handler) writeCSVResponse(c *gin.Context, fileName string, records [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, fileName))
+	c.Status(http.StatusOK)
+
+	for _, record := range records {
+		for i := range record {
+			record[i] = escapeCSVCell(record[i])
+		}
+	}
+
+	if err := csv.NewWriter(c.Writer).WriteAll(records); err != nil {
+		h.getLogEntry(c).Errorf("failed to write csv: %v", err)
+	}
+}
+
+// escapeCSVCell prefixes cell starting with formula character with quote,
+// so spreadsheet applications show user input like task title as text instead of running it as formula.
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune(csvFormulaChars, rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+func durationPercentilesRecord(metric string, percentiles models.DurationPercentiles) []string {
+	record := []string{metric, strconv.FormatInt(percentiles.Count, 10)}
+	for _, p := range []*float64{percentiles.P50, percentiles.P75, percentiles.P85, percentiles.P95} {
+		if p == nil {
+			record = append(record, "")
+			continue
+		}
+
+		record = append(record, strconv.FormatFloat(*p, 'f', 2, 64))
+	}
+
+	return record
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEscapeCSVCell(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{cell: "", want: ""},
+		{cell: "Task", want: "Task"},
+		{cell: "42", want: "42"},
+		{cell: "a=b", want: "a=b"},
+		{cell: "=HYPERLINK(\"http://example.com\")", want: "'=HYPERLINK(\"http://example.com\")"},
+		{cell: "+1", want: "'+1"},
+		{cell: "-1+2", want: "'-1+2"},
+		{cell: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{cell: "\t=1", want: "'\t=1"},
+		{cell: "\r=1", want: "'\r=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			if got := escapeCSVCell(tt.cell); got != tt.want {
+				t.Errorf("escapeCSVCell(%q) = %q, want %q", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler_writeCSVResponse(t *testing.T) {
+	c, w := newTestContext(httptest.NewRequest(http.MethodGet, "/api/v1/reports/timesheet?format=csv", nil))
+
+	newTestHandler().writeCSVResponse(c, "timesheet", [][]string{
+		{"userId", "firstName", "lastName"},
+		{"1", "=cmd|' /C calc'!A0", "Smith, Jr."},
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="timesheet.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	want := "userId,firstName,lastName\n1,'=cmd|' /C calc'!A0,\"Smith, Jr.\"\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
@@ -8,10 +8,16 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-type errorResponse struct {
-	Message string `json:"message"`
-	Detail  string `json:"detail"`
-}
+type (
+	errorResponse struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	conflictResponse struct {
+		errorResponse
+		Current interface{} `json:"current"`
+	}
+)
 
 func (h *Handler) newErrorResponse(c *gin.Context, statusCode int, err error) {
 	logEntry := h.getLogEntry(c)
@@ -27,10 +33,17 @@ func (h *Handler) newErrorResponse(c *gin.Context, statusCode int, err error) {
 func handleCustomError(c *gin.Context, logEntry *logrus.Entry, err *ierrors.Error) {
 	var statusCode int
 
-	if err.Level == ierrors.Business {
+	switch err.Level {
+	case ierrors.Business:
 		logEntry.Debug(err)
 		statusCode = http.StatusBadRequest
-	} else {
+	case ierrors.Forbidden:
+		logEntry.Debug(err)
+		statusCode = http.StatusForbidden
+	case ierrors.Conflict:
+		logEntry.Debug(err)
+		statusCode = http.StatusConflict
+	default:
 		logEntry.Error(err)
 		statusCode = http.StatusInternalServerError
 	}
@@ -55,3 +68,27 @@ func handleDefaultError(c *gin.Context, logEntry *logrus.Entry, err error, statu
 
 	c.AbortWithStatusJSON(statusCode, errResp)
 }
+
+// newConflictResponse responds with current state of entity which was changed by another request.
+func (h *Handler) newConflictResponse(c *gin.Context, err error, current interface{}) {
+	h.getLogEntry(c).Debug(err)
+
+	errResp := errorResponse{
+		Message: err.Error(),
+		Detail:  ierrors.DetailConflict,
+	}
+	if customErr, ok := err.(*ierrors.Error); ok {
+		errResp.Detail = customErr.Detail
+	}
+
+	c.AbortWithStatusJSON(http.StatusConflict, &conflictResponse{
+		errorResponse: errResp,
+		Current:       current,
+	})
+}
+
+func isConflictError(err error) bool {
+	customErr, ok := err.(*ierrors.Error)
+
+	return ok && customErr.Level == ierrors.Conflict
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// Search searches tasks, comments and projects of user by q query param.
+// Search can be limited to the project by projectId query param.
+func (h *Handler) Search(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "Search")
+
+	params := models.SearchParams{
+		Query: c.Query("q"),
+	}
+
+	if projectIDStr := c.Query("projectId"); projectIDStr != "" {
+		projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
+		if err != nil {
+			h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+			return
+		}
+
+		if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		params.ProjectID = &projectID
+	}
+
+	limit, err := getLimitQueryParam(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	params.Limit = limit
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	results, err := h.svc.Search.Search(c, userID, params)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
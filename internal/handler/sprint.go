@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateSprint(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateSprint")
+
+	var sprint models.SprintToCreate
+	if err := c.BindJSON(&sprint); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.checkProjectPermission(c, sprint.ProjectID, models.ProjectPermissionManageSprints); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.Sprint.CreateSprint(c, sprint)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetSprintByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetSprintByID")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	sprint, err := h.svc.Sprint.GetSprintByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if sprint == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, sprint.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (h *Handler) UpdateSprint(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateSprint")
+
+	var sprint models.SprintToUpdate
+	if err := c.BindJSON(&sprint); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := h.getSprintWithPermission(c, sprint.ID, models.ProjectPermissionManageSprints); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.Sprint.UpdateSprint(c, sprint); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) GetAllSprintsToProject(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllSprintsToProject")
+
+	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	sprints, err := h.svc.Sprint.GetAllProjectSprints(c, projectID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if sprints == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprints)
+}
+
+// DeleteSprint deletes sprint and moves its tasks to the backlog.
+func (h *Handler) DeleteSprint(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteSprint")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.getSprintWithPermission(c, id, models.ProjectPermissionManageSprints); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Sprint.DeleteSprint(c, id); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) StartSprint(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "StartSprint")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	sprint, err := h.getSprintWithPermission(c, id, models.ProjectPermissionManageSprints)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Sprint.StartSprint(c, *sprint); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// CloseSprint closes active sprint. Unfinished tasks are moved to the next planned sprint by default,
+// or to the backlog if moveTo query param is "backlog".
+func (h *Handler) CloseSprint(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CloseSprint")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	sprint, err := h.getSprintWithPermission(c, id, models.ProjectPermissionManageSprints)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	moveTo := models.SprintMoveTo(c.DefaultQuery("moveTo", string(models.SprintMoveToNext)))
+
+	result, err := h.svc.Sprint.CloseSprint(c, *sprint, moveTo)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	for _, taskID := range result.MovedTaskIDs {
+		h.svc.BoardEvents.PublishBoardEvent(sprint.ProjectID, actorID, models.BoardEventTaskUpdated,
+			map[string]interface{}{
+				"id":       taskID,
+				"sprintId": result.NextSprintID,
+			})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) GetAllSprintTasks(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllSprintTasks")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.getSprintWithPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	tasks, err := h.svc.Sprint.GetAllSprintTasks(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if tasks == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetSprintBurndown returns numbers of total and remaining sprint tasks by days of the sprint.
+func (h *Handler) GetSprintBurndown(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetSprintBurndown")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	sprint, err := h.getSprintWithPermission(c, id, models.ProjectPermissionRead)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	burndown, err := h.svc.Sprint.GetSprintBurndown(c, *sprint)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, burndown)
+}
+
+// getSprintWithPermission returns sprint if user from context has the permission in the sprint project.
+func (h *Handler) getSprintWithPermission(
+	c *gin.Context, id uint64, permission models.ProjectPermission,
+) (*models.Sprint, error) {
+	sprint, err := h.svc.Sprint.GetSprintByID(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint == nil {
+		return nil, ierrors.NewBusiness(ErrSprintNotFound, "")
+	}
+
+	if err = h.checkProjectPermission(c, sprint.ProjectID, permission); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+// checkSprintInProject checks that sprint belongs to the project.
+func (h *Handler) checkSprintInProject(c *gin.Context, sprintID, projectID uint64) error {
+	sprint, err := h.svc.Sprint.GetSprintByID(c, sprintID)
+	if err != nil {
+		return err
+	}
+
+	if sprint == nil || sprint.ProjectID != projectID {
+		return ierrors.NewBusiness(ErrSprintNotFound, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const sseRetryInterval = "1000"
+
+// sseStream is the server-sent events stream written to the response. Every event is flushed to client.
+type sseStream struct {
+	c *gin.Context
+}
+
+// newSSEStream writes stream response headers. Headers already set to the response (cookies, CORS) are kept.
+func newSSEStream(c *gin.Context) (*sseStream, error) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	// proxies like nginx should not buffer events
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	s := &sseStream{c: c}
+	if err := s.write("retry: " + sseRetryInterval + "\n\n"); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Done returns channel which is closed when client closes connection or server is shut down.
+func (s *sseStream) Done() <-chan struct{} {
+	return s.c.Request.Context().Done()
+}
+
+// Send sends data as the message event.
+func (s *sseStream) Send(data []byte) error {
+	return s.write("data: " + string(data) + "\n\n")
+}
+
+// Ping sends comment to keep connection alive.
+func (s *sseStream) Ping() error {
+	return s.write(": ping\n\n")
+}
+
+func (s *sseStream) write(event string) error {
+	if _, err := io.WriteString(s.c.Writer, event); err != nil {
+		return err
+	}
+
+	s.c.Writer.Flush()
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSSEStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	send := make(chan string)
+	isDone := make(chan struct{})
+
+	router := gin.New()
+	router.GET("/stream", func(c *gin.Context) {
+		defer close(isDone)
+
+		c.Header("Set-Cookie", "a=b")
+
+		stream, err := newSSEStream(c)
+		if err != nil {
+			t.Errorf("newSSEStream() error = %v", err)
+			return
+		}
+
+		for {
+			select {
+			case <-stream.Done():
+				return
+			case data := <-send:
+				if err = stream.Send([]byte(data)); err != nil {
+					t.Errorf("Send() error = %v", err)
+					return
+				}
+
+				if err = stream.Ping(); err != nil {
+					t.Errorf("Ping() error = %v", err)
+					return
+				}
+			}
+		}
+	})
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+
+	if got := resp.Header.Get("Set-Cookie"); got != "a=b" {
+		t.Errorf("header set before stream is not kept: Set-Cookie = %q", got)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+
+	// events are flushed, so they are read before response is finished
+	wantLines := []string{"retry: " + sseRetryInterval, "", `data: {"id":1}`, "", ": ping", ""}
+	send <- `{"id":1}`
+
+	for _, want := range wantLines {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+
+		if got := strings.TrimSuffix(line, "\n"); got != want {
+			t.Fatalf("line = %q, want %q", got, want)
+		}
+	}
+
+	// stream is ended when client closes connection
+	cancel()
+
+	select {
+	case <-isDone:
+	case <-time.After(time.Second):
+		t.Fatal("stream is not ended after client closed connection")
+	}
+}
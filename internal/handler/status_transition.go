@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateStatusTransition(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateStatusTransition")
+
+	var transition models.StatusTransitionToCreate
+	if err := c.BindJSON(&transition); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.checkProjectPermission(c, transition.ProjectID, models.ProjectPermissionCreateStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.StatusTransition.Create(c, transition)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetStatusTransitionByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetStatusTransitionByID")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	transition, err := h.svc.StatusTransition.GetByID(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if transition == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, transition.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transition)
+}
+
+func (h *Handler) UpdateStatusTransition(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateStatusTransition")
+
+	var transition models.StatusTransitionToUpdate
+	if err := c.BindJSON(&transition); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.checkStatusTransitionProjectPermission(
+		c, transition.ID, models.ProjectPermissionUpdateStatus,
+	); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.StatusTransition.Update(c, transition); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) GetAllStatusTransitionsToProject(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllStatusTransitionsToProject")
+
+	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	transitions, err := h.svc.StatusTransition.GetAllToProject(c, projectID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+func (h *Handler) DeleteStatusTransition(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteStatusTransition")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if err = h.checkStatusTransitionProjectPermission(c, id, models.ProjectPermissionDeleteStatus); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.StatusTransition.Delete(c, id); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// checkStatusTransitionProjectPermission checks that user from context has the permission in the transition project.
+func (h *Handler) checkStatusTransitionProjectPermission(
+	c *gin.Context, id int64, permission models.ProjectPermission,
+) error {
+	transition, err := h.svc.StatusTransition.GetByID(c, id)
+	if err != nil {
+		return err
+	}
+
+	if transition == nil {
+		return ierrors.NewBusiness(ErrStatusTransitionNotFound, "")
+	}
+
+	return h.checkProjectPermission(c, transition.ProjectID, permission)
+}
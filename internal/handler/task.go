@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
 	"github.com/l-orlov/task-tracker/internal/models"
 )
 
@@ -15,12 +16,48 @@ func (h *Handler) CreateTaskToProject(c *gin.Context) {
 		return
 	}
 
+	if err := h.checkProjectPermission(c, task.ProjectID, models.ProjectPermissionCreateTask); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.ProjectAccess.CheckTaskProjectEntities(
+		c, task.ProjectID, task.AssigneeID, task.ImportanceStatusID, task.ProgressStatusID,
+	); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	id, err := h.svc.Task.CreateTaskToProject(c, task)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	createdTask := models.Task{
+		ID:                 id,
+		ProjectID:          task.ProjectID,
+		Title:              task.Title,
+		Description:        task.Description,
+		AssigneeID:         task.AssigneeID,
+		ImportanceStatusID: task.ImportanceStatusID,
+		ProgressStatusID:   task.ProgressStatusID,
+		StartDate:          task.StartDate,
+		DueDate:            task.DueDate,
+		ParentID:           task.ParentID,
+		SprintID:           task.SprintID,
+		OriginalEstimate:   task.OriginalEstimate,
+		RemainingEstimate:  task.RemainingEstimate,
+	}
+	h.svc.Webhook.NotifyProjectEvent(c, task.ProjectID, models.WebhookEventTaskCreated, createdTask)
+	h.svc.BoardEvents.PublishBoardEvent(task.ProjectID, actorID, models.BoardEventTaskCreated, createdTask)
+
 	c.JSON(http.StatusOK, map[string]interface{}{
 		"id": id,
 	})
@@ -44,6 +81,11 @@ func (h *Handler) GetTaskByID(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkProjectPermission(c, task.ProjectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, task)
 }
 
@@ -54,12 +96,46 @@ func (h *Handler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.Task.UpdateTask(c, task); err != nil {
+	projectID, err := h.checkTaskProjectPermission(c, task.ID, models.ProjectPermissionUpdateTask)
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.Status(http.StatusOK)
+	// task can not be moved to another project
+	task.ProjectID = projectID
+
+	if err = h.svc.ProjectAccess.CheckTaskProjectEntities(
+		c, task.ProjectID, task.AssigneeID, task.ImportanceStatusID, task.ProgressStatusID,
+	); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	version, err := h.svc.Task.UpdateTask(c, task, actorID)
+	if err != nil {
+		if isConflictError(err) {
+			h.newTaskConflictResponse(c, task.ID, err)
+			return
+		}
+
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	task.Version = version
+	h.svc.Webhook.NotifyProjectEvent(c, task.ProjectID, models.WebhookEventTaskUpdated, task)
+	h.svc.BoardEvents.PublishBoardEvent(task.ProjectID, actorID, models.BoardEventTaskUpdated, task)
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"version": version,
+	})
 }
 
 func (h *Handler) GetAllTasksToProject(c *gin.Context) {
@@ -69,18 +145,24 @@ func (h *Handler) GetAllTasksToProject(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.svc.Task.GetAllTasksToProject(c, projectID)
+	pageParams, err := getPageParams(c)
 	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	if tasks == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.Task.GetAllTasksToProject(c, projectID, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tasks)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) GetAllTasksWithParameters(c *gin.Context) {
@@ -90,7 +172,29 @@ func (h *Handler) GetAllTasksWithParameters(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.svc.Task.GetAllTasksWithParameters(c, params)
+	pageParams, err := getPageParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.svc.Task.GetAllTasksWithParameters(c, params, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *Handler) GetOverdueTasksToUser(c *gin.Context) {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	tasks, err := h.svc.Task.GetOverdueTasksToUser(c, userID)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -111,10 +215,94 @@ func (h *Handler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.Task.DeleteTask(c, id); err != nil {
+	projectID, err := h.checkTaskProjectPermission(c, id, models.ProjectPermissionDeleteTask)
+	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	policy := models.SubtasksPolicy(c.DefaultQuery("subtasks", string(models.SubtasksPolicyOrphan)))
+
+	deletedIDs, err := h.svc.Task.DeleteTask(c, id, policy)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	for _, deletedID := range deletedIDs {
+		deletedTask := map[string]interface{}{
+			"id": deletedID,
+		}
+		h.svc.Webhook.NotifyProjectEvent(c, projectID, models.WebhookEventTaskDeleted, deletedTask)
+		h.svc.BoardEvents.PublishBoardEvent(projectID, actorID, models.BoardEventTaskDeleted, deletedTask)
+	}
+
 	c.Status(http.StatusOK)
 }
+
+// GetTaskTree returns the task with its subtasks of all levels.
+func (h *Handler) GetTaskTree(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTaskTree")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, id, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	tree, err := h.svc.Task.GetTaskTree(c, id)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if tree == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// newTaskConflictResponse responds with current state of task which was changed by another request.
+func (h *Handler) newTaskConflictResponse(c *gin.Context, taskID uint64, err error) {
+	task, getErr := h.svc.Task.GetTaskByID(c, taskID)
+	if getErr != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, getErr)
+		return
+	}
+
+	h.newConflictResponse(c, err, task)
+}
+
+// checkTaskProjectPermission checks that user from context has the permission in the task project
+// and returns project id.
+func (h *Handler) checkTaskProjectPermission(
+	c *gin.Context, taskID uint64, permission models.ProjectPermission,
+) (uint64, error) {
+	task, err := h.svc.Task.GetTaskByID(c, taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	if task == nil {
+		return 0, ierrors.NewBusiness(ErrTaskNotFound, "")
+	}
+
+	if err = h.checkProjectPermission(c, task.ProjectID, permission); err != nil {
+		return 0, err
+	}
+
+	return task.ProjectID, nil
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// GetTasksByFilter returns page of the project tasks matching filter query from q query param.
+func (h *Handler) GetTasksByFilter(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTasksByFilter")
+
+	projectID, err := strconv.ParseUint(c.Query("projectId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidProjectIDQueryParam)
+		return
+	}
+
+	h.getTasksByFilterQuery(c, projectID, c.Query("q"))
+}
+
+func (h *Handler) CreateTaskFilter(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateTaskFilter")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var filter models.TaskFilterToCreate
+	if err = c.BindJSON(&filter); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	filter.ProjectID = projectID
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.TaskFilter.CreateTaskFilter(c, filter, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetTaskFilterByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTaskFilterByID")
+
+	filter, ok := h.getProjectTaskFilterByParams(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
+
+// GetAllProjectTaskFilters returns filters of the project owned by user or shared with project members.
+func (h *Handler) GetAllProjectTaskFilters(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllProjectTaskFilters")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	filters, err := h.svc.TaskFilter.GetAllProjectTaskFiltersToUser(c, projectID, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if filters == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
+
+func (h *Handler) UpdateTaskFilter(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateTaskFilter")
+
+	filter, ok := h.getProjectTaskFilterByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.checkTaskFilterOwner(c, filter); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	var filterToUpdate models.TaskFilterToUpdate
+	if err := c.BindJSON(&filterToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	filterToUpdate.ID = filter.ID
+
+	if err := h.svc.TaskFilter.UpdateTaskFilter(c, filterToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteTaskFilter(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteTaskFilter")
+
+	filter, ok := h.getProjectTaskFilterByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.checkTaskFilterOwner(c, filter); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := h.svc.TaskFilter.DeleteTaskFilter(c, filter.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetTasksByTaskFilter returns page of the project tasks matching the saved filter.
+func (h *Handler) GetTasksByTaskFilter(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTasksByTaskFilter")
+
+	filter, ok := h.getProjectTaskFilterByParams(c)
+	if !ok {
+		return
+	}
+
+	h.getTasksByFilterQuery(c, filter.ProjectID, filter.Query)
+}
+
+func (h *Handler) getTasksByFilterQuery(c *gin.Context, projectID uint64, query string) {
+	pageParams, err := getPageParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	page, err := h.svc.Task.GetTasksByFilter(c, projectID, userID, query, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// getProjectTaskFilterByParams gets task filter by project id and filter id params
+// and checks that user can read it: user is a project member and filter is owned by user or shared.
+// On failure it writes error response and returns false.
+func (h *Handler) getProjectTaskFilterByParams(c *gin.Context) (*models.TaskFilter, bool) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return nil, false
+	}
+
+	filterID, err := strconv.ParseUint(c.Param("filterId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidFilterIDParameter)
+		return nil, false
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	filter, err := h.svc.TaskFilter.GetTaskFilterByID(c, filterID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if filter == nil || filter.ProjectID != projectID || (filter.OwnerID != userID && !filter.IsShared) {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrTaskFilterNotFound, ""))
+		return nil, false
+	}
+
+	return filter, true
+}
+
+// checkTaskFilterOwner checks that user from context owns the filter. Shared filters can be changed only by owner.
+func (h *Handler) checkTaskFilterOwner(c *gin.Context, filter *models.TaskFilter) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if filter.OwnerID != userID {
+		return ierrors.NewForbidden(ErrNotTaskFilterOwner, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateTaskLink(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateTaskLink")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var link models.TaskLinkToCreate
+	if err = c.BindJSON(&link); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	link.TaskID = taskID
+
+	// linked task is checked to be in the same project by db
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionUpdateTask); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.TaskLink.CreateTaskLink(c, link)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+// GetAllTaskLinks returns links from the task and links to the task.
+func (h *Handler) GetAllTaskLinks(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllTaskLinks")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	links, err := h.svc.TaskLink.GetAllTaskLinks(c, taskID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if links == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+func (h *Handler) DeleteTaskLink(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteTaskLink")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	linkID, err := strconv.ParseUint(c.Param("linkId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidLinkIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionUpdateTask); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	link, err := h.svc.TaskLink.GetTaskLinkByID(c, linkID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if link == nil || (link.TaskID != taskID && link.LinkedTaskID != taskID) {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrTaskLinkNotFound, ""))
+		return
+	}
+
+	if err = h.svc.TaskLink.DeleteTaskLink(c, linkID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetBlockedProjectTasks returns not done tasks of the project which are blocked by not done tasks.
+func (h *Handler) GetBlockedProjectTasks(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetBlockedProjectTasks")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	tasks, err := h.svc.TaskLink.GetBlockedProjectTasks(c, projectID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	ierrors "github.com/l-orlov/task-tracker/internal/errors"
@@ -19,6 +20,45 @@ func (h *Handler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	// user can sign up by project invitation
+	var invitation *models.ProjectInvitation
+	invitationToken := c.Query("invitationToken")
+	if invitationToken != "" {
+		if invitation, err = h.svc.Verification.GetProjectInvitation(invitationToken); err != nil {
+			h.newErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+
+		if !strings.EqualFold(invitation.Email, user.Email) {
+			h.newErrorResponse(
+				c, http.StatusBadRequest, ierrors.NewBusiness(ErrInvitationEmailMismatch, ""),
+			)
+			return
+		}
+	}
+
+	if invitation != nil {
+		// user is created and added to the project together, so failed sign up can be retried
+		id, err := h.svc.User.CreateInvitedUser(c, user, *invitation)
+		if err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.notifyProjectMemberAdded(c, invitation.ProjectID, id)
+
+		// user is already a member, so invitation can not be accepted again even if token is not deleted
+		if err = h.svc.Verification.DeleteProjectInvitationToken(invitationToken); err != nil {
+			h.getLogEntry(c).Errorf("failed to delete invitation token: %v", err)
+		}
+
+		c.JSON(http.StatusOK, map[string]interface{}{
+			"id":        id,
+			"projectId": invitation.ProjectID,
+		})
+		return
+	}
+
 	id, err := h.svc.User.CreateUser(c, user)
 	if err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
@@ -92,13 +132,16 @@ func (h *Handler) SetUserPassword(c *gin.Context) {
 		return
 	}
 
-	// ToDo: add deleting all sessions
-
 	if err = h.svc.User.SetUserPassword(c, user.ID, user.Password); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	if err = h.svc.RevokeAllUserSessions(strconv.FormatUint(user.ID, 10)); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.Status(http.StatusOK)
 }
 
@@ -112,6 +155,11 @@ func (h *Handler) ChangeUserPassword(c *gin.Context) {
 		return
 	}
 
+	if err = h.checkUserAccountManagement(c, user.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	if err = h.svc.User.ChangeUserPassword(c, user.ID, user.OldPassword, user.NewPassword); err != nil {
 		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -123,18 +171,19 @@ func (h *Handler) ChangeUserPassword(c *gin.Context) {
 func (h *Handler) GetAllUsers(c *gin.Context) {
 	setHandlerNameToLogEntry(c, "GetAllUsers")
 
-	users, err := h.svc.User.GetAllUsers(c)
+	pageParams, err := getPageParams(c)
 	if err != nil {
-		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		h.newErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if users == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.User.GetAllUsers(c, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) GetAllUsersWithParameters(c *gin.Context) {
@@ -144,18 +193,19 @@ func (h *Handler) GetAllUsersWithParameters(c *gin.Context) {
 		return
 	}
 
-	users, err := h.svc.User.GetAllUsersWithParameters(c, params)
+	pageParams, err := getPageParams(c)
 	if err != nil {
-		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		h.newErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	if users == nil {
-		c.JSON(http.StatusOK, []struct{}{})
+	page, err := h.svc.User.GetAllUsersWithParameters(c, params, pageParams)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *Handler) DeleteUser(c *gin.Context) {
@@ -174,5 +224,139 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	if err = h.svc.RevokeAllUserSessions(strconv.FormatUint(id, 10)); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.Status(http.StatusOK)
 }
+
+func (h *Handler) GetCurrentUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetCurrentUser")
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	user, err := h.svc.User.GetUserByID(c, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrUserNotFound, ""))
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// PatchCurrentUser changes profile of user from context. New email is set only after it is confirmed
+// by link sent to it, so the old email is used until then.
+func (h *Handler) PatchCurrentUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "PatchCurrentUser")
+
+	var patch models.UserToPatch
+	if err := c.BindJSON(&patch); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	user, err := h.svc.User.GetUserByID(c, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user == nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrUserNotFound, ""))
+		return
+	}
+
+	isEmailChanged := patch.Email != nil && !strings.EqualFold(*patch.Email, user.Email)
+	if isEmailChanged {
+		if err = h.svc.User.CheckEmailIsFree(c, *patch.Email); err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if patch.FirstName != nil {
+		user.FirstName = *patch.FirstName
+	}
+
+	if patch.LastName != nil {
+		user.LastName = *patch.LastName
+	}
+
+	if err = h.svc.User.UpdateUser(c, *user); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if isEmailChanged {
+		emailChangeToken, err := h.svc.Verification.CreateEmailChangeToken(models.EmailChange{
+			UserID: userID,
+			Email:  *patch.Email,
+		})
+		if err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		// send token to the new email to confirm it
+		h.svc.Mailer.SendEmailChangeConfirm(*patch.Email, emailChangeToken)
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"isEmailChangePending": isEmailChanged,
+	})
+}
+
+// DeleteCurrentUser deletes account of user from context and logs user out on all devices.
+func (h *Handler) DeleteCurrentUser(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteCurrentUser")
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.User.DeleteUser(c, userID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.RevokeAllUserSessions(strconv.FormatUint(userID, 10)); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// checkUserAccountManagement checks that user from context can change account of the user.
+// Account can be changed only by its owner.
+func (h *Handler) checkUserAccountManagement(c *gin.Context, userID uint64) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if actorID != userID {
+		return ierrors.NewForbidden(ErrNotAccountOwner, "")
+	}
+
+	return nil
+}
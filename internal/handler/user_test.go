@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/l-orlov/task-tracker/internal/service"
+)
+
+// fakeUserService deletes users in memory. Not implemented methods panic.
+type fakeUserService struct {
+	service.User
+	deletedIDs []uint64
+}
+
+func (s *fakeUserService) DeleteUser(_ context.Context, id uint64) error {
+	s.deletedIDs = append(s.deletedIDs, id)
+	return nil
+}
+
+// fakeSessionService keeps user ids by access tokens in memory. Not implemented methods panic.
+type fakeSessionService struct {
+	service.UserAuthorization
+	userIDs map[string]string
+}
+
+func (s *fakeSessionService) CreateSession(userID string) (accessToken, refreshToken string, err error) {
+	accessToken = strconv.Itoa(len(s.userIDs) + 1)
+	s.userIDs[accessToken] = userID
+
+	return accessToken, "refresh" + accessToken, nil
+}
+
+func (s *fakeSessionService) ValidateAccessToken(accessToken string) (*jwt.StandardClaims, error) {
+	userID, ok := s.userIDs[accessToken]
+	if !ok {
+		return nil, errors.New("session is not found")
+	}
+
+	return &jwt.StandardClaims{Subject: userID}, nil
+}
+
+func (s *fakeSessionService) RevokeAllUserSessions(userID string) error {
+	for accessToken, sessionUserID := range s.userIDs {
+		if sessionUserID == userID {
+			delete(s.userIDs, accessToken)
+		}
+	}
+
+	return nil
+}
+
+func TestHandler_DeleteUser_RevokesAllSessions(t *testing.T) {
+	const userID, otherUserID = 10, 11
+
+	tests := []struct {
+		name   string
+		handle func(h *Handler, c *gin.Context)
+	}{
+		{
+			name: "user deletes own account",
+			handle: func(h *Handler, c *gin.Context) {
+				c.Set(ctxUserID, uint64(userID))
+				h.DeleteCurrentUser(c)
+			},
+		},
+		{
+			name: "admin deletes user",
+			handle: func(h *Handler, c *gin.Context) {
+				c.Set(ctxUserID, uint64(otherUserID))
+				c.Params = gin.Params{{Key: "id", Value: strconv.Itoa(userID)}}
+				h.DeleteUser(c)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &fakeUserService{}
+			sessions := &fakeSessionService{userIDs: make(map[string]string)}
+			h := newTestHandler()
+			h.svc = &service.Service{User: users, UserAuthorization: sessions}
+
+			// user is signed in on two devices
+			firstToken, _, _ := sessions.CreateSession(strconv.Itoa(userID))
+			secondToken, _, _ := sessions.CreateSession(strconv.Itoa(userID))
+			otherUserToken, _, _ := sessions.CreateSession(strconv.Itoa(otherUserID))
+
+			c, w := newTestContext(httptest.NewRequest(http.MethodDelete, "/api/v1/users", nil))
+			tt.handle(h, c)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			if len(users.deletedIDs) != 1 || users.deletedIDs[0] != userID {
+				t.Errorf("deleted users = %v, want [%d]", users.deletedIDs, userID)
+			}
+
+			for _, token := range []string{firstToken, secondToken} {
+				if _, err := sessions.ValidateAccessToken(token); err == nil {
+					t.Errorf("session %s of deleted user is valid", token)
+				}
+			}
+
+			if _, err := sessions.ValidateAccessToken(otherUserToken); err != nil {
+				t.Errorf("session of other user is not valid: %v", err)
+			}
+		})
+	}
+}
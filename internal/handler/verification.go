@@ -53,3 +53,29 @@ func (h *Handler) ConfirmPasswordReset(c *gin.Context) {
 		"id": userID,
 	})
 }
+
+// ConfirmEmailChange sets new email of user by token sent to the new email.
+func (h *Handler) ConfirmEmailChange(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "ConfirmEmailChange")
+
+	token, ok := c.GetQuery("token")
+	if !ok || token == "" {
+		h.newErrorResponse(
+			c, http.StatusBadRequest, ierrors.NewBusiness(ErrEmptyTokenParameter, ""),
+		)
+		return
+	}
+
+	change, err := h.svc.Verification.VerifyEmailChangeToken(token)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.svc.User.ChangeUserEmail(c, *change); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateWebhook")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var webhook models.WebhookToCreate
+	if err = c.BindJSON(&webhook); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhook.ProjectID = projectID
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionManageWebhooks); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.Webhook.CreateWebhook(c, webhook)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetWebhookByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetWebhookByID")
+
+	webhook, ok := h.getProjectWebhookByParams(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) GetAllProjectWebhooks(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllProjectWebhooks")
+
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionManageWebhooks); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	webhooks, err := h.svc.Webhook.GetAllProjectWebhooks(c, projectID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if webhooks == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateWebhook")
+
+	webhook, ok := h.getProjectWebhookByParams(c)
+	if !ok {
+		return
+	}
+
+	var webhookToUpdate models.WebhookToUpdate
+	if err := c.BindJSON(&webhookToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	webhookToUpdate.ID = webhook.ID
+
+	if err := h.svc.Webhook.UpdateWebhook(c, webhookToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteWebhook")
+
+	webhook, ok := h.getProjectWebhookByParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Webhook.DeleteWebhook(c, webhook.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetWebhookDeliveries")
+
+	webhook, ok := h.getProjectWebhookByParams(c)
+	if !ok {
+		return
+	}
+
+	limit, err := getLimitQueryParam(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	deliveries, err := h.svc.Webhook.GetWebhookDeliveries(c, webhook.ID, limit)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if deliveries == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// getProjectWebhookByParams gets webhook by project id and webhook id params
+// and checks permission to manage webhooks in the project.
+// On failure it writes error response and returns false.
+func (h *Handler) getProjectWebhookByParams(c *gin.Context) (*models.Webhook, bool) {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return nil, false
+	}
+
+	webhookID, err := strconv.ParseUint(c.Param("webhookId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidWebhookIDParameter)
+		return nil, false
+	}
+
+	if err = h.checkProjectPermission(c, projectID, models.ProjectPermissionManageWebhooks); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	webhook, err := h.svc.Webhook.GetWebhookByID(c, webhookID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if webhook == nil || webhook.ProjectID != projectID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrWebhookNotFound, ""))
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// notifyProjectMemberAdded sends member.added event with the added project user to webhooks.
+func (h *Handler) notifyProjectMemberAdded(c *gin.Context, projectID, userID uint64) {
+	user, err := h.svc.Project.GetProjectUser(c, projectID, userID)
+	if err != nil || user == nil {
+		h.getLogEntry(c).Errorf("failed to get project user to notify webhooks: %v", err)
+		return
+	}
+
+	h.svc.Webhook.NotifyProjectEvent(c, projectID, models.WebhookEventMemberAdded, user)
+}
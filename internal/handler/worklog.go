@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func (h *Handler) CreateWorklog(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "CreateWorklog")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	var worklog models.WorklogToCreate
+	if err = c.BindJSON(&worklog); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	worklog.TaskID = taskID
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionUpdateTask); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	id, err := h.svc.Worklog.CreateWorklog(c, worklog, userID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) GetWorklogByID(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetWorklogByID")
+
+	worklog, ok := h.getTaskWorklogByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, worklog)
+}
+
+func (h *Handler) GetAllTaskWorklogs(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetAllTaskWorklogs")
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, models.ProjectPermissionRead); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	worklogs, err := h.svc.Worklog.GetAllTaskWorklogs(c, taskID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if worklogs == nil {
+		c.JSON(http.StatusOK, []struct{}{})
+		return
+	}
+
+	c.JSON(http.StatusOK, worklogs)
+}
+
+func (h *Handler) UpdateWorklog(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "UpdateWorklog")
+
+	worklog, ok := h.getTaskWorklogByParams(c, models.ProjectPermissionUpdateTask)
+	if !ok {
+		return
+	}
+
+	var worklogToUpdate models.WorklogToUpdate
+	if err := c.BindJSON(&worklogToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.svc.Worklog.UpdateWorklog(c, worklog.ID, userID, worklogToUpdate); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteWorklog(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "DeleteWorklog")
+
+	worklog, ok := h.getTaskWorklogByParams(c, models.ProjectPermissionRead)
+	if !ok {
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// author can delete own worklog, others need permission to delete any worklog
+	if !worklog.IsLoggedBy(userID) {
+		if _, err = h.checkTaskProjectPermission(
+			c, worklog.TaskID, models.ProjectPermissionDeleteAnyWorklog,
+		); err != nil {
+			h.newErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if err = h.svc.Worklog.DeleteWorklog(c, worklog.ID); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetTimesheet returns time logged per user per day in projects of the user.
+// Time of one user is returned if userId query param is set.
+func (h *Handler) GetTimesheet(c *gin.Context) {
+	setHandlerNameToLogEntry(c, "GetTimesheet")
+
+	var userID *uint64
+	if userIDStr := c.Query("userId"); userIDStr != "" {
+		id, err := strconv.ParseUint(userIDStr, 10, 64)
+		if err != nil {
+			h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidUserIDQueryParam)
+			return
+		}
+
+		userID = &id
+	}
+
+	period, format, err := getReportParams(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	timesheet, err := h.svc.Worklog.GetTimesheet(c, viewerID, userID, period)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format != reportFormatCSV {
+		c.JSON(http.StatusOK, timesheet)
+		return
+	}
+
+	records := [][]string{{"userId", "email", "firstName", "lastName", "date", "duration"}}
+	for _, entry := range timesheet.Entries {
+		// user id is empty for time logged by deleted users
+		var userIDStr string
+		if entry.UserID != nil {
+			userIDStr = strconv.FormatUint(*entry.UserID, 10)
+		}
+
+		records = append(records, []string{
+			userIDStr, entry.Email, entry.FirstName, entry.LastName,
+			entry.Date.String(), strconv.FormatInt(entry.Duration, 10),
+		})
+	}
+
+	h.writeCSVResponse(c, "timesheet", records)
+}
+
+// getTaskWorklogByParams gets worklog by task id and worklog id params and checks permission in the task project.
+// On failure it writes error response and returns false.
+func (h *Handler) getTaskWorklogByParams(
+	c *gin.Context, permission models.ProjectPermission,
+) (*models.Worklog, bool) {
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidIDParameter)
+		return nil, false
+	}
+
+	worklogID, err := strconv.ParseUint(c.Param("worklogId"), 10, 64)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusBadRequest, ErrNotValidWorklogIDParameter)
+		return nil, false
+	}
+
+	if _, err = h.checkTaskProjectPermission(c, taskID, permission); err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	worklog, err := h.svc.Worklog.GetWorklogByID(c, worklogID)
+	if err != nil {
+		h.newErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if worklog == nil || worklog.TaskID != taskID {
+		h.newErrorResponse(c, http.StatusBadRequest, ierrors.NewBusiness(ErrWorklogNotFound, ""))
+		return nil, false
+	}
+
+	return worklog, true
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type (
+	TaskActivity struct {
+		ID        uint64    `json:"id" db:"id"`
+		ProjectID uint64    `json:"projectId" db:"project_id"`
+		TaskID    uint64    `json:"taskId" db:"task_id"`
+		ActorID   *uint64   `json:"actorId" db:"actor_id"`
+		Field     string    `json:"field" db:"field"`
+		OldValue  *string   `json:"oldValue" db:"old_value"`
+		NewValue  *string   `json:"newValue" db:"new_value"`
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	}
+	TaskActivityPage struct {
+		Items      []TaskActivity `json:"items"`
+		NextCursor string         `json:"nextCursor"`
+		TotalCount *int64         `json:"totalCount,omitempty"`
+	}
+)
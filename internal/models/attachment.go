@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type (
+	AttachmentToCreate struct {
+		TaskID      uint64
+		FileName    string
+		ContentType string
+		// Size is size of file in bytes
+		Size int64
+	}
+	Attachment struct {
+		ID          uint64    `json:"id" db:"id"`
+		TaskID      uint64    `json:"taskId" db:"task_id"`
+		UploaderID  *uint64   `json:"uploaderId" db:"uploader_id"`
+		FileName    string    `json:"fileName" db:"file_name"`
+		ContentType string    `json:"contentType" db:"content_type"`
+		Size        int64     `json:"size" db:"size"`
+		BlobKey     string    `json:"-" db:"blob_key"`
+		CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+	}
+)
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+	"io"
+)
+
+// DefaultAvatarSize is size of avatar served if size is not requested.
+const DefaultAvatarSize = 256
+
+// AvatarSizes are sizes in pixels of square images made from uploaded avatar.
+var AvatarSizes = []int{32, 64, 128, DefaultAvatarSize}
+
+type (
+	// UserAvatar is avatar image. Content should be closed by receiver.
+	UserAvatar struct {
+		Content     io.ReadCloser
+		ContentType string
+		// IsGenerated is true for initials avatar of user without uploaded avatar
+		IsGenerated bool
+	}
+)
+
+// UserAvatarURL returns url of user avatar served by api. Version of uploaded avatar
+// changes url, so clients do not show cached previous avatar.
+func UserAvatarURL(userID uint64, version string) string {
+	url := fmt.Sprintf("/api/v1/users/%d/avatar", userID)
+	if version != "" {
+		url += "?v=" + version
+	}
+
+	return url
+}
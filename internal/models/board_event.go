@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	BoardEventTaskCreated       BoardEventType = "task.created"
+	BoardEventTaskUpdated       BoardEventType = "task.updated"
+	BoardEventTaskDeleted       BoardEventType = "task.deleted"
+	BoardEventTaskMoved         BoardEventType = "task.moved"
+	BoardEventStatusesReordered BoardEventType = "statuses.reordered"
+)
+
+type (
+	BoardEventType string
+	// BoardEvent is the project board delta pushed to board stream subscribers.
+	BoardEvent struct {
+		Type       BoardEventType `json:"type"`
+		ProjectID  uint64         `json:"projectId"`
+		ActorID    uint64         `json:"actorId"`
+		OccurredAt time.Time      `json:"occurredAt"`
+		Data       interface{}    `json:"data"`
+	}
+)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type (
+	CommentToCreate struct {
+		TaskID   uint64  `json:"-"`
+		ParentID *uint64 `json:"parentId"`
+		Text     string  `json:"text" binding:"required"`
+	}
+	CommentToUpdate struct {
+		Text string `json:"text" binding:"required"`
+	}
+	Comment struct {
+		ID        uint64    `json:"id" db:"id"`
+		TaskID    uint64    `json:"taskId" db:"task_id"`
+		ParentID  *uint64   `json:"parentId" db:"parent_id"`
+		AuthorID  uint64    `json:"authorId" db:"author_id"`
+		Text      string    `json:"text" db:"text"`
+		IsEdited  bool      `json:"isEdited" db:"is_edited"`
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+		Replies   []Comment `json:"replies,omitempty" db:"-"`
+	}
+	CommentEdit struct {
+		ID        uint64    `json:"id" db:"id"`
+		CommentID uint64    `json:"commentId" db:"comment_id"`
+		Text      string    `json:"text" db:"text"`
+		EditedAt  time.Time `json:"editedAt" db:"edited_at"`
+	}
+)
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without time. It is represented as "2006-01-02" in json and db.
+type Date struct {
+	time.Time
+}
+
+func NewDate(t time.Time) Date {
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+
+	return Date{Time: t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	date, err := ParseDate(strings.Trim(string(b), `"`))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse date")
+	}
+
+	*d = date
+
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case time.Time:
+		*d = NewDate(v)
+		return nil
+	case []byte:
+		return d.UnmarshalJSON(v)
+	case string:
+		return d.UnmarshalJSON([]byte(v))
+	default:
+		return errors.Errorf("failed to scan into Date: unexpected type %T", value)
+	}
+}
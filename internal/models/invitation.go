@@ -0,0 +1,14 @@
+package models
+
+type (
+	ProjectInvitationToCreate struct {
+		Email string      `json:"email" binding:"required,email"`
+		Role  ProjectRole `json:"role" binding:"omitempty,oneof=admin member viewer"`
+	}
+	ProjectInvitation struct {
+		ProjectID uint64      `json:"projectId"`
+		Email     string      `json:"email"`
+		Role      ProjectRole `json:"role"`
+		InviterID uint64      `json:"inviterId"`
+	}
+)
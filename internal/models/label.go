@@ -0,0 +1,16 @@
+package models
+
+type (
+	LabelToCreate struct {
+		ProjectID uint64 `json:"projectId" binding:"required"`
+		Name      string `json:"name" binding:"required,max=255"`
+		// Color is hex color of label, gray by default
+		Color string `json:"color" binding:"omitempty,hexcolor"`
+	}
+	Label struct {
+		ID        int64  `json:"id" binding:"required" db:"id"`
+		ProjectID uint64 `json:"projectId" binding:"required" db:"project_id"`
+		Name      string `json:"name" binding:"required,max=255" db:"name"`
+		Color     string `json:"color" binding:"required,hexcolor" db:"color"`
+	}
+)
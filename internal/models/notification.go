@@ -0,0 +1,19 @@
+package models
+
+type (
+	NotificationSettings struct {
+		UserID               uint64 `json:"-" db:"user_id"`
+		IsDailyDigestEnabled bool   `json:"isDailyDigestEnabled" db:"is_daily_digest_enabled"`
+		IsDueReminderEnabled bool   `json:"isDueReminderEnabled" db:"is_due_reminder_enabled"`
+	}
+	// TaskNotification is a task to notify its assignee about.
+	TaskNotification struct {
+		UserID      uint64 `db:"user_id"`
+		Email       string `db:"email"`
+		FirstName   string `db:"firstname"`
+		TaskID      uint64 `db:"task_id"`
+		TaskTitle   string `db:"task_title"`
+		ProjectName string `db:"project_name"`
+		DueDate     *Date  `db:"due_date"`
+	}
+)
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+const (
+	SortFieldID        SortField = "id"
+	SortFieldCreatedAt SortField = "createdAt"
+	SortFieldUpdatedAt SortField = "updatedAt"
+	SortFieldTitle     SortField = "title"
+	SortFieldName      SortField = "name"
+	SortFieldEmail     SortField = "email"
+	SortFieldDueDate   SortField = "dueDate"
+)
+
+type (
+	SortField string
+	// PageParams are parameters of list page requested by client.
+	PageParams struct {
+		// Limit is max number of items in page. Zero means default limit.
+		Limit int
+		// Cursor is opaque position returned with previous page. Empty cursor means the first page.
+		Cursor string
+		// Sort is field to sort by. Field with "-" prefix is sorted in descending order.
+		Sort string
+		// WithTotal requests total count of items matching the filters.
+		WithTotal bool
+	}
+	// PageRequest is validated page request used by repository.
+	PageRequest struct {
+		SortField SortField
+		SortDesc  bool
+		// After is position of the last item of previous page. Nil means the first page.
+		After     *PageCursor
+		Limit     int
+		WithTotal bool
+	}
+	// PageCursor is position of item in sorted list.
+	PageCursor struct {
+		Sort      string `json:"s"`
+		SortValue string `json:"v"`
+		ID        uint64 `json:"id"`
+	}
+	UserPage struct {
+		Items      []User `json:"items"`
+		NextCursor string `json:"nextCursor"`
+		TotalCount *int64 `json:"totalCount,omitempty"`
+	}
+	ProjectPage struct {
+		Items      []Project `json:"items"`
+		NextCursor string    `json:"nextCursor"`
+		TotalCount *int64    `json:"totalCount,omitempty"`
+	}
+	TaskPage struct {
+		Items      []Task `json:"items"`
+		NextCursor string `json:"nextCursor"`
+		TotalCount *int64 `json:"totalCount,omitempty"`
+	}
+)
+
+// FormatSortTime formats time as sort value of cursor without loss of precision.
+func FormatSortTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -1,15 +1,27 @@
 package models
 
+const (
+	ProgressStatusCategoryTodo       ProgressStatusCategory = "todo"
+	ProgressStatusCategoryInProgress ProgressStatusCategory = "in_progress"
+	// ProgressStatusCategoryDone is category of statuses of completed tasks
+	ProgressStatusCategoryDone ProgressStatusCategory = "done"
+)
+
 type (
+	ProgressStatusCategory string
 	ProgressStatusToCreate struct {
 		ProjectID uint64 `json:"projectId" binding:"required"`
 		Name      string `json:"name" binding:"required"`
 		OrderNum  int    `json:"orderNum"`
+		// Category is todo by default
+		Category ProgressStatusCategory `json:"category" binding:"omitempty,oneof=todo in_progress done"`
 	}
 	ProgressStatus struct {
 		ID        int64  `json:"id" binding:"required" db:"id"`
 		ProjectID uint64 `json:"projectId" binding:"required" db:"project_id"`
 		Name      string `json:"name" binding:"required" db:"name"`
 		OrderNum  int    `json:"orderNum" db:"order_num"`
+		// Category is not changed on update if it is empty
+		Category ProgressStatusCategory `json:"category" binding:"omitempty,oneof=todo in_progress done" db:"category"`
 	}
 )
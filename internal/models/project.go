@@ -1,14 +1,54 @@
 package models
 
+import "time"
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleAdmin  ProjectRole = "admin"
+	ProjectRoleMember ProjectRole = "member"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+const (
+	ProjectPermissionRead              ProjectPermission = "read"
+	ProjectPermissionUpdateProject     ProjectPermission = "update_project"
+	ProjectPermissionDeleteProject     ProjectPermission = "delete_project"
+	ProjectPermissionCreateTask        ProjectPermission = "create_task"
+	ProjectPermissionUpdateTask        ProjectPermission = "update_task"
+	ProjectPermissionDeleteTask        ProjectPermission = "delete_task"
+	ProjectPermissionCreateStatus      ProjectPermission = "create_status"
+	ProjectPermissionUpdateStatus      ProjectPermission = "update_status"
+	ProjectPermissionDeleteStatus      ProjectPermission = "delete_status"
+	ProjectPermissionUpdateBoardOrder  ProjectPermission = "update_board_order"
+	ProjectPermissionCreateComment     ProjectPermission = "create_comment"
+	ProjectPermissionDeleteAnyComment  ProjectPermission = "delete_any_comment"
+	ProjectPermissionDeleteAnyWorklog  ProjectPermission = "delete_any_worklog"
+	ProjectPermissionManageMembers     ProjectPermission = "manage_members"
+	ProjectPermissionManageAdmins      ProjectPermission = "manage_admins"
+	ProjectPermissionManageWebhooks    ProjectPermission = "manage_webhooks"
+	ProjectPermissionManageSprints     ProjectPermission = "manage_sprints"
+	ProjectPermissionTransferOwnership ProjectPermission = "transfer_ownership"
+)
+
 type (
-	ProjectToCreate struct {
+	ProjectRole       string
+	ProjectPermission string
+	ProjectToCreate   struct {
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 	}
 	Project struct {
-		ID          uint64 `json:"id" binding:"required" db:"id"`
-		Name        string `json:"name" binding:"required" db:"name"`
-		Description string `json:"description" db:"description"`
+		ID          uint64    `json:"id" binding:"required" db:"id"`
+		Name        string    `json:"name" binding:"required" db:"name"`
+		Description string    `json:"description" db:"description"`
+		CreatedAt   time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+	}
+	// ProjectTaskCounts are counts of tasks in done category statuses and other tasks of the project.
+	ProjectTaskCounts struct {
+		ProjectID uint64 `json:"projectId" db:"project_id"`
+		Open      int64  `json:"open" db:"open"`
+		Completed int64  `json:"completed" db:"completed"`
 	}
 	ProjectParams struct {
 		ID          *uint64 `json:"id"`
@@ -16,10 +56,24 @@ type (
 		Description *string `json:"description"`
 	}
 	ProjectUser struct {
-		ID        uint64 `json:"id" db:"id"`
-		Email     string `json:"email" db:"email"`
-		FirstName string `json:"firstName" db:"firstname"`
-		LastName  string `json:"lastName" db:"lastname"`
-		IsOwner   bool   `json:"isOwner" db:"is_owner"`
+		ID        uint64      `json:"id" db:"id"`
+		Email     string      `json:"email" db:"email"`
+		FirstName string      `json:"firstName" db:"firstname"`
+		LastName  string      `json:"lastName" db:"lastname"`
+		Role      ProjectRole `json:"role" db:"role"`
+	}
+	ProjectUserRole struct {
+		UserID uint64      `json:"userId" binding:"required"`
+		Role   ProjectRole `json:"role" binding:"required"`
 	}
 )
+
+// IsValid checks that role is one of the known project roles.
+func (r ProjectRole) IsValid() bool {
+	switch r {
+	case ProjectRoleOwner, ProjectRoleAdmin, ProjectRoleMember, ProjectRoleViewer:
+		return true
+	}
+
+	return false
+}
@@ -16,11 +16,28 @@ type (
 		AssigneeFirstname string `json:"assigneeFirstname"`
 		AssigneeLastname  string `json:"assigneeLastname"`
 		AssigneeAvatarURL string `json:"assigneeAvatarURL"`
+		StartDate         *Date  `json:"startDate"`
+		DueDate           *Date  `json:"dueDate"`
+		// Labels are ignored on board update
+		Labels   []ProjectBoardLabel `json:"labels,omitempty"`
+		ParentID *uint64             `json:"parentId,omitempty"`
+		// SprintID is ignored on board update
+		SprintID *uint64 `json:"sprintId,omitempty"`
+		// SubtasksTotal and SubtasksDone are counts of direct subtasks, they are ignored on board update
+		SubtasksTotal int `json:"subtasksTotal"`
+		SubtasksDone  int `json:"subtasksDone"`
+	}
+	ProjectBoardLabel struct {
+		LabelID    int64  `json:"labelId"`
+		LabelName  string `json:"labelName"`
+		LabelColor string `json:"labelColor"`
 	}
 	ProjectBoardProgressStatus struct {
 		ProgressStatusId       int64  `json:"progressStatusId" binding:"required"`
 		ProgressStatusName     string `json:"progressStatusName"`
 		ProgressStatusOrderNum int    `json:"progressStatusOrderNum"`
+		// ProgressStatusCategory is ignored on board update
+		ProgressStatusCategory ProgressStatusCategory `json:"progressStatusCategory,omitempty"`
 	}
 	ProjectBoardProgressStatusWithTasks struct {
 		ProjectBoardProgressStatus
@@ -0,0 +1,50 @@
+package models
+
+type (
+	// ReportPeriod is period of report from the first day to the last day inclusive.
+	ReportPeriod struct {
+		From Date `json:"from"`
+		To   Date `json:"to"`
+	}
+	CumulativeFlowReport struct {
+		ReportPeriod
+		Statuses []ProgressStatus    `json:"statuses"`
+		Days     []CumulativeFlowDay `json:"days"`
+	}
+	CumulativeFlowDay struct {
+		Date Date `json:"date"`
+		// Counts are numbers of tasks in report statuses at the end of the day in order of report statuses.
+		Counts []int64 `json:"counts"`
+	}
+	// CumulativeFlowCount is number of tasks in progress status at the end of the day.
+	CumulativeFlowCount struct {
+		Date             Date  `db:"day"`
+		ProgressStatusID int64 `db:"progress_status_id"`
+		Count            int64 `db:"count"`
+	}
+	// LeadCycleTimeReport is report of tasks completed in the period.
+	// Lead time is time from task creation to completion,
+	// cycle time is time from the first move of task out of todo category status to completion.
+	LeadCycleTimeReport struct {
+		ReportPeriod
+		LeadTime  DurationPercentiles `json:"leadTime"`
+		CycleTime DurationPercentiles `json:"cycleTime"`
+	}
+	// DurationPercentiles are percentiles of durations in hours. Percentiles are nil if count is zero.
+	DurationPercentiles struct {
+		Count int64    `json:"count"`
+		P50   *float64 `json:"p50"`
+		P75   *float64 `json:"p75"`
+		P85   *float64 `json:"p85"`
+		P95   *float64 `json:"p95"`
+	}
+	ThroughputReport struct {
+		ReportPeriod
+		Weeks []ThroughputWeek `json:"weeks"`
+	}
+	// ThroughputWeek is number of tasks completed in the week starting on Monday.
+	ThroughputWeek struct {
+		WeekStart Date  `json:"weekStart" db:"week_start"`
+		Completed int64 `json:"completed" db:"completed"`
+	}
+)
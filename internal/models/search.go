@@ -0,0 +1,38 @@
+package models
+
+type (
+	SearchParams struct {
+		Query string
+		// ProjectID limits search to the project. Nil means all projects of user.
+		ProjectID *uint64
+		// Limit is max number of results of each entity type.
+		Limit int
+	}
+	// SearchResults are search results grouped by entity type and sorted by relevance.
+	// Snippets are html escaped with matched words wrapped in <mark> tags.
+	SearchResults struct {
+		Tasks    []TaskSearchResult    `json:"tasks"`
+		Comments []CommentSearchResult `json:"comments"`
+		Projects []ProjectSearchResult `json:"projects"`
+	}
+	TaskSearchResult struct {
+		ID        uint64  `json:"id" db:"id"`
+		ProjectID uint64  `json:"projectId" db:"project_id"`
+		Title     string  `json:"title" db:"title"`
+		Snippet   string  `json:"snippet" db:"snippet"`
+		Rank      float64 `json:"rank" db:"rank"`
+	}
+	CommentSearchResult struct {
+		ID        uint64  `json:"id" db:"id"`
+		TaskID    uint64  `json:"taskId" db:"task_id"`
+		ProjectID uint64  `json:"projectId" db:"project_id"`
+		Snippet   string  `json:"snippet" db:"snippet"`
+		Rank      float64 `json:"rank" db:"rank"`
+	}
+	ProjectSearchResult struct {
+		ID      uint64  `json:"id" db:"id"`
+		Name    string  `json:"name" db:"name"`
+		Snippet string  `json:"snippet" db:"snippet"`
+		Rank    float64 `json:"rank" db:"rank"`
+	}
+)
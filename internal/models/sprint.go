@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+const (
+	SprintStatusPlanned SprintStatus = "planned"
+	SprintStatusActive  SprintStatus = "active"
+	SprintStatusClosed  SprintStatus = "closed"
+
+	// SprintMoveToNext moves unfinished tasks of closed sprint to the next planned sprint,
+	// or to the backlog if there is no planned sprint.
+	SprintMoveToNext SprintMoveTo = "next"
+	// SprintMoveToBacklog moves unfinished tasks of closed sprint to the backlog.
+	SprintMoveToBacklog SprintMoveTo = "backlog"
+)
+
+type (
+	SprintStatus   string
+	SprintMoveTo   string
+	SprintToCreate struct {
+		ProjectID uint64 `json:"projectId" binding:"required"`
+		Name      string `json:"name" binding:"required,max=255"`
+		Goal      string `json:"goal"`
+		StartDate Date   `json:"startDate"`
+		EndDate   Date   `json:"endDate"`
+	}
+	SprintToUpdate struct {
+		ID        uint64 `json:"id" binding:"required"`
+		Name      string `json:"name" binding:"required,max=255"`
+		Goal      string `json:"goal"`
+		StartDate Date   `json:"startDate"`
+		EndDate   Date   `json:"endDate"`
+	}
+	Sprint struct {
+		ID        uint64       `json:"id" db:"id"`
+		ProjectID uint64       `json:"projectId" db:"project_id"`
+		Name      string       `json:"name" db:"name"`
+		Goal      string       `json:"goal" db:"goal"`
+		StartDate Date         `json:"startDate" db:"start_date"`
+		EndDate   Date         `json:"endDate" db:"end_date"`
+		Status    SprintStatus `json:"status" db:"status"`
+		StartedAt *time.Time   `json:"startedAt" db:"started_at"`
+		ClosedAt  *time.Time   `json:"closedAt" db:"closed_at"`
+		CreatedAt time.Time    `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time    `json:"updatedAt" db:"updated_at"`
+	}
+	// SprintCloseResult is result of sprint closing with ids of unfinished tasks moved out of the sprint.
+	SprintCloseResult struct {
+		MovedTaskIDs []uint64 `json:"movedTaskIds"`
+		// NextSprintID is id of sprint which got unfinished tasks, nil if they were moved to the backlog.
+		NextSprintID *uint64 `json:"nextSprintId"`
+	}
+	// SprintBurndown is burndown of sprint by days from sprint start to sprint end.
+	SprintBurndown struct {
+		SprintID uint64              `json:"sprintId"`
+		Days     []SprintBurndownDay `json:"days"`
+	}
+	// SprintBurndownDay is number of sprint tasks at the end of the day.
+	// Total and remaining are nil for days which have not come yet or came after sprint closing.
+	SprintBurndownDay struct {
+		Date      Date   `json:"date"`
+		Total     *int64 `json:"total"`
+		Remaining *int64 `json:"remaining"`
+		// Ideal is number of remaining tasks if tasks of the first day were done evenly.
+		Ideal float64 `json:"ideal"`
+	}
+	// SprintBurndownCount is number of sprint tasks and not completed sprint tasks at the end of the day.
+	SprintBurndownCount struct {
+		Date      Date  `db:"day"`
+		Total     int64 `db:"total"`
+		Remaining int64 `db:"remaining"`
+	}
+)
@@ -0,0 +1,24 @@
+package models
+
+type (
+	StatusTransitionToCreate struct {
+		ProjectID    uint64 `json:"projectId" binding:"required"`
+		FromStatusID int64  `json:"fromStatusId" binding:"required"`
+		ToStatusID   int64  `json:"toStatusId" binding:"required,nefield=FromStatusID"`
+		// Roles are project roles allowed to make transition. Empty roles allow transition to any role.
+		Roles []ProjectRole `json:"roles" binding:"dive,oneof=owner admin member viewer"`
+	}
+	StatusTransitionToUpdate struct {
+		ID    int64         `json:"id" binding:"required"`
+		Roles []ProjectRole `json:"roles" binding:"dive,oneof=owner admin member viewer"`
+	}
+	// StatusTransition is allowed transition of task between progress statuses.
+	// Any transition is allowed in project without transitions.
+	StatusTransition struct {
+		ID           int64         `json:"id"`
+		ProjectID    uint64        `json:"projectId"`
+		FromStatusID int64         `json:"fromStatusId"`
+		ToStatusID   int64         `json:"toStatusId"`
+		Roles        []ProjectRole `json:"roles"`
+	}
+)
@@ -1,5 +1,14 @@
 package models
 
+import "time"
+
+const (
+	// SubtasksPolicyOrphan makes subtasks of deleted task root tasks.
+	SubtasksPolicyOrphan SubtasksPolicy = "orphan"
+	// SubtasksPolicyCascade deletes subtasks of all levels with deleted task.
+	SubtasksPolicyCascade SubtasksPolicy = "cascade"
+)
+
 type (
 	TaskToCreate struct {
 		ProjectID          uint64 `json:"projectId" binding:"required"`
@@ -8,15 +17,41 @@ type (
 		AssigneeID         uint64 `json:"assigneeId" binding:"required"`
 		ImportanceStatusID int64  `json:"importanceStatusId" binding:"required"`
 		ProgressStatusID   int64  `json:"progressStatusId" binding:"required"`
+		StartDate          *Date  `json:"startDate"`
+		DueDate            *Date  `json:"dueDate"`
+		// ParentID is id of parent task in the same project, nil for root task.
+		ParentID *uint64 `json:"parentId"`
+		// SprintID is id of not closed sprint of the project, nil for task in the backlog.
+		SprintID *uint64 `json:"sprintId"`
+		// OriginalEstimate and RemainingEstimate are estimates of task effort in minutes, nil if not set.
+		OriginalEstimate  *int `json:"originalEstimate" binding:"omitempty,min=0"`
+		RemainingEstimate *int `json:"remainingEstimate" binding:"omitempty,min=0"`
 	}
 	Task struct {
-		ID                 uint64 `json:"id" binding:"required" db:"id"`
-		ProjectID          uint64 `json:"projectId" binding:"required" db:"project_id"`
-		Title              string `json:"title" binding:"required" db:"title"`
-		Description        string `json:"description" db:"description"`
-		AssigneeID         uint64 `json:"assigneeId" binding:"required" db:"assignee_id"`
-		ImportanceStatusID int64  `json:"importanceStatusId" binding:"required" db:"importance_status_id"`
-		ProgressStatusID   int64  `json:"progressStatusId" binding:"required" db:"progress_status_id"`
+		ID                 uint64  `json:"id" binding:"required" db:"id"`
+		ProjectID          uint64  `json:"projectId" binding:"required" db:"project_id"`
+		Title              string  `json:"title" binding:"required" db:"title"`
+		Description        string  `json:"description" db:"description"`
+		AssigneeID         uint64  `json:"assigneeId" binding:"required" db:"assignee_id"`
+		ImportanceStatusID int64   `json:"importanceStatusId" binding:"required" db:"importance_status_id"`
+		ProgressStatusID   int64   `json:"progressStatusId" binding:"required" db:"progress_status_id"`
+		StartDate          *Date   `json:"startDate" db:"start_date"`
+		DueDate            *Date   `json:"dueDate" db:"due_date"`
+		ParentID           *uint64 `json:"parentId" db:"parent_id"`
+		SprintID           *uint64 `json:"sprintId" db:"sprint_id"`
+		OriginalEstimate   *int    `json:"originalEstimate" binding:"omitempty,min=0" db:"original_estimate"`
+		RemainingEstimate  *int    `json:"remainingEstimate" binding:"omitempty,min=0" db:"remaining_estimate"`
+		// CompletedAt is time when task entered done category status, nil for not done task.
+		CompletedAt *time.Time `json:"completedAt" db:"completed_at"`
+		// Version is incremented on every task change. Update of task with stale version is rejected.
+		Version   uint64    `json:"version" binding:"required" db:"version"`
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+	}
+	// TaskTree is task with its subtasks of all levels.
+	TaskTree struct {
+		Task
+		Subtasks []TaskTree `json:"subtasks"`
 	}
 	TaskParams struct {
 		ID                 *uint64 `json:"id"`
@@ -26,5 +61,14 @@ type (
 		AssigneeID         *uint64 `json:"assigneeId"`
 		ImportanceStatusID *int64  `json:"importanceStatusId"`
 		ProgressStatusID   *int64  `json:"progressStatusId"`
+		LabelID            *int64  `json:"labelId"`
+		DueBefore          *Date   `json:"dueBefore"`
+		DueAfter           *Date   `json:"dueAfter"`
+		// Overdue selects only not done tasks with due date in the past.
+		Overdue bool `json:"overdue"`
+		// DueThisWeek selects only tasks with due date in the current week (from Monday to Sunday).
+		DueThisWeek bool `json:"dueThisWeek"`
 	}
+	// SubtasksPolicy is policy of subtasks of deleted task.
+	SubtasksPolicy string
 )
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type (
+	TaskFilterToCreate struct {
+		ProjectID uint64 `json:"-"`
+		Name      string `json:"name" binding:"required,max=255"`
+		Query     string `json:"query" binding:"required"`
+		IsShared  bool   `json:"isShared"`
+	}
+	TaskFilterToUpdate struct {
+		ID       uint64 `json:"-"`
+		Name     string `json:"name" binding:"required,max=255"`
+		Query    string `json:"query" binding:"required"`
+		IsShared bool   `json:"isShared"`
+	}
+	// TaskFilter is saved task filter query. Shared filter is visible to all project members.
+	TaskFilter struct {
+		ID        uint64    `json:"id" db:"id"`
+		ProjectID uint64    `json:"projectId" db:"project_id"`
+		OwnerID   uint64    `json:"ownerId" db:"owner_id"`
+		Name      string    `json:"name" db:"name"`
+		Query     string    `json:"query" db:"query"`
+		IsShared  bool      `json:"isShared" db:"is_shared"`
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+	}
+)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	// TaskLinkTypeBlocks means that linked task can not be done until the task is done.
+	TaskLinkTypeBlocks     TaskLinkType = "blocks"
+	TaskLinkTypeRelatesTo  TaskLinkType = "relates_to"
+	TaskLinkTypeDuplicates TaskLinkType = "duplicates"
+)
+
+type (
+	TaskLinkType     string
+	TaskLinkToCreate struct {
+		TaskID       uint64       `json:"-"`
+		LinkedTaskID uint64       `json:"linkedTaskId" binding:"required"`
+		Type         TaskLinkType `json:"type" binding:"required,oneof=blocks relates_to duplicates"`
+	}
+	TaskLink struct {
+		ID           uint64       `json:"id" db:"id"`
+		TaskID       uint64       `json:"taskId" db:"task_id"`
+		LinkedTaskID uint64       `json:"linkedTaskId" db:"linked_task_id"`
+		Type         TaskLinkType `json:"type" db:"type"`
+		CreatedAt    time.Time    `json:"createdAt" db:"created_at"`
+	}
+	// BlockedTask is not done task with ids of not done tasks blocking it.
+	BlockedTask struct {
+		Task
+		BlockedBy []uint64 `json:"blockedBy"`
+	}
+)
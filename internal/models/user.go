@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -17,13 +19,29 @@ type (
 		Fingerprint string `json:"fingerprint" binding:"required"`
 	}
 	User struct {
-		ID               uint64 `json:"id" binding:"required" db:"id"`
-		Email            string `json:"email" binding:"required,email" db:"email"`
-		FirstName        string `json:"firstName" binding:"required" db:"firstname"`
-		LastName         string `json:"lastName" binding:"required" db:"lastname"`
-		Password         string `json:"-" db:"password"`
-		IsEmailConfirmed bool   `json:"isEmailConfirmed" db:"is_email_confirmed"`
-		AvatarURL        string `json:"avatarURL" db:"avatar_url"`
+		ID               uint64    `json:"id" binding:"required" db:"id"`
+		Email            string    `json:"email" binding:"required,email" db:"email"`
+		FirstName        string    `json:"firstName" binding:"required" db:"firstname"`
+		LastName         string    `json:"lastName" binding:"required" db:"lastname"`
+		Password         string    `json:"-" db:"password"`
+		IsEmailConfirmed bool      `json:"isEmailConfirmed" db:"is_email_confirmed"`
+		AvatarURL        string    `json:"avatarURL" db:"avatar_url"`
+		IsAdmin          bool      `json:"isAdmin" db:"is_admin"`
+		IsDisabled       bool      `json:"isDisabled" db:"is_disabled"`
+		CreatedAt        time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt        time.Time `json:"updatedAt" db:"updated_at"`
+	}
+	// UserToPatch is change of own profile. Nil fields are not changed.
+	// Changed email is set after confirmation of the new address.
+	UserToPatch struct {
+		FirstName *string `json:"firstName" binding:"omitempty,min=1"`
+		LastName  *string `json:"lastName" binding:"omitempty,min=1"`
+		Email     *string `json:"email" binding:"omitempty,email"`
+	}
+	// EmailChange is email change waiting for confirmation of the new address.
+	EmailChange struct {
+		UserID uint64 `json:"userId"`
+		Email  string `json:"email"`
 	}
 	UserPassword struct {
 		ID       uint64 `json:"id" binding:"required"`
@@ -40,6 +58,11 @@ type (
 		FirstName        *string `json:"firstName"`
 		LastName         *string `json:"lastName"`
 		IsEmailConfirmed *bool   `json:"isEmailConfirmed"`
+		IsAdmin          *bool   `json:"isAdmin"`
+		IsDisabled       *bool   `json:"isDisabled"`
+	}
+	UserAdminFlag struct {
+		IsAdmin bool `json:"isAdmin"`
 	}
 )
 
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	WebhookEventTaskCreated   WebhookEvent = "task.created"
+	WebhookEventTaskUpdated   WebhookEvent = "task.updated"
+	WebhookEventTaskDeleted   WebhookEvent = "task.deleted"
+	WebhookEventTaskMoved     WebhookEvent = "task.moved"
+	WebhookEventMemberAdded   WebhookEvent = "member.added"
+	WebhookEventMemberRemoved WebhookEvent = "member.removed"
+)
+
+type (
+	WebhookEvent string
+	// WebhookEvents is the filter of webhook events. Empty filter means all events.
+	WebhookEvents   []WebhookEvent
+	WebhookToCreate struct {
+		ProjectID uint64        `json:"-"`
+		URL       string        `json:"url" binding:"required,url,startswith=http"`
+		Secret    string        `json:"secret" binding:"required"`
+		Events    WebhookEvents `json:"events" binding:"dive,oneof=task.created task.updated task.deleted task.moved member.added member.removed"`
+	}
+	WebhookToUpdate struct {
+		ID  uint64 `json:"-"`
+		URL string `json:"url" binding:"required,url,startswith=http"`
+		// Secret is not changed if it is empty.
+		Secret   string        `json:"secret"`
+		Events   WebhookEvents `json:"events" binding:"dive,oneof=task.created task.updated task.deleted task.moved member.added member.removed"`
+		IsActive bool          `json:"isActive"`
+	}
+	Webhook struct {
+		ID        uint64        `json:"id" db:"id"`
+		ProjectID uint64        `json:"projectId" db:"project_id"`
+		URL       string        `json:"url" db:"url"`
+		Secret    string        `json:"-" db:"secret"`
+		Events    WebhookEvents `json:"events" db:"events"`
+		IsActive  bool          `json:"isActive" db:"is_active"`
+		CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time     `json:"updatedAt" db:"updated_at"`
+	}
+	WebhookPayload struct {
+		Event      WebhookEvent `json:"event"`
+		ProjectID  uint64       `json:"projectId"`
+		OccurredAt time.Time    `json:"occurredAt"`
+		Data       interface{}  `json:"data"`
+	}
+	WebhookDelivery struct {
+		ID         uint64         `json:"id" db:"id"`
+		WebhookID  uint64         `json:"webhookId" db:"webhook_id"`
+		Event      WebhookEvent   `json:"event" db:"event"`
+		Payload    types.JSONText `json:"payload" db:"payload"`
+		Attempt    int            `json:"attempt" db:"attempt"`
+		StatusCode *int           `json:"statusCode" db:"status_code"`
+		Error      string         `json:"error" db:"error"`
+		IsSuccess  bool           `json:"isSuccess" db:"is_success"`
+		CreatedAt  time.Time      `json:"createdAt" db:"created_at"`
+	}
+)
+
+func (events WebhookEvents) Value() (driver.Value, error) {
+	if events == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(events)
+}
+
+func (events *WebhookEvents) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to scan into WebhookEvents: type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &events)
+}
+
+// Has checks that the event passes the filter.
+func (events WebhookEvents) Has(event WebhookEvent) bool {
+	if len(events) == 0 {
+		return true
+	}
+
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type (
+	WorklogToCreate struct {
+		TaskID uint64 `json:"-"`
+		// Duration is logged time in minutes
+		Duration  int       `json:"duration" binding:"required,min=1"`
+		StartedAt time.Time `json:"startedAt" binding:"required"`
+		Note      string    `json:"note"`
+	}
+	WorklogToUpdate struct {
+		Duration  int       `json:"duration" binding:"required,min=1"`
+		StartedAt time.Time `json:"startedAt" binding:"required"`
+		Note      string    `json:"note"`
+	}
+	Worklog struct {
+		ID        uint64    `json:"id" db:"id"`
+		TaskID    uint64    `json:"taskId" db:"task_id"`
+		UserID    *uint64   `json:"userId" db:"user_id"`
+		Duration  int       `json:"duration" db:"duration"`
+		StartedAt time.Time `json:"startedAt" db:"started_at"`
+		Note      string    `json:"note" db:"note"`
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+		UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+	}
+	// Timesheet is time logged by users per day in tasks of all projects visible to viewer.
+	Timesheet struct {
+		ReportPeriod
+		Entries []TimesheetEntry `json:"entries"`
+	}
+	// TimesheetEntry is time in minutes logged by user in the day. Days without logged time are omitted.
+	// Time logged by deleted users is in entries with nil user id and empty names.
+	TimesheetEntry struct {
+		UserID    *uint64 `json:"userId" db:"user_id"`
+		Email     string  `json:"email" db:"email"`
+		FirstName string  `json:"firstName" db:"firstname"`
+		LastName  string  `json:"lastName" db:"lastname"`
+		Date      Date    `json:"date" db:"day"`
+		Duration  int64   `json:"duration" db:"duration"`
+	}
+)
+
+// IsLoggedBy checks that worklog is logged by the user. Worklogs of deleted users have no author.
+func (w Worklog) IsLoggedBy(userID uint64) bool {
+	return w.UserID != nil && *w.UserID == userID
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+const activityColumns = `id, project_id, task_id, actor_id, field, old_value, new_value, created_at`
+
+type ActivityPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewActivityPostgres(db *sqlx.DB, dbTimeout time.Duration) *ActivityPostgres {
+	return &ActivityPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// GetTaskActivity returns page of task activity.
+func (r *ActivityPostgres) GetTaskActivity(
+	ctx context.Context, taskID uint64, page models.PageRequest,
+) ([]models.TaskActivity, int64, error) {
+	return r.getActivityPage(ctx, `task_id = $1`, []interface{}{taskID}, page)
+}
+
+// GetProjectActivity returns page of activity of project tasks.
+func (r *ActivityPostgres) GetProjectActivity(
+	ctx context.Context, projectID uint64, page models.PageRequest,
+) ([]models.TaskActivity, int64, error) {
+	return r.getActivityPage(ctx, `project_id = $1`, []interface{}{projectID}, page)
+}
+
+func (r *ActivityPostgres) getActivityPage(
+	ctx context.Context, condition string, args []interface{}, page models.PageRequest,
+) ([]models.TaskActivity, int64, error) {
+	q, err := newPageQuery(activityColumns, taskActivityTable, condition, args, page, activitySortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var activity []models.TaskActivity
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	total, err := selectPage(dbCtx, r.db, &activity, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return activity, total, nil
+}
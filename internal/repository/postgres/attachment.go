@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const attachmentColumns = `id, task_id, uploader_id, file_name, content_type, size, blob_key, created_at`
+
+type AttachmentPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewAttachmentPostgres(db *sqlx.DB, dbTimeout time.Duration) *AttachmentPostgres {
+	return &AttachmentPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *AttachmentPostgres) CreateAttachment(
+	ctx context.Context, attachment models.AttachmentToCreate, blobKey string, uploaderID uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (task_id, uploader_id, file_name, content_type, size, blob_key)
+values ($1, $2, $3, $4, $5, $6) RETURNING id`, attachmentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query,
+		&attachment.TaskID, &uploaderID, &attachment.FileName, &attachment.ContentType, &attachment.Size, &blobKey)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *AttachmentPostgres) GetAttachmentByID(ctx context.Context, id uint64) (*models.Attachment, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, attachmentColumns, attachmentTable)
+	var attachment models.Attachment
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &attachment, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentPostgres) GetAllTaskAttachments(ctx context.Context, taskID uint64) ([]models.Attachment, error) {
+	query := fmt.Sprintf(`
+SELECT %s FROM %s WHERE task_id = $1 ORDER BY id ASC`, attachmentColumns, attachmentTable)
+	var attachments []models.Attachment
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &attachments, query, &taskID)
+
+	return attachments, err
+}
+
+// DeleteAttachment deletes attachment. Its blob is queued for deletion by db trigger.
+func (r *AttachmentPostgres) DeleteAttachment(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, attachmentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAttachments deletes attachments by ids. Their blobs are queued for deletion by db trigger.
+func (r *AttachmentPostgres) DeleteAttachments(ctx context.Context, ids []uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = ANY($1)`, attachmentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, pq.Array(ids)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// BlobPostgres keeps queue of blobs to delete from blob storage. Blobs are queued by db triggers
+// when entities owning them are deleted.
+type BlobPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewBlobPostgres(db *sqlx.DB, dbTimeout time.Duration) *BlobPostgres {
+	return &BlobPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// GetDeletedBlobKeys returns keys of blobs queued for deletion in order of queuing.
+func (r *BlobPostgres) GetDeletedBlobKeys(ctx context.Context, limit int) ([]string, error) {
+	query := fmt.Sprintf(`
+SELECT blob_key FROM %s ORDER BY deleted_at ASC, blob_key ASC LIMIT $1`, deletedBlobTable)
+	var keys []string
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &keys, query, &limit)
+
+	return keys, err
+}
+
+// DeleteDeletedBlobKeys removes keys of blobs which were deleted from storage.
+func (r *BlobPostgres) DeleteDeletedBlobKeys(ctx context.Context, keys []string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE blob_key = ANY($1)`, deletedBlobTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, pq.Array(keys)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+type CommentPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewCommentPostgres(db *sqlx.DB, dbTimeout time.Duration) *CommentPostgres {
+	return &CommentPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *CommentPostgres) CreateComment(
+	ctx context.Context, comment models.CommentToCreate, authorID uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (task_id, parent_id, author_id, text) values ($1, $2, $3, $4) RETURNING id`, commentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &comment.TaskID, &comment.ParentID, &authorID, &comment.Text)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *CommentPostgres) GetCommentByID(ctx context.Context, id uint64) (*models.Comment, error) {
+	query := fmt.Sprintf(`
+SELECT c.id, c.task_id, c.parent_id, c.author_id, c.text, c.created_at, c.updated_at,
+EXISTS(SELECT 1 FROM %s WHERE comment_id = c.id) AS is_edited
+FROM %s AS c WHERE c.id = $1`, commentEditTable, commentTable)
+	var comment models.Comment
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &comment, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// UpdateComment updates comment text and saves previous text to the edit history.
+func (r *CommentPostgres) UpdateComment(ctx context.Context, id uint64, text string) error {
+	saveEditQuery := fmt.Sprintf(`
+INSERT INTO %s (comment_id, text) SELECT id, text FROM %s WHERE id = $1`, commentEditTable, commentTable)
+	updateQuery := fmt.Sprintf(`UPDATE %s SET text = $1 WHERE id = $2`, commentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(dbCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(dbCtx, saveEditQuery, &id); err != nil {
+		return getDBError(err)
+	}
+
+	if _, err = tx.ExecContext(dbCtx, updateQuery, &text, &id); err != nil {
+		return getDBError(err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *CommentPostgres) GetAllTaskComments(ctx context.Context, taskID uint64) ([]models.Comment, error) {
+	query := fmt.Sprintf(`
+SELECT c.id, c.task_id, c.parent_id, c.author_id, c.text, c.created_at, c.updated_at,
+EXISTS(SELECT 1 FROM %s WHERE comment_id = c.id) AS is_edited
+FROM %s AS c WHERE c.task_id = $1 ORDER BY c.id ASC`, commentEditTable, commentTable)
+	var comments []models.Comment
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &comments, query, &taskID)
+
+	return comments, err
+}
+
+func (r *CommentPostgres) GetCommentEdits(ctx context.Context, commentID uint64) ([]models.CommentEdit, error) {
+	query := fmt.Sprintf(`
+SELECT id, comment_id, text, edited_at FROM %s WHERE comment_id = $1 ORDER BY id ASC`, commentEditTable)
+	var edits []models.CommentEdit
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &edits, query, &commentID)
+
+	return edits, err
+}
+
+func (r *CommentPostgres) DeleteComment(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, commentTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestCommentPostgres_UpdateComment_SavesEdit(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	r := NewCommentPostgres(db, testDBTimeout)
+
+	id, err := r.CreateComment(context.Background(), models.CommentToCreate{
+		TaskID: p.createTask(t, nil), Text: "first",
+	}, p.UserID)
+	if err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+
+	for _, text := range []string{"second", "third"} {
+		if err = r.UpdateComment(context.Background(), id, text); err != nil {
+			t.Fatalf("UpdateComment() error = %v", err)
+		}
+	}
+
+	comment, err := r.GetCommentByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetCommentByID() error = %v", err)
+	}
+
+	if comment.Text != "third" || !comment.IsEdited {
+		t.Errorf("comment text = %q, is edited %v, want %q edited", comment.Text, comment.IsEdited, "third")
+	}
+
+	edits, err := r.GetCommentEdits(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetCommentEdits() error = %v", err)
+	}
+
+	// edits keep previous texts in order of editing
+	wantTexts := []string{"first", "second"}
+	if len(edits) != len(wantTexts) {
+		t.Fatalf("edits = %+v, want texts %v", edits, wantTexts)
+	}
+
+	for i, edit := range edits {
+		if edit.Text != wantTexts[i] || edit.CommentID != id {
+			t.Errorf("edit %d = %+v, want text %q", i, edit, wantTexts[i])
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+type LabelPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewLabelPostgres(db *sqlx.DB, dbTimeout time.Duration) *LabelPostgres {
+	return &LabelPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *LabelPostgres) Create(ctx context.Context, label models.LabelToCreate) (int64, error) {
+	query := fmt.Sprintf(`INSERT INTO %s (project_id, name, color) values ($1, $2, $3) RETURNING id`, labelTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &label.ProjectID, &label.Name, &label.Color)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *LabelPostgres) GetByID(ctx context.Context, id int64) (*models.Label, error) {
+	query := fmt.Sprintf(`SELECT id, project_id, name, color FROM %s WHERE id=$1`, labelTable)
+	var label models.Label
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &label, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &label, nil
+}
+
+func (r *LabelPostgres) Update(ctx context.Context, label models.Label) error {
+	query := fmt.Sprintf(`UPDATE %s SET name = :name, color = :color WHERE id = :id`, labelTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	_, err := r.db.NamedExecContext(dbCtx, query, &label)
+	if err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *LabelPostgres) GetAllToProject(ctx context.Context, projectID uint64) ([]models.Label, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, name, color FROM %s WHERE project_id = $1 ORDER BY name ASC`, labelTable)
+	var labels []models.Label
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &labels, query, &projectID)
+
+	return labels, err
+}
+
+func (r *LabelPostgres) Delete(ctx context.Context, id int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, labelTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *LabelPostgres) GetAllToTask(ctx context.Context, taskID uint64) ([]models.Label, error) {
+	query := fmt.Sprintf(`
+SELECT l.id, l.project_id, l.name, l.color FROM %s AS l
+INNER JOIN %s AS tl ON tl.label_id = l.id
+WHERE tl.task_id = $1 ORDER BY l.name ASC`, labelTable, taskLabelTable)
+	var labels []models.Label
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &labels, query, &taskID)
+
+	return labels, err
+}
+
+// AddToTask adds label to task. Adding of label which task already has is ignored.
+func (r *LabelPostgres) AddToTask(ctx context.Context, taskID uint64, labelID int64) error {
+	query := fmt.Sprintf(`
+INSERT INTO %s (task_id, label_id) values ($1, $2) ON CONFLICT (task_id, label_id) DO NOTHING`, taskLabelTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &taskID, &labelID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *LabelPostgres) DeleteFromTask(ctx context.Context, taskID uint64, labelID int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE task_id = $1 AND label_id = $2`, taskLabelTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &taskID, &labelID); err != nil {
+		return err
+	}
+
+	return nil
+}
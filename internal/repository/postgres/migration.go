@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"database/sql"
-	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -22,8 +21,8 @@ func MigrateSchema(db *sql.DB, cfg config.PostgresDB) error {
 		return err
 	}
 
-	if err = m.Steps(1); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+	if err = m.Up(); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
 			return nil
 		}
 
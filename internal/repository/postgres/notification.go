@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+type NotificationPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewNotificationPostgres(db *sqlx.DB, dbTimeout time.Duration) *NotificationPostgres {
+	return &NotificationPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// GetNotificationSettings returns settings of the user. Notifications are enabled if settings were not saved.
+func (r *NotificationPostgres) GetNotificationSettings(
+	ctx context.Context, userID uint64,
+) (*models.NotificationSettings, error) {
+	query := fmt.Sprintf(`
+SELECT u.id AS user_id, COALESCE(s.is_daily_digest_enabled, TRUE) AS is_daily_digest_enabled,
+COALESCE(s.is_due_reminder_enabled, TRUE) AS is_due_reminder_enabled
+FROM %s AS u LEFT JOIN %s AS s ON s.user_id = u.id WHERE u.id = $1`, userTable, notificationSettingTable)
+	var settings models.NotificationSettings
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &settings, query, &userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *NotificationPostgres) UpdateNotificationSettings(
+	ctx context.Context, settings models.NotificationSettings,
+) error {
+	query := fmt.Sprintf(`
+INSERT INTO %s (user_id, is_daily_digest_enabled, is_due_reminder_enabled) VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET is_daily_digest_enabled = EXCLUDED.is_daily_digest_enabled,
+is_due_reminder_enabled = EXCLUDED.is_due_reminder_enabled`, notificationSettingTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query,
+		&settings.UserID, &settings.IsDailyDigestEnabled, &settings.IsDueReminderEnabled,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// GetDailyDigestTasks returns tasks changed since the time for assignees with enabled daily digest.
+func (r *NotificationPostgres) GetDailyDigestTasks(
+	ctx context.Context, since, until time.Time,
+) ([]models.TaskNotification, error) {
+	query := fmt.Sprintf(`
+SELECT u.id AS user_id, u.email, u.firstname, %[1]s.id AS task_id, %[1]s.title AS task_title,
+p.name AS project_name, %[1]s.due_date
+FROM %[1]s
+INNER JOIN %[2]s AS u ON u.id = %[1]s.assignee_id
+INNER JOIN %[3]s AS p ON p.id = %[1]s.project_id
+LEFT JOIN %[4]s AS s ON s.user_id = u.id
+WHERE %[1]s.updated_at >= $1 AND %[1]s.updated_at < $2 AND u.is_email_confirmed AND COALESCE(s.is_daily_digest_enabled, TRUE)
+ORDER BY u.id, %[1]s.id`, taskTable, userTable, projectTable, notificationSettingTable)
+	var tasks []models.TaskNotification
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &tasks, query, &since, &until)
+
+	return tasks, err
+}
+
+// GetDueSoonTasks returns not done tasks with due date in the given number of days
+// for assignees with enabled due reminders.
+func (r *NotificationPostgres) GetDueSoonTasks(ctx context.Context, days int) ([]models.TaskNotification, error) {
+	query := fmt.Sprintf(`
+SELECT u.id AS user_id, u.email, u.firstname, %[1]s.id AS task_id, %[1]s.title AS task_title,
+p.name AS project_name, %[1]s.due_date
+FROM %[1]s
+INNER JOIN %[2]s AS u ON u.id = %[1]s.assignee_id
+INNER JOIN %[3]s AS p ON p.id = %[1]s.project_id
+LEFT JOIN %[4]s AS s ON s.user_id = u.id
+WHERE %[1]s.due_date BETWEEN CURRENT_DATE AND CURRENT_DATE + $1::INT AND %[5]s AND
+u.is_email_confirmed AND COALESCE(s.is_due_reminder_enabled, TRUE)
+ORDER BY u.id, %[1]s.due_date, %[1]s.id`,
+		taskTable, userTable, projectTable, notificationSettingTable, notDoneTaskCondition)
+	var tasks []models.TaskNotification
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &tasks, query, &days)
+
+	return tasks, err
+}
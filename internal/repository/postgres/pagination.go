@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+type (
+	// sortColumn is sql expression used to sort by field and type of its cursor value.
+	// Expression must not be null for keyset pagination, so nullable columns are coalesced.
+	sortColumn struct {
+		expr     string
+		castType string
+	}
+	pageQuery struct {
+		query      string
+		args       []interface{}
+		countQuery string
+		countArgs  []interface{}
+	}
+)
+
+var (
+	userSortColumns = map[models.SortField]sortColumn{
+		models.SortFieldID:        {expr: "id", castType: "BIGINT"},
+		models.SortFieldCreatedAt: {expr: "created_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldEmail:     {expr: "email", castType: "TEXT"},
+	}
+	projectSortColumns = map[models.SortField]sortColumn{
+		models.SortFieldID:        {expr: "id", castType: "BIGINT"},
+		models.SortFieldCreatedAt: {expr: "created_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldName:      {expr: "name", castType: "TEXT"},
+	}
+	taskSortColumns = map[models.SortField]sortColumn{
+		models.SortFieldID:        {expr: "id", castType: "BIGINT"},
+		models.SortFieldCreatedAt: {expr: "created_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+		models.SortFieldTitle:     {expr: "title", castType: "TEXT"},
+		// tasks without due date are the last in ascending order
+		models.SortFieldDueDate: {expr: "COALESCE(due_date, 'infinity'::DATE)", castType: "DATE"},
+	}
+	activitySortColumns = map[models.SortField]sortColumn{
+		models.SortFieldID:        {expr: "id", castType: "BIGINT"},
+		models.SortFieldCreatedAt: {expr: "created_at", castType: "TIMESTAMPTZ"},
+	}
+)
+
+// newPageQuery builds query of items page and query of total count of items.
+// Condition can use args as $1..$n, empty condition selects all rows of table.
+// One more item than limit is selected to know if there is next page.
+func newPageQuery(
+	columns, table, condition string, args []interface{},
+	page models.PageRequest, sortColumns map[models.SortField]sortColumn,
+) (pageQuery, error) {
+	column, ok := sortColumns[page.SortField]
+	if !ok {
+		return pageQuery{}, fmt.Errorf("not supported sort field %q", page.SortField)
+	}
+
+	var conditions []string
+	if condition != "" {
+		conditions = append(conditions, "("+condition+")")
+	}
+
+	q := pageQuery{
+		countArgs: args,
+	}
+	q.args = append(q.args, args...)
+
+	if page.WithTotal {
+		q.countQuery = fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table, whereClause(conditions))
+	}
+
+	order, comparison := "ASC", ">"
+	if page.SortDesc {
+		order, comparison = "DESC", "<"
+	}
+
+	if page.After != nil {
+		if page.SortField == models.SortFieldID {
+			q.args = append(q.args, page.After.ID)
+			conditions = append(conditions, fmt.Sprintf("id %s $%d", comparison, len(q.args)))
+		} else {
+			q.args = append(q.args, page.After.SortValue, page.After.ID)
+			conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)",
+				column.expr, comparison, len(q.args)-1, column.castType, len(q.args)))
+		}
+	}
+
+	orderBy := fmt.Sprintf("id %s", order)
+	if page.SortField != models.SortFieldID {
+		orderBy = fmt.Sprintf("%s %s, id %s", column.expr, order, order)
+	}
+
+	q.args = append(q.args, page.Limit+1)
+	q.query = fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY %s LIMIT $%d`,
+		columns, table, whereClause(conditions), orderBy, len(q.args))
+
+	return q, nil
+}
+
+// selectPage selects page items to dest and returns total count of items if it was requested.
+func selectPage(ctx context.Context, db *sqlx.DB, dest interface{}, q pageQuery) (int64, error) {
+	if err := db.SelectContext(ctx, dest, q.query, q.args...); err != nil {
+		return 0, err
+	}
+
+	if q.countQuery == "" {
+		return 0, nil
+	}
+
+	var total int64
+	if err := db.GetContext(ctx, &total, q.countQuery, q.countArgs...); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
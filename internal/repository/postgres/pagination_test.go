@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestNewPageQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     models.PageRequest
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "first page by id",
+			page:     models.PageRequest{SortField: models.SortFieldID, Limit: 10},
+			wantSQL:  `SELECT id FROM items WHERE (project_id = $1) ORDER BY id ASC LIMIT $2`,
+			wantArgs: []interface{}{uint64(1), 11},
+		},
+		{
+			name: "next page by id in descending order",
+			page: models.PageRequest{
+				SortField: models.SortFieldID, SortDesc: true, Limit: 10,
+				After: &models.PageCursor{ID: 5},
+			},
+			wantSQL:  `SELECT id FROM items WHERE (project_id = $1) AND id < $2 ORDER BY id DESC LIMIT $3`,
+			wantArgs: []interface{}{uint64(1), uint64(5), 11},
+		},
+		{
+			// rows with the same created_at are ordered by id, so they are not skipped or repeated
+			name: "next page by time",
+			page: models.PageRequest{
+				SortField: models.SortFieldCreatedAt, Limit: 10,
+				After: &models.PageCursor{SortValue: "2026-10-01T12:00:00Z", ID: 5},
+			},
+			wantSQL: `SELECT id FROM items WHERE (project_id = $1) AND (created_at, id) > ($2::TIMESTAMPTZ, $3) ` +
+				`ORDER BY created_at ASC, id ASC LIMIT $4`,
+			wantArgs: []interface{}{uint64(1), "2026-10-01T12:00:00Z", uint64(5), 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := newPageQuery("id", "items", "project_id = $1", []interface{}{uint64(1)}, tt.page, taskSortColumns)
+			if err != nil {
+				t.Fatalf("newPageQuery() error = %v", err)
+			}
+
+			if q.query != tt.wantSQL {
+				t.Errorf("query = %s, want %s", q.query, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(q.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", q.args, tt.wantArgs)
+			}
+		})
+	}
+
+	t.Run("not supported sort field", func(t *testing.T) {
+		page := models.PageRequest{SortField: models.SortFieldEmail, Limit: 10}
+		if _, err := newPageQuery("id", "items", "", nil, page, taskSortColumns); err == nil {
+			t.Error("newPageQuery() error = nil, want error")
+		}
+	})
+}
+
+func TestTaskPostgres_GetAllTasksToProject_SameSortValue(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	r := NewTaskPostgres(db, testDBTimeout)
+
+	wantIDs := make(map[uint64]bool)
+	for i := 0; i < 5; i++ {
+		wantIDs[p.createTask(t, nil)] = true
+	}
+
+	if _, err := db.Exec(`UPDATE `+taskTable+` SET created_at = $2 WHERE project_id = $1`,
+		p.ID, time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("failed to set created_at: %v", err)
+	}
+
+	for _, sortDesc := range []bool{false, true} {
+		page := models.PageRequest{SortField: models.SortFieldCreatedAt, SortDesc: sortDesc, Limit: 2}
+		gotIDs := make(map[uint64]bool)
+
+		for {
+			tasks, _, err := r.GetAllTasksToProject(context.Background(), p.ID, page)
+			if err != nil {
+				t.Fatalf("GetAllTasksToProject() error = %v", err)
+			}
+
+			if len(tasks) > page.Limit {
+				tasks = tasks[:page.Limit]
+			}
+
+			for _, task := range tasks {
+				if gotIDs[task.ID] {
+					t.Fatalf("task %d is repeated, desc %v", task.ID, sortDesc)
+				}
+
+				gotIDs[task.ID] = true
+			}
+
+			if len(tasks) < page.Limit {
+				break
+			}
+
+			last := tasks[len(tasks)-1]
+			page.After = &models.PageCursor{SortValue: models.FormatSortTime(last.CreatedAt), ID: last.ID}
+		}
+
+		if !reflect.DeepEqual(gotIDs, wantIDs) {
+			t.Errorf("tasks = %v, want %v, desc %v", gotIDs, wantIDs, sortDesc)
+		}
+	}
+}
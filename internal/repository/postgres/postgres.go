@@ -1,7 +1,10 @@
 package postgres
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/l-orlov/task-tracker/internal/config"
@@ -10,17 +13,39 @@ import (
 )
 
 const (
-	userTable             = "r_user"
-	projectTable          = "r_project"
-	importanceStatusTable = "s_project_importance_status"
-	progressStatusTable   = "s_project_progress_status"
-	projectUserTable      = "nn_project_user"
-	taskTable             = "r_task"
+	userTable                = "r_user"
+	projectTable             = "r_project"
+	importanceStatusTable    = "s_project_importance_status"
+	progressStatusTable      = "s_project_progress_status"
+	labelTable               = "s_project_label"
+	statusTransitionTable    = "s_project_status_transition"
+	projectUserTable         = "nn_project_user"
+	taskTable                = "r_task"
+	taskLabelTable           = "nn_task_label"
+	taskLinkTable            = "r_task_link"
+	commentTable             = "r_task_comment"
+	commentEditTable         = "h_task_comment_edit"
+	taskActivityTable        = "h_task_activity"
+	taskStatusChangeTable    = "h_task_status_change"
+	sprintTable              = "r_sprint"
+	taskSprintChangeTable    = "h_task_sprint_change"
+	worklogTable             = "r_task_worklog"
+	attachmentTable          = "r_task_attachment"
+	deletedBlobTable         = "h_deleted_blob"
+	notificationSettingTable = "r_user_notification_setting"
+	webhookTable             = "r_project_webhook"
+	webhookDeliveryTable     = "h_webhook_delivery"
+	taskFilterTable          = "r_task_filter"
 
 	fnGetProjectBoard                       = "get_project_board"
 	fnUpdateProjectBoardParts               = "update_project_board_parts"
 	fnUpdateProjectBoardProgressStatuses    = "update_project_board_progress_statuses"
 	fnUpdateProjectBoardProgressStatusTasks = "update_project_board_progress_status_tasks"
+	fnGetProjectBoardVersion                = "get_project_board_version"
+	fnLockProjectBoard                      = "lock_project_board"
+
+	// errCodeConflict is custom error code raised by db functions on version conflict
+	errCodeConflict = "TT409"
 )
 
 func ConnectToDB(cfg config.PostgresDB) (*sqlx.DB, error) {
@@ -43,6 +68,10 @@ func initConnectionString(cfg config.PostgresDB) string {
 
 func getDBError(err error) error {
 	if err, ok := err.(*pq.Error); ok {
+		if err.Code == errCodeConflict {
+			return ierrors.NewConflict(err, "")
+		}
+
 		if err.Code.Class() < "50" { // business error
 			return ierrors.NewBusiness(err, err.Detail)
 		}
@@ -52,3 +81,10 @@ func getDBError(err error) error {
 
 	return err
 }
+
+// setActor sets user making changes in transaction. It is used by triggers to log task activity.
+func setActor(ctx context.Context, tx *sql.Tx, actorID uint64) error {
+	_, err := tx.ExecContext(ctx, `SELECT set_config('app.actor_id', $1, true)`, strconv.FormatUint(actorID, 10))
+
+	return err
+}
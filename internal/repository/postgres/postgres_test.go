@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/config"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/lib/pq"
+)
+
+const (
+	// testDBEnv is environment variable with connection string of database for tests of db functions.
+	// Tests using database are skipped if it is not set.
+	testDBEnv        = "TEST_PG_DSN"
+	testMigrationDir = "../../../schema"
+	testDBTimeout    = 5 * time.Second
+)
+
+// testProject is project created in test database with its owner and default statuses.
+type testProject struct {
+	db                 *sqlx.DB
+	ID                 uint64
+	UserID             uint64
+	ImportanceStatusID int64
+	ProgressStatusID   int64
+}
+
+// newTestDB connects to test database and migrates its schema.
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testDBEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDBEnv)
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err = MigrateSchema(db.DB, config.PostgresDB{MigrationDir: testMigrationDir}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+
+	return db
+}
+
+// newTestProject creates project with its owner. Project is deleted with its tasks on test cleanup.
+func newTestProject(t *testing.T, db *sqlx.DB) testProject {
+	t.Helper()
+
+	p := testProject{db: db}
+	email := fmt.Sprintf("test-%d@example.com", time.Now().UnixNano())
+	if err := db.Get(&p.UserID, `INSERT INTO `+userTable+` (email) VALUES ($1) RETURNING id`, email); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	if err := db.Get(&p.ID, `INSERT INTO `+projectTable+` (name) VALUES ('test') RETURNING id`); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM `+projectTable+` WHERE id = $1`, p.ID)
+		db.Exec(`DELETE FROM `+userTable+` WHERE id = $1`, p.UserID)
+	})
+
+	if err := db.Get(&p.ImportanceStatusID, `
+SELECT id FROM `+importanceStatusTable+` WHERE project_id = $1 ORDER BY id LIMIT 1`, p.ID); err != nil {
+		t.Fatalf("failed to get importance status: %v", err)
+	}
+
+	if err := db.Get(&p.ProgressStatusID, `
+SELECT id FROM `+progressStatusTable+` WHERE project_id = $1 ORDER BY order_num LIMIT 1`, p.ID); err != nil {
+		t.Fatalf("failed to get progress status: %v", err)
+	}
+
+	return p
+}
+
+// createTask creates task of the project. Nil parent id creates root task.
+func (p testProject) createTask(t *testing.T, parentID *uint64) uint64 {
+	t.Helper()
+
+	id, err := NewTaskPostgres(p.db, testDBTimeout).CreateTaskToProject(context.Background(), models.TaskToCreate{
+		ProjectID:          p.ID,
+		Title:              "test",
+		AssigneeID:         p.UserID,
+		ImportanceStatusID: p.ImportanceStatusID,
+		ProgressStatusID:   p.ProgressStatusID,
+		ParentID:           parentID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	return id
+}
+
+// doneProgressStatusID returns id of progress status of the project having done category.
+func (p testProject) doneProgressStatusID(t *testing.T) int64 {
+	t.Helper()
+
+	var id int64
+	if err := p.db.Get(&id, `
+SELECT id FROM `+progressStatusTable+` WHERE project_id = $1 AND category = $2 ORDER BY id LIMIT 1`,
+		p.ID, models.ProgressStatusCategoryDone); err != nil {
+		t.Fatalf("failed to get done progress status: %v", err)
+	}
+
+	return id
+}
+
+// checkBusinessError checks that err is business error with the detail.
+func checkBusinessError(t *testing.T, err error, wantDetail string) {
+	t.Helper()
+
+	var customErr *ierrors.Error
+	if !errors.As(err, &customErr) || customErr.Level != ierrors.Business {
+		t.Fatalf("error = %v, want business error", err)
+	}
+
+	if customErr.Detail != wantDetail {
+		t.Errorf("detail = %q, want %q", customErr.Detail, wantDetail)
+	}
+}
+
+func TestGetDBError(t *testing.T) {
+	t.Run("check violation is business error with detail", func(t *testing.T) {
+		err := getDBError(&pq.Error{Code: "23514", Message: "task hierarchy cycle", Detail: "some detail"})
+		checkBusinessError(t, err, "some detail")
+	})
+
+	t.Run("version conflict is conflict error", func(t *testing.T) {
+		var customErr *ierrors.Error
+		err := getDBError(&pq.Error{Code: errCodeConflict})
+		if !errors.As(err, &customErr) || customErr.Level != ierrors.Conflict {
+			t.Errorf("error = %v, want conflict error", err)
+		}
+	})
+
+	t.Run("internal error is server error", func(t *testing.T) {
+		var customErr *ierrors.Error
+		err := getDBError(&pq.Error{Code: "XX000"})
+		if !errors.As(err, &customErr) || customErr.Level != ierrors.Server {
+			t.Errorf("error = %v, want server error", err)
+		}
+	})
+}
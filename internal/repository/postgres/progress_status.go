@@ -25,12 +25,12 @@ func NewProgressStatusPostgres(db *sqlx.DB, dbTimeout time.Duration) *ProgressSt
 
 func (r *ProgressStatusPostgres) Create(ctx context.Context, status models.ProgressStatusToCreate) (int64, error) {
 	query := fmt.Sprintf(`
-INSERT INTO %s (project_id, name, order_num) values ($1, $2, $3) RETURNING id`, progressStatusTable)
+INSERT INTO %s (project_id, name, order_num, category) values ($1, $2, $3, $4) RETURNING id`, progressStatusTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	row := r.db.QueryRowContext(dbCtx, query, &status.ProjectID, &status.Name, &status.OrderNum)
+	row := r.db.QueryRowContext(dbCtx, query, &status.ProjectID, &status.Name, &status.OrderNum, &status.Category)
 	if err := row.Err(); err != nil {
 		return 0, getDBError(err)
 	}
@@ -44,7 +44,7 @@ INSERT INTO %s (project_id, name, order_num) values ($1, $2, $3) RETURNING id`,
 }
 
 func (r *ProgressStatusPostgres) GetByID(ctx context.Context, id int64) (*models.ProgressStatus, error) {
-	query := fmt.Sprintf(`SELECT id, project_id, name, order_num FROM %s WHERE id=$1`, progressStatusTable)
+	query := fmt.Sprintf(`SELECT id, project_id, name, order_num, category FROM %s WHERE id=$1`, progressStatusTable)
 	var status models.ProgressStatus
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -63,7 +63,8 @@ func (r *ProgressStatusPostgres) GetByID(ctx context.Context, id int64) (*models
 
 func (r *ProgressStatusPostgres) Update(ctx context.Context, status models.ProgressStatus) error {
 	query := fmt.Sprintf(`
-UPDATE %s SET name = :name, order_num = :order_num WHERE id = :id`, progressStatusTable)
+UPDATE %s SET name = :name, order_num = :order_num, category = COALESCE(NULLIF(:category, ''), category)
+WHERE id = :id`, progressStatusTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
@@ -78,7 +79,7 @@ UPDATE %s SET name = :name, order_num = :order_num WHERE id = :id`, progressStat
 
 func (r *ProgressStatusPostgres) GetAll(ctx context.Context) ([]models.ProgressStatus, error) {
 	query := fmt.Sprintf(`
-SELECT id, project_id, name, order_num FROM %s ORDER BY id ASC`, progressStatusTable)
+SELECT id, project_id, name, order_num, category FROM %s ORDER BY id ASC`, progressStatusTable)
 	var statuses []models.ProgressStatus
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -91,7 +92,7 @@ SELECT id, project_id, name, order_num FROM %s ORDER BY id ASC`, progressStatusT
 
 func (r *ProgressStatusPostgres) GetAllToProject(ctx context.Context, projectID uint64) ([]models.ProgressStatus, error) {
 	query := fmt.Sprintf(`
-SELECT id, project_id, name, order_num FROM %s WHERE project_id = $1 ORDER BY id ASC`, progressStatusTable)
+SELECT id, project_id, name, order_num, category FROM %s WHERE project_id = $1 ORDER BY id ASC`, progressStatusTable)
 	var statuses []models.ProgressStatus
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestTaskCompletedAt(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	taskRepo := NewTaskPostgres(db, testDBTimeout)
+	statusRepo := NewProgressStatusPostgres(db, testDBTimeout)
+
+	doneStatusID := p.doneProgressStatusID(t)
+	taskID := p.createTask(t, nil)
+
+	setTaskStatus := func(t *testing.T, statusID int64) {
+		t.Helper()
+
+		task, err := taskRepo.GetTaskByID(context.Background(), taskID)
+		if err != nil {
+			t.Fatalf("GetTaskByID() error = %v", err)
+		}
+
+		task.ProgressStatusID = statusID
+		if _, err = taskRepo.UpdateTask(context.Background(), *task, p.UserID); err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+	}
+
+	setStatusCategory := func(t *testing.T, statusID int64, category models.ProgressStatusCategory) {
+		t.Helper()
+
+		status, err := statusRepo.GetByID(context.Background(), statusID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+
+		status.Category = category
+		if err = statusRepo.Update(context.Background(), *status); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	checkCompleted := func(t *testing.T, wantCompleted bool) {
+		t.Helper()
+
+		task, err := taskRepo.GetTaskByID(context.Background(), taskID)
+		if err != nil {
+			t.Fatalf("GetTaskByID() error = %v", err)
+		}
+
+		if isCompleted := task.CompletedAt != nil; isCompleted != wantCompleted {
+			t.Errorf("completed at = %v, want completed %v", task.CompletedAt, wantCompleted)
+		}
+	}
+
+	t.Run("new task is not completed", func(t *testing.T) {
+		checkCompleted(t, false)
+	})
+
+	t.Run("task is moved to done status", func(t *testing.T) {
+		setTaskStatus(t, doneStatusID)
+		checkCompleted(t, true)
+	})
+
+	t.Run("task is reopened", func(t *testing.T) {
+		setTaskStatus(t, p.ProgressStatusID)
+		checkCompleted(t, false)
+	})
+
+	t.Run("category of task status is changed from done", func(t *testing.T) {
+		setTaskStatus(t, doneStatusID)
+		setStatusCategory(t, doneStatusID, models.ProgressStatusCategoryInProgress)
+		checkCompleted(t, false)
+	})
+
+	t.Run("category of task status is changed to done", func(t *testing.T) {
+		setStatusCategory(t, doneStatusID, models.ProgressStatusCategoryDone)
+		checkCompleted(t, true)
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const projectColumns = `id, name, description, created_at, updated_at`
+
 type ProjectPostgres struct {
 	db        *sqlx.DB
 	dbTimeout time.Duration
@@ -29,18 +31,18 @@ func (r *ProjectPostgres) CreateProject(
 	createProjectQuery := fmt.Sprintf(`
 INSERT INTO %s (name, description) values ($1, $2) RETURNING id`, projectTable)
 	addProjectUserQuery := fmt.Sprintf(`
-INSERT INTO %s (project_id, user_id, is_owner) values ($1, $2, 'TRUE')`, projectUserTable)
+INSERT INTO %s (project_id, user_id, role) values ($1, $2, $3)`, projectUserTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := r.db.BeginTx(dbCtx, nil)
 	if err != nil {
 		return 0, err
 	}
 	defer tx.Rollback()
 
-	row := r.db.QueryRowContext(dbCtx, createProjectQuery, &project.Name, &project.Description)
+	row := tx.QueryRowContext(dbCtx, createProjectQuery, &project.Name, &project.Description)
 	if err := row.Err(); err != nil {
 		return 0, getDBError(err)
 	}
@@ -50,7 +52,7 @@ INSERT INTO %s (project_id, user_id, is_owner) values ($1, $2, 'TRUE')`, project
 		return 0, err
 	}
 
-	if _, err = tx.ExecContext(dbCtx, addProjectUserQuery, id, owner); err != nil {
+	if _, err = tx.ExecContext(dbCtx, addProjectUserQuery, id, owner, models.ProjectRoleOwner); err != nil {
 		return 0, err
 	}
 
@@ -63,7 +65,7 @@ INSERT INTO %s (project_id, user_id, is_owner) values ($1, $2, 'TRUE')`, project
 
 func (r *ProjectPostgres) GetProjectByID(ctx context.Context, id uint64) (*models.Project, error) {
 	query := fmt.Sprintf(`
-SELECT id, name, description FROM %s WHERE id = $1`, projectTable)
+SELECT id, name, description, created_at, updated_at FROM %s WHERE id = $1`, projectTable)
 	var project models.Project
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -80,54 +82,54 @@ SELECT id, name, description FROM %s WHERE id = $1`, projectTable)
 	return &project, nil
 }
 
-func (r *ProjectPostgres) UpdateProject(ctx context.Context, project models.Project) error {
+func (r *ProjectPostgres) GetProjectTaskCounts(ctx context.Context, projectID uint64) (*models.ProjectTaskCounts, error) {
 	query := fmt.Sprintf(`
-UPDATE %s SET name = :name, description = :description WHERE id = :id`, projectTable)
+SELECT $1::BIGINT AS project_id, COUNT(*) FILTER (WHERE completed_at IS NULL) AS open,
+COUNT(*) FILTER (WHERE completed_at IS NOT NULL) AS completed
+FROM %s WHERE project_id = $1`, taskTable)
+	var counts models.ProjectTaskCounts
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.NamedExecContext(dbCtx, query, &project)
-	if err != nil {
-		return err
+	if err := r.db.GetContext(dbCtx, &counts, query, &projectID); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &counts, nil
 }
 
-func (r *ProjectPostgres) GetAllProjects(ctx context.Context) ([]models.Project, error) {
+func (r *ProjectPostgres) UpdateProject(ctx context.Context, project models.Project) error {
 	query := fmt.Sprintf(`
-SELECT id, name, description FROM %s ORDER BY id ASC`, projectTable)
-	var projects []models.Project
+UPDATE %s SET name = :name, description = :description WHERE id = :id`, projectTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err := r.db.SelectContext(dbCtx, &projects, query)
+	_, err := r.db.NamedExecContext(dbCtx, query, &project)
+	if err != nil {
+		return err
+	}
 
-	return projects, err
+	return nil
 }
 
-func (r *ProjectPostgres) GetAllProjectsToUser(ctx context.Context, userID uint64) ([]models.Project, error) {
-	query := fmt.Sprintf(`
-SELECT p.id, p.name, p.description
-FROM %s AS p INNER JOIN %s AS pu ON p.id = pu.project_id
-WHERE pu.user_id = $1 ORDER BY p.id ASC`, projectTable, projectUserTable)
-	var projects []models.Project
-
-	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
-	defer cancel()
+func (r *ProjectPostgres) GetAllProjects(ctx context.Context, page models.PageRequest) ([]models.Project, int64, error) {
+	return r.getProjectsPage(ctx, "", nil, page)
+}
 
-	err := r.db.SelectContext(dbCtx, &projects, query, &userID)
+func (r *ProjectPostgres) GetAllProjectsToUser(
+	ctx context.Context, userID uint64, page models.PageRequest,
+) ([]models.Project, int64, error) {
+	condition := fmt.Sprintf(`id IN (SELECT project_id FROM %s WHERE user_id = $1)`, projectUserTable)
 
-	return projects, err
+	return r.getProjectsPage(ctx, condition, []interface{}{userID}, page)
 }
 
-func (r *ProjectPostgres) GetAllProjectsWithParameters(ctx context.Context, params models.ProjectParams) ([]models.Project, error) {
-	query := fmt.Sprintf(`
-SELECT id, name, description FROM %s
-WHERE (id = $1 OR $1 is null) AND (name ILIKE $2 OR $2 is null) AND (description ILIKE $3 OR $3 is null)
-ORDER BY id ASC`, projectTable)
+func (r *ProjectPostgres) GetAllProjectsWithParameters(
+	ctx context.Context, params models.ProjectParams, page models.PageRequest,
+) ([]models.Project, int64, error) {
+	condition := `(id = $1 OR $1 is null) AND (name ILIKE $2 OR $2 is null) AND (description ILIKE $3 OR $3 is null)`
 
 	if params.Name != nil {
 		*params.Name = "%%" + *params.Name + "%%"
@@ -137,14 +139,28 @@ ORDER BY id ASC`, projectTable)
 		*params.Description = "%%" + *params.Description + "%%"
 	}
 
+	return r.getProjectsPage(ctx, condition, []interface{}{params.ID, params.Name, params.Description}, page)
+}
+
+func (r *ProjectPostgres) getProjectsPage(
+	ctx context.Context, condition string, args []interface{}, page models.PageRequest,
+) ([]models.Project, int64, error) {
+	q, err := newPageQuery(projectColumns, projectTable, condition, args, page, projectSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	var projects []models.Project
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err := r.db.SelectContext(dbCtx, &projects, query, &params.ID, &params.Name, &params.Description)
+	total, err := selectPage(dbCtx, r.db, &projects, q)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return projects, err
+	return projects, total, nil
 }
 
 func (r *ProjectPostgres) DeleteProject(ctx context.Context, id uint64) error {
@@ -160,14 +176,16 @@ func (r *ProjectPostgres) DeleteProject(ctx context.Context, id uint64) error {
 	return nil
 }
 
-func (r *ProjectPostgres) AddUserToProject(ctx context.Context, projectID, userID uint64) error {
+func (r *ProjectPostgres) AddUserToProject(
+	ctx context.Context, projectID, userID uint64, role models.ProjectRole,
+) error {
 	query := fmt.Sprintf(`
-INSERT INTO %s (project_id, user_id, is_owner) values ($1, $2, 'FALSE')`, projectUserTable)
+INSERT INTO %s (project_id, user_id, role) values ($1, $2, $3)`, projectUserTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	if _, err := r.db.ExecContext(dbCtx, query, &projectID, &userID); err != nil {
+	if _, err := r.db.ExecContext(dbCtx, query, &projectID, &userID, &role); err != nil {
 		return getDBError(err)
 	}
 
@@ -176,7 +194,7 @@ INSERT INTO %s (project_id, user_id, is_owner) values ($1, $2, 'FALSE')`, projec
 
 func (r *ProjectPostgres) GetAllProjectUsers(ctx context.Context, projectID uint64) ([]models.ProjectUser, error) {
 	query := fmt.Sprintf(`
-SELECT u.id, u.email, u.firstname, u.lastname, pu.is_owner
+SELECT u.id, u.email, u.firstname, u.lastname, pu.role
 FROM %s AS u INNER JOIN %s AS pu ON u.id = pu.user_id
 WHERE pu.project_id = $1 ORDER BY u.id ASC`, userTable, projectUserTable)
 	var users []models.ProjectUser
@@ -191,6 +209,106 @@ WHERE pu.project_id = $1 ORDER BY u.id ASC`, userTable, projectUserTable)
 	return users, nil
 }
 
+func (r *ProjectPostgres) GetProjectUser(ctx context.Context, projectID, userID uint64) (*models.ProjectUser, error) {
+	query := fmt.Sprintf(`
+SELECT u.id, u.email, u.firstname, u.lastname, pu.role
+FROM %s AS u INNER JOIN %s AS pu ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.user_id = $2`, userTable, projectUserTable)
+	var user models.ProjectUser
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &user, query, &projectID, &userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *ProjectPostgres) UpdateProjectUserRole(
+	ctx context.Context, projectID, userID uint64, role models.ProjectRole,
+) error {
+	query := fmt.Sprintf(`UPDATE %s SET role = $1 WHERE project_id = $2 AND user_id = $3`, projectUserTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &role, &projectID, &userID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// TransferProjectOwnership makes user the owner of the project. Previous owner becomes an admin.
+func (r *ProjectPostgres) TransferProjectOwnership(ctx context.Context, projectID, userID uint64) error {
+	demoteOwnerQuery := fmt.Sprintf(`
+UPDATE %s SET role = $1 WHERE project_id = $2 AND role = $3`, projectUserTable)
+	promoteUserQuery := fmt.Sprintf(`
+UPDATE %s SET role = $1 WHERE project_id = $2 AND user_id = $3`, projectUserTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(dbCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(dbCtx, demoteOwnerQuery,
+		models.ProjectRoleAdmin, projectID, models.ProjectRoleOwner,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	if _, err = tx.ExecContext(dbCtx, promoteUserQuery,
+		models.ProjectRoleOwner, projectID, userID,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return tx.Commit()
+}
+
+// SetProjectOwner makes user the project owner. User who is not a member is added to the project.
+// Previous owner becomes the project admin.
+func (r *ProjectPostgres) SetProjectOwner(ctx context.Context, projectID, userID uint64) error {
+	demoteOwnerQuery := fmt.Sprintf(`
+UPDATE %s SET role = $1 WHERE project_id = $2 AND role = $3`, projectUserTable)
+	setOwnerQuery := fmt.Sprintf(`
+INSERT INTO %s (project_id, user_id, role) VALUES ($1, $2, $3)
+ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role`, projectUserTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(dbCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(dbCtx, demoteOwnerQuery,
+		models.ProjectRoleAdmin, projectID, models.ProjectRoleOwner,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	if _, err = tx.ExecContext(dbCtx, setOwnerQuery,
+		projectID, userID, models.ProjectRoleOwner,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return tx.Commit()
+}
+
 func (r *ProjectPostgres) DeleteUserFromProject(ctx context.Context, projectID, userID uint64) error {
 	query := fmt.Sprintf(`DELETE FROM %s WHERE project_id = $1 AND user_id = $2`, projectUserTable)
 
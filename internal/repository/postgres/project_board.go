@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/l-orlov/task-tracker/internal/models"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type ProjectBoardPostgres struct {
@@ -22,18 +24,32 @@ func NewProjectBoardPostgres(db *sqlx.DB, dbTimeout time.Duration) *ProjectBoard
 	}
 }
 
-func (r *ProjectBoardPostgres) GetProjectBoardBytes(ctx context.Context, projectID uint64) (jsonData []byte, err error) {
-	query := fmt.Sprintf(`SELECT * FROM %s($1)`, fnGetProjectBoard)
+// GetProjectBoardBytes returns project board with its version read from the same snapshot.
+// Board has only tasks of the sprint if sprint id is not nil.
+func (r *ProjectBoardPostgres) GetProjectBoardBytes(
+	ctx context.Context, projectID uint64, sprintID *uint64,
+) (jsonData []byte, version uint64, err error) {
+	query := fmt.Sprintf(`SELECT %s($1, $2), %s($1)`, fnGetProjectBoard, fnGetProjectBoardVersion)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err = r.db.QueryRowContext(dbCtx, query, &projectID).Scan(&jsonData)
+	tx, err := r.db.BeginTx(dbCtx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	var nullVersion sql.NullInt64
+	if err = tx.QueryRowContext(dbCtx, query, &projectID, sprintID).Scan(&jsonData, &nullVersion); err != nil {
+		return nil, 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, 0, err
 	}
 
-	return jsonData, nil
+	return jsonData, uint64(nullVersion.Int64), nil
 }
 
 func (r *ProjectBoardPostgres) GetProjectBoard(ctx context.Context, projectID uint64) (*models.ProjectBoard, error) {
@@ -52,44 +68,93 @@ func (r *ProjectBoardPostgres) GetProjectBoard(ctx context.Context, projectID ui
 	return &board, nil
 }
 
-func (r *ProjectBoardPostgres) UpdateProjectBoardParts(ctx context.Context, board models.ProjectBoard) error {
-	query := fmt.Sprintf(`SELECT * FROM %s($1)`, fnUpdateProjectBoardParts)
+// UpdateProjectBoardParts updates project board parts and returns new version of board.
+// Nil version is not checked.
+func (r *ProjectBoardPostgres) UpdateProjectBoardParts(
+	ctx context.Context, projectID uint64, board models.ProjectBoard, version *uint64, actorID uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s($1, $2, $3)`, fnUpdateProjectBoardParts)
+
+	return r.updateWithActor(ctx, actorID, query, &projectID, &board, version)
+}
+
+// UpdateProjectBoardProgressStatuses updates order of progress statuses and returns new version of board.
+// Nil version is not checked.
+func (r *ProjectBoardPostgres) UpdateProjectBoardProgressStatuses(
+	ctx context.Context, projectID uint64, statuses models.ProjectBoardProgressStatuses, version *uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s($1, $2, $3)`, fnUpdateProjectBoardProgressStatuses)
+	var newVersion uint64
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(dbCtx, query, &board)
-	if err != nil {
-		return err
+	if err := r.db.QueryRowContext(dbCtx, query, &projectID, &statuses, version).Scan(&newVersion); err != nil {
+		return 0, getDBError(err)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
-func (r *ProjectBoardPostgres) UpdateProjectBoardProgressStatuses(ctx context.Context, statuses models.ProjectBoardProgressStatuses) error {
-	query := fmt.Sprintf(`SELECT * FROM %s($1)`, fnUpdateProjectBoardProgressStatuses)
+// UpdateProjectBoardProgressStatusTasks updates order of tasks in progress status and returns new version of board.
+// Nil version is not checked.
+func (r *ProjectBoardPostgres) UpdateProjectBoardProgressStatusTasks(
+	ctx context.Context, projectID uint64, tasks models.ProjectBoardProgressStatusTasks, version *uint64, actorID uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s($1, $2, $3)`, fnUpdateProjectBoardProgressStatusTasks)
+
+	return r.updateWithActor(ctx, actorID, query, &projectID, &tasks, version)
+}
+
+func (r *ProjectBoardPostgres) GetProjectIDsByBoardEntities(
+	ctx context.Context, progressStatusIDs []int64, taskIDs []uint64,
+) ([]uint64, error) {
+	query := fmt.Sprintf(`
+SELECT project_id FROM %s WHERE id = ANY($1)
+UNION
+SELECT project_id FROM %s WHERE id = ANY($2)`, progressStatusTable, taskTable)
+	var projectIDs []uint64
+
+	// pq does not support arrays of unsigned integers
+	tasks := make([]int64, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		tasks = append(tasks, int64(id))
+	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(dbCtx, query, &statuses)
-	if err != nil {
-		return err
-	}
+	err := r.db.SelectContext(dbCtx, &projectIDs, query, pq.Array(progressStatusIDs), pq.Array(tasks))
 
-	return nil
+	return projectIDs, err
 }
 
-func (r *ProjectBoardPostgres) UpdateProjectBoardProgressStatusTasks(ctx context.Context, tasks models.ProjectBoardProgressStatusTasks) error {
-	query := fmt.Sprintf(`SELECT * FROM %s($1)`, fnUpdateProjectBoardProgressStatusTasks)
-
+// updateWithActor executes board update query in transaction with actor set for task activity logging
+// and returns new version of board.
+func (r *ProjectBoardPostgres) updateWithActor(
+	ctx context.Context, actorID uint64, query string, args ...interface{},
+) (uint64, error) {
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(dbCtx, query, &tasks)
+	tx, err := r.db.BeginTx(dbCtx, nil)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err = setActor(dbCtx, tx, actorID); err != nil {
+		return 0, err
+	}
+
+	var version uint64
+	if err = tx.QueryRowContext(dbCtx, query, args...).Scan(&version); err != nil {
+		return 0, getDBError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return version, nil
 }
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+type ReportPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewReportPostgres(db *sqlx.DB, dbTimeout time.Duration) *ReportPostgres {
+	return &ReportPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// GetCumulativeFlow returns numbers of project tasks in progress statuses at the end of each day of the period.
+// Days without tasks in the status are omitted.
+func (r *ReportPostgres) GetCumulativeFlow(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) ([]models.CumulativeFlowCount, error) {
+	query := fmt.Sprintf(`
+SELECT d.day::DATE AS day, s.to_status_id AS progress_status_id, COUNT(*) AS count
+FROM generate_series($2::DATE::TIMESTAMP, $3::DATE::TIMESTAMP, INTERVAL '1 day') AS d(day)
+INNER JOIN LATERAL (
+SELECT DISTINCT ON (h.task_id) h.task_id, h.to_status_id FROM %s AS h
+WHERE h.project_id = $1 AND h.changed_at < d.day + INTERVAL '1 day'
+ORDER BY h.task_id, h.changed_at DESC, h.id DESC
+) AS s ON TRUE
+GROUP BY d.day, s.to_status_id ORDER BY d.day ASC`, taskStatusChangeTable)
+	var counts []models.CumulativeFlowCount
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &counts, query, &projectID, period.From, period.To); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetLeadCycleTime returns lead and cycle time percentiles of project tasks completed in the period.
+func (r *ReportPostgres) GetLeadCycleTime(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) (*models.LeadCycleTimeReport, error) {
+	query := fmt.Sprintf(`
+WITH completed AS (
+SELECT EXTRACT(EPOCH FROM t.completed_at - t.created_at) / 3600 AS lead_hours,
+EXTRACT(EPOCH FROM t.completed_at - (
+SELECT MIN(h.changed_at) FROM %s AS h
+INNER JOIN %s AS ps ON ps.id = h.to_status_id
+WHERE h.task_id = t.id AND ps.category <> $4
+)) / 3600 AS cycle_hours
+FROM %s AS t
+WHERE t.project_id = $1 AND t.completed_at >= $2::DATE AND t.completed_at < $3::DATE + 1
+)
+SELECT COUNT(lead_hours) AS lead_count,
+percentile_cont(0.5) WITHIN GROUP (ORDER BY lead_hours) AS lead_p50,
+percentile_cont(0.75) WITHIN GROUP (ORDER BY lead_hours) AS lead_p75,
+percentile_cont(0.85) WITHIN GROUP (ORDER BY lead_hours) AS lead_p85,
+percentile_cont(0.95) WITHIN GROUP (ORDER BY lead_hours) AS lead_p95,
+COUNT(cycle_hours) AS cycle_count,
+percentile_cont(0.5) WITHIN GROUP (ORDER BY cycle_hours) AS cycle_p50,
+percentile_cont(0.75) WITHIN GROUP (ORDER BY cycle_hours) AS cycle_p75,
+percentile_cont(0.85) WITHIN GROUP (ORDER BY cycle_hours) AS cycle_p85,
+percentile_cont(0.95) WITHIN GROUP (ORDER BY cycle_hours) AS cycle_p95
+FROM completed`, taskStatusChangeTable, progressStatusTable, taskTable)
+	var row struct {
+		LeadCount  int64    `db:"lead_count"`
+		LeadP50    *float64 `db:"lead_p50"`
+		LeadP75    *float64 `db:"lead_p75"`
+		LeadP85    *float64 `db:"lead_p85"`
+		LeadP95    *float64 `db:"lead_p95"`
+		CycleCount int64    `db:"cycle_count"`
+		CycleP50   *float64 `db:"cycle_p50"`
+		CycleP75   *float64 `db:"cycle_p75"`
+		CycleP85   *float64 `db:"cycle_p85"`
+		CycleP95   *float64 `db:"cycle_p95"`
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(
+		dbCtx, &row, query, &projectID, period.From, period.To, models.ProgressStatusCategoryTodo,
+	); err != nil {
+		return nil, err
+	}
+
+	return &models.LeadCycleTimeReport{
+		ReportPeriod: period,
+		LeadTime: models.DurationPercentiles{
+			Count: row.LeadCount,
+			P50:   row.LeadP50,
+			P75:   row.LeadP75,
+			P85:   row.LeadP85,
+			P95:   row.LeadP95,
+		},
+		CycleTime: models.DurationPercentiles{
+			Count: row.CycleCount,
+			P50:   row.CycleP50,
+			P75:   row.CycleP75,
+			P85:   row.CycleP85,
+			P95:   row.CycleP95,
+		},
+	}, nil
+}
+
+// GetThroughput returns numbers of project tasks completed in the period by weeks.
+// The first and the last weeks are counted only within the period.
+func (r *ReportPostgres) GetThroughput(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) ([]models.ThroughputWeek, error) {
+	query := fmt.Sprintf(`
+SELECT w.week_start::DATE AS week_start, COUNT(t.id) AS completed
+FROM generate_series(
+date_trunc('week', $2::DATE::TIMESTAMP), $3::DATE::TIMESTAMP, INTERVAL '1 week'
+) AS w(week_start)
+LEFT JOIN %s AS t ON t.project_id = $1
+AND t.completed_at >= GREATEST(w.week_start, $2::DATE::TIMESTAMP)
+AND t.completed_at < LEAST(w.week_start + INTERVAL '1 week', $3::DATE + 1)
+GROUP BY w.week_start ORDER BY w.week_start ASC`, taskTable)
+	var weeks []models.ThroughputWeek
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &weeks, query, &projectID, period.From, period.To); err != nil {
+		return nil, err
+	}
+
+	return weeks, nil
+}
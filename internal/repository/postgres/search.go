@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+const (
+	searchConfig = "task_tracker_search"
+	// searchHeadlineOptions are options of ts_headline to highlight matched words in snippet
+	searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+type SearchPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewSearchPostgres(db *sqlx.DB, dbTimeout time.Duration) *SearchPostgres {
+	return &SearchPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// SearchTasks returns tasks matching the query in the projects where user is a member sorted by relevance.
+func (r *SearchPostgres) SearchTasks(
+	ctx context.Context, userID uint64, params models.SearchParams,
+) ([]models.TaskSearchResult, error) {
+	query := fmt.Sprintf(`
+WITH q AS (SELECT websearch_to_tsquery('%[1]s', $1) AS query)
+SELECT t.id, t.project_id, t.title,
+ts_headline('%[1]s', html_escape(t.title || E'\n' || t.description), q.query, '%[2]s') AS snippet,
+ts_rank_cd(t.search_vector, q.query) AS rank
+FROM %[3]s AS t, q
+WHERE t.search_vector @@ q.query AND (t.project_id = $2 OR $2 is null) AND
+t.project_id IN (SELECT project_id FROM %[4]s WHERE user_id = $3)
+ORDER BY rank DESC, t.id DESC LIMIT $4`, searchConfig, searchHeadlineOptions, taskTable, projectUserTable)
+	var results []models.TaskSearchResult
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &results, query, &params.Query, params.ProjectID, &userID, &params.Limit)
+
+	return results, err
+}
+
+// SearchComments returns task comments matching the query in the projects where user is a member
+// sorted by relevance.
+func (r *SearchPostgres) SearchComments(
+	ctx context.Context, userID uint64, params models.SearchParams,
+) ([]models.CommentSearchResult, error) {
+	query := fmt.Sprintf(`
+WITH q AS (SELECT websearch_to_tsquery('%[1]s', $1) AS query)
+SELECT c.id, c.task_id, t.project_id,
+ts_headline('%[1]s', html_escape(c.text), q.query, '%[2]s') AS snippet,
+ts_rank_cd(c.search_vector, q.query) AS rank
+FROM %[3]s AS c INNER JOIN %[4]s AS t ON t.id = c.task_id, q
+WHERE c.search_vector @@ q.query AND (t.project_id = $2 OR $2 is null) AND
+t.project_id IN (SELECT project_id FROM %[5]s WHERE user_id = $3)
+ORDER BY rank DESC, c.id DESC LIMIT $4`,
+		searchConfig, searchHeadlineOptions, commentTable, taskTable, projectUserTable)
+	var results []models.CommentSearchResult
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &results, query, &params.Query, params.ProjectID, &userID, &params.Limit)
+
+	return results, err
+}
+
+// SearchProjects returns projects matching the query where user is a member sorted by relevance.
+func (r *SearchPostgres) SearchProjects(
+	ctx context.Context, userID uint64, params models.SearchParams,
+) ([]models.ProjectSearchResult, error) {
+	query := fmt.Sprintf(`
+WITH q AS (SELECT websearch_to_tsquery('%[1]s', $1) AS query)
+SELECT p.id, p.name,
+ts_headline('%[1]s', html_escape(p.name || E'\n' || p.description), q.query, '%[2]s') AS snippet,
+ts_rank_cd(p.search_vector, q.query) AS rank
+FROM %[3]s AS p, q
+WHERE p.search_vector @@ q.query AND (p.id = $2 OR $2 is null) AND
+p.id IN (SELECT project_id FROM %[4]s WHERE user_id = $3)
+ORDER BY rank DESC, p.id DESC LIMIT $4`, searchConfig, searchHeadlineOptions, projectTable, projectUserTable)
+	var results []models.ProjectSearchResult
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &results, query, &params.Query, params.ProjectID, &userID, &params.Limit)
+
+	return results, err
+}
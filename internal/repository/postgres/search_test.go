@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestSearchPostgres_OnlyProjectsOfMember(t *testing.T) {
+	db := newTestDB(t)
+	r := NewSearchPostgres(db, testDBTimeout)
+
+	const text = `<script>alert("xylophonequux")</script> & xylophonequux`
+
+	// project of the user and project where the user is not a member have the same text
+	p := newTestProject(t, db)
+	otherProject := newTestProject(t, db)
+	taskID := p.createTask(t, nil)
+	otherProject.createTask(t, nil)
+
+	if _, err := db.Exec(`INSERT INTO `+projectUserTable+` (project_id, user_id, role) VALUES ($1, $2, $3)`,
+		p.ID, p.UserID, models.ProjectRoleMember); err != nil {
+		t.Fatalf("failed to add project user: %v", err)
+	}
+
+	for _, projectID := range []uint64{p.ID, otherProject.ID} {
+		if _, err := db.Exec(`UPDATE `+projectTable+` SET description = $2 WHERE id = $1`, projectID, text); err != nil {
+			t.Fatalf("failed to update project: %v", err)
+		}
+
+		if _, err := db.Exec(`UPDATE `+taskTable+` SET description = $2 WHERE project_id = $1`, projectID, text); err != nil {
+			t.Fatalf("failed to update tasks: %v", err)
+		}
+	}
+
+	params := models.SearchParams{Query: "xylophonequux", Limit: 10}
+
+	// checkSnippet checks that text is escaped and only matched words are highlighted
+	checkSnippet := func(t *testing.T, snippet string) {
+		t.Helper()
+
+		if strings.Contains(snippet, "<script>") || !strings.Contains(snippet, "&lt;script&gt;") ||
+			!strings.Contains(snippet, "&amp;") {
+			t.Errorf("snippet %q is not escaped", snippet)
+		}
+
+		if !strings.Contains(snippet, "<mark>xylophonequux</mark>") {
+			t.Errorf("snippet %q does not highlight matched word", snippet)
+		}
+	}
+
+	t.Run("projects", func(t *testing.T) {
+		results, err := r.SearchProjects(context.Background(), p.UserID, params)
+		if err != nil {
+			t.Fatalf("SearchProjects() error = %v", err)
+		}
+
+		if len(results) != 1 || results[0].ID != p.ID {
+			t.Fatalf("results = %+v, want only project %d", results, p.ID)
+		}
+
+		checkSnippet(t, results[0].Snippet)
+	})
+
+	t.Run("tasks", func(t *testing.T) {
+		results, err := r.SearchTasks(context.Background(), p.UserID, params)
+		if err != nil {
+			t.Fatalf("SearchTasks() error = %v", err)
+		}
+
+		if len(results) != 1 || results[0].ID != taskID {
+			t.Fatalf("results = %+v, want only task %d", results, taskID)
+		}
+
+		checkSnippet(t, results[0].Snippet)
+	})
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+const sprintColumns = `id, project_id, name, goal, start_date, end_date, status, started_at, closed_at,
+created_at, updated_at`
+
+type SprintPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewSprintPostgres(db *sqlx.DB, dbTimeout time.Duration) *SprintPostgres {
+	return &SprintPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *SprintPostgres) CreateSprint(ctx context.Context, sprint models.SprintToCreate) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (project_id, name, goal, start_date, end_date) values ($1, $2, $3, $4, $5) RETURNING id`,
+		sprintTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query,
+		&sprint.ProjectID, &sprint.Name, &sprint.Goal, &sprint.StartDate, &sprint.EndDate)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *SprintPostgres) GetSprintByID(ctx context.Context, id uint64) (*models.Sprint, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, sprintColumns, sprintTable)
+	var sprint models.Sprint
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &sprint, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &sprint, nil
+}
+
+func (r *SprintPostgres) UpdateSprint(ctx context.Context, sprint models.SprintToUpdate) error {
+	query := fmt.Sprintf(`
+UPDATE %s SET name = $1, goal = $2, start_date = $3, end_date = $4 WHERE id = $5`, sprintTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query,
+		&sprint.Name, &sprint.Goal, &sprint.StartDate, &sprint.EndDate, &sprint.ID,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// GetAllProjectSprints returns sprints of the project sorted by start date.
+func (r *SprintPostgres) GetAllProjectSprints(ctx context.Context, projectID uint64) ([]models.Sprint, error) {
+	query := fmt.Sprintf(`
+SELECT %s FROM %s WHERE project_id = $1 ORDER BY start_date ASC, id ASC`, sprintColumns, sprintTable)
+	var sprints []models.Sprint
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &sprints, query, &projectID)
+
+	return sprints, err
+}
+
+// DeleteSprint deletes sprint, its tasks are moved to the backlog.
+func (r *SprintPostgres) DeleteSprint(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, sprintTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StartSprint makes planned sprint active. False is returned if sprint is not planned.
+func (r *SprintPostgres) StartSprint(ctx context.Context, id uint64) (bool, error) {
+	query := fmt.Sprintf(`
+UPDATE %s SET status = $1, started_at = NOW() WHERE id = $2 AND status = $3`, sprintTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(dbCtx, query, models.SprintStatusActive, &id, models.SprintStatusPlanned)
+	if err != nil {
+		return false, getDBError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected != 0, nil
+}
+
+// CloseSprint closes active sprint and moves its unfinished tasks out of the sprint.
+// Nil result is returned if sprint is not active.
+func (r *SprintPostgres) CloseSprint(
+	ctx context.Context, id uint64, moveTo models.SprintMoveTo,
+) (*models.SprintCloseResult, error) {
+	closeQuery := fmt.Sprintf(`
+UPDATE %s SET status = $1, closed_at = NOW() WHERE id = $2 AND status = $3 RETURNING project_id`, sprintTable)
+	nextSprintQuery := fmt.Sprintf(`
+SELECT id FROM %s WHERE project_id = $1 AND status = $2 ORDER BY start_date ASC, id ASC LIMIT 1`, sprintTable)
+	moveTasksQuery := fmt.Sprintf(`
+UPDATE %s SET sprint_id = $1 WHERE sprint_id = $2 AND %s RETURNING id`, taskTable, notDoneTaskCondition)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var projectID uint64
+	if err = tx.QueryRowContext(dbCtx, closeQuery,
+		models.SprintStatusClosed, &id, models.SprintStatusActive,
+	).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	result := &models.SprintCloseResult{}
+
+	if moveTo == models.SprintMoveToNext {
+		var nextSprintID uint64
+		err = tx.QueryRowContext(dbCtx, nextSprintQuery, &projectID, models.SprintStatusPlanned).Scan(&nextSprintID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		if err == nil {
+			result.NextSprintID = &nextSprintID
+		}
+	}
+
+	if err = tx.SelectContext(dbCtx, &result.MovedTaskIDs, moveTasksQuery, result.NextSprintID, &id); err != nil {
+		return nil, getDBError(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *SprintPostgres) GetAllSprintTasks(ctx context.Context, sprintID uint64) ([]models.Task, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE sprint_id = $1 ORDER BY id ASC`, taskColumns, taskTable)
+	var tasks []models.Task
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &tasks, query, &sprintID)
+
+	return tasks, err
+}
+
+// GetSprintBurndown returns numbers of sprint tasks at the end of each day from sprint start
+// to sprint end, today or sprint closing whichever comes first.
+// Tasks moved out of the sprint on closing are counted as sprint tasks on the closing day.
+func (r *SprintPostgres) GetSprintBurndown(ctx context.Context, sprintID uint64) ([]models.SprintBurndownCount, error) {
+	query := fmt.Sprintf(`
+SELECT d.day::DATE AS day, COUNT(m.task_id) AS total,
+COUNT(m.task_id) FILTER (WHERE t.completed_at IS NULL OR t.completed_at >= m.cutoff) AS remaining
+FROM %[1]s AS s
+INNER JOIN LATERAL generate_series(
+s.start_date::TIMESTAMP, LEAST(s.end_date, CURRENT_DATE, s.closed_at::DATE)::TIMESTAMP, INTERVAL '1 day'
+) AS d(day) ON TRUE
+LEFT JOIN LATERAL (
+SELECT latest.task_id, LEAST(d.day + INTERVAL '1 day', s.closed_at) AS cutoff FROM (
+SELECT DISTINCT ON (c.task_id) c.task_id, c.sprint_id FROM %[2]s AS c
+WHERE c.task_id IN (SELECT task_id FROM %[2]s WHERE sprint_id = s.id)
+AND c.changed_at < LEAST(d.day + INTERVAL '1 day', s.closed_at)
+ORDER BY c.task_id, c.changed_at DESC, c.id DESC
+) AS latest WHERE latest.sprint_id = s.id
+) AS m ON TRUE
+LEFT JOIN %[3]s AS t ON t.id = m.task_id
+WHERE s.id = $1
+GROUP BY d.day ORDER BY d.day ASC`, sprintTable, taskSprintChangeTable, taskTable)
+	var counts []models.SprintBurndownCount
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &counts, query, &sprintID); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
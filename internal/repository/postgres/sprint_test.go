@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+// createSprint creates sprint of the project from start date to end date.
+func (p testProject) createSprint(t *testing.T, startDate, endDate time.Time) uint64 {
+	t.Helper()
+
+	id, err := NewSprintPostgres(p.db, testDBTimeout).CreateSprint(context.Background(), models.SprintToCreate{
+		ProjectID: p.ID,
+		Name:      "test",
+		StartDate: models.NewDate(startDate),
+		EndDate:   models.NewDate(endDate),
+	})
+	if err != nil {
+		t.Fatalf("failed to create sprint: %v", err)
+	}
+
+	return id
+}
+
+// createSprintTasks creates tasks of the sprint. The first task is done.
+func (p testProject) createSprintTasks(t *testing.T, sprintID uint64, num int) []uint64 {
+	t.Helper()
+
+	ids := make([]uint64, 0, num)
+	for i := 0; i < num; i++ {
+		id := p.createTask(t, nil)
+		if _, err := p.db.Exec(`UPDATE `+taskTable+` SET sprint_id = $2 WHERE id = $1`, id, sprintID); err != nil {
+			t.Fatalf("failed to add task to sprint: %v", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if _, err := p.db.Exec(`UPDATE `+taskTable+` SET progress_status_id = $2 WHERE id = $1`,
+		ids[0], p.doneProgressStatusID(t)); err != nil {
+		t.Fatalf("failed to complete task: %v", err)
+	}
+
+	return ids
+}
+
+func TestSprintPostgres_CloseSprint(t *testing.T) {
+	db := newTestDB(t)
+	r := NewSprintPostgres(db, testDBTimeout)
+	today := time.Now()
+
+	tests := []struct {
+		name          string
+		moveTo        models.SprintMoveTo
+		hasNextSprint bool
+	}{
+		{
+			name:          "tasks are moved to next sprint",
+			moveTo:        models.SprintMoveToNext,
+			hasNextSprint: true,
+		},
+		{
+			name:   "tasks are moved to backlog without next sprint",
+			moveTo: models.SprintMoveToNext,
+		},
+		{
+			name:          "tasks are moved to backlog",
+			moveTo:        models.SprintMoveToBacklog,
+			hasNextSprint: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProject(t, db)
+			sprintID := p.createSprint(t, today, today.AddDate(0, 0, 7))
+			taskIDs := p.createSprintTasks(t, sprintID, 3)
+
+			if _, err := r.StartSprint(context.Background(), sprintID); err != nil {
+				t.Fatalf("StartSprint() error = %v", err)
+			}
+
+			var nextSprintID *uint64
+			if tt.hasNextSprint {
+				// the nearest planned sprint is the next one
+				p.createSprint(t, today.AddDate(0, 0, 16), today.AddDate(0, 0, 23))
+				id := p.createSprint(t, today.AddDate(0, 0, 8), today.AddDate(0, 0, 15))
+
+				if tt.moveTo == models.SprintMoveToNext {
+					nextSprintID = &id
+				}
+			}
+
+			result, err := r.CloseSprint(context.Background(), sprintID, tt.moveTo)
+			if err != nil {
+				t.Fatalf("CloseSprint() error = %v", err)
+			}
+
+			// done task stays in closed sprint
+			wantMovedIDs := taskIDs[1:]
+			sort.Slice(result.MovedTaskIDs, func(i, j int) bool { return result.MovedTaskIDs[i] < result.MovedTaskIDs[j] })
+			if !reflect.DeepEqual(result.MovedTaskIDs, wantMovedIDs) {
+				t.Errorf("moved tasks = %v, want %v", result.MovedTaskIDs, wantMovedIDs)
+			}
+
+			if !reflect.DeepEqual(result.NextSprintID, nextSprintID) {
+				t.Errorf("next sprint = %v, want %v", result.NextSprintID, nextSprintID)
+			}
+
+			for i, taskID := range taskIDs {
+				var taskSprintID *uint64
+				if err = db.Get(&taskSprintID, `SELECT sprint_id FROM `+taskTable+` WHERE id = $1`, taskID); err != nil {
+					t.Fatalf("failed to get task sprint: %v", err)
+				}
+
+				wantSprintID := nextSprintID
+				if i == 0 {
+					wantSprintID = &sprintID
+				}
+
+				if !reflect.DeepEqual(taskSprintID, wantSprintID) {
+					t.Errorf("sprint of task %d = %v, want %v", taskID, taskSprintID, wantSprintID)
+				}
+			}
+
+			if result, err = r.CloseSprint(context.Background(), sprintID, tt.moveTo); err != nil || result != nil {
+				t.Errorf("CloseSprint() of closed sprint = %+v, %v, want nil result", result, err)
+			}
+		})
+	}
+}
+
+func TestSprintPostgres_GetSprintBurndown(t *testing.T) {
+	db := newTestDB(t)
+	r := NewSprintPostgres(db, testDBTimeout)
+	p := newTestProject(t, db)
+
+	// dates are taken from database, so days of burndown are in its time zone
+	var today time.Time
+	if err := db.Get(&today, `SELECT CURRENT_DATE::TIMESTAMP`); err != nil {
+		t.Fatalf("failed to get current date: %v", err)
+	}
+
+	// sprint started 3 days ago with 3 tasks and will end in 2 days, 4th task is added yesterday
+	startDate := today.AddDate(0, 0, -3)
+	sprintID := p.createSprint(t, startDate, today.AddDate(0, 0, 2))
+	taskIDs := p.createSprintTasks(t, sprintID, 4)
+
+	if _, err := db.Exec(`
+UPDATE `+taskSprintChangeTable+` SET changed_at = CURRENT_DATE - CASE WHEN task_id = $2 THEN 1 ELSE 3 END
+WHERE sprint_id = $1`, sprintID, taskIDs[3]); err != nil {
+		t.Fatalf("failed to set sprint changes time: %v", err)
+	}
+
+	// the first task is done 2 days ago
+	if _, err := db.Exec(`
+UPDATE `+taskTable+` SET completed_at = CURRENT_DATE - 2 + INTERVAL '12 hours' WHERE id = $1`,
+		taskIDs[0]); err != nil {
+		t.Fatalf("failed to set completion time: %v", err)
+	}
+
+	counts, err := r.GetSprintBurndown(context.Background(), sprintID)
+	if err != nil {
+		t.Fatalf("GetSprintBurndown() error = %v", err)
+	}
+
+	// days which have not come yet are not counted
+	want := []struct {
+		daysAgo   int
+		total     int64
+		remaining int64
+	}{
+		{daysAgo: 3, total: 3, remaining: 3},
+		{daysAgo: 2, total: 3, remaining: 2},
+		{daysAgo: 1, total: 4, remaining: 3},
+		{daysAgo: 0, total: 4, remaining: 3},
+	}
+
+	if len(counts) != len(want) {
+		t.Fatalf("counts = %+v, want %d days", counts, len(want))
+	}
+
+	for i, count := range counts {
+		wantDate := models.NewDate(today.AddDate(0, 0, -want[i].daysAgo))
+		if count.Date.String() != wantDate.String() || count.Total != want[i].total ||
+			count.Remaining != want[i].remaining {
+			t.Errorf("day %d = %+v, want %s total %d remaining %d",
+				i, count, wantDate, want[i].total, want[i].remaining)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const statusTransitionColumns = `id, project_id, from_status_id, to_status_id, roles`
+
+type (
+	StatusTransitionPostgres struct {
+		db        *sqlx.DB
+		dbTimeout time.Duration
+	}
+	// statusTransitionRow is status transition scanned from db with roles array.
+	statusTransitionRow struct {
+		ID           int64          `db:"id"`
+		ProjectID    uint64         `db:"project_id"`
+		FromStatusID int64          `db:"from_status_id"`
+		ToStatusID   int64          `db:"to_status_id"`
+		Roles        pq.StringArray `db:"roles"`
+	}
+)
+
+func NewStatusTransitionPostgres(db *sqlx.DB, dbTimeout time.Duration) *StatusTransitionPostgres {
+	return &StatusTransitionPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *StatusTransitionPostgres) Create(ctx context.Context, transition models.StatusTransitionToCreate) (int64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (project_id, from_status_id, to_status_id, roles) values ($1, $2, $3, $4) RETURNING id`,
+		statusTransitionTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &transition.ProjectID, &transition.FromStatusID,
+		&transition.ToStatusID, rolesArray(transition.Roles))
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *StatusTransitionPostgres) GetByID(ctx context.Context, id int64) (*models.StatusTransition, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, statusTransitionColumns, statusTransitionTable)
+	var row statusTransitionRow
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &row, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	transition := row.toModel()
+
+	return &transition, nil
+}
+
+func (r *StatusTransitionPostgres) Update(ctx context.Context, transition models.StatusTransitionToUpdate) error {
+	query := fmt.Sprintf(`UPDATE %s SET roles = $1 WHERE id = $2`, statusTransitionTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, rolesArray(transition.Roles), &transition.ID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *StatusTransitionPostgres) GetAllToProject(
+	ctx context.Context, projectID uint64,
+) ([]models.StatusTransition, error) {
+	query := fmt.Sprintf(`
+SELECT %s FROM %s WHERE project_id = $1 ORDER BY from_status_id ASC, to_status_id ASC`,
+		statusTransitionColumns, statusTransitionTable)
+	var rows []statusTransitionRow
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &rows, query, &projectID); err != nil {
+		return nil, err
+	}
+
+	transitions := make([]models.StatusTransition, 0, len(rows))
+	for _, row := range rows {
+		transitions = append(transitions, row.toModel())
+	}
+
+	return transitions, nil
+}
+
+func (r *StatusTransitionPostgres) Delete(ctx context.Context, id int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, statusTransitionTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (row statusTransitionRow) toModel() models.StatusTransition {
+	roles := make([]models.ProjectRole, 0, len(row.Roles))
+	for _, role := range row.Roles {
+		roles = append(roles, models.ProjectRole(role))
+	}
+
+	return models.StatusTransition{
+		ID:           row.ID,
+		ProjectID:    row.ProjectID,
+		FromStatusID: row.FromStatusID,
+		ToStatusID:   row.ToStatusID,
+		Roles:        roles,
+	}
+}
+
+func rolesArray(roles []models.ProjectRole) pq.StringArray {
+	array := make(pq.StringArray, 0, len(roles))
+	for _, role := range roles {
+		array = append(array, string(role))
+	}
+
+	return array
+}
@@ -8,9 +8,25 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/taskfilter"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
+// notDoneTaskCondition is true for task which is not in done category progress status.
+var notDoneTaskCondition = notDoneTaskConditionOf(taskTable)
+
+const taskColumns = `id, project_id, title, description, assignee_id, importance_status_id, progress_status_id,
+start_date, due_date, parent_id, sprint_id, original_estimate, remaining_estimate, completed_at, version,
+created_at, updated_at`
+
+// subtasksQuery selects ids of the task with id $1 and its subtasks of all levels.
+const subtasksQuery = `WITH RECURSIVE subtasks AS (
+SELECT id FROM ` + taskTable + ` WHERE id = $1
+UNION
+SELECT t.id FROM ` + taskTable + ` AS t INNER JOIN subtasks AS s ON t.parent_id = s.id)
+SELECT id FROM subtasks`
+
 type TaskPostgres struct {
 	db        *sqlx.DB
 	dbTimeout time.Duration
@@ -25,14 +41,16 @@ func NewTaskPostgres(db *sqlx.DB, dbTimeout time.Duration) *TaskPostgres {
 
 func (r *TaskPostgres) CreateTaskToProject(ctx context.Context, task models.TaskToCreate) (uint64, error) {
 	query := fmt.Sprintf(`
-INSERT INTO %s (project_id, title, description, assignee_id, importance_status_id, progress_status_id)
-values ($1, $2, $3, $4, $5, $6) RETURNING id`, taskTable)
+INSERT INTO %s (project_id, title, description, assignee_id, importance_status_id, progress_status_id,
+start_date, due_date, parent_id, sprint_id, original_estimate, remaining_estimate)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`, taskTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	row := r.db.QueryRowContext(dbCtx, query, &task.ProjectID, &task.Title, &task.Description,
-		&task.AssigneeID, &task.ImportanceStatusID, &task.ProgressStatusID)
+		&task.AssigneeID, &task.ImportanceStatusID, &task.ProgressStatusID, &task.StartDate, &task.DueDate,
+		&task.ParentID, &task.SprintID, &task.OriginalEstimate, &task.RemainingEstimate)
 	if err := row.Err(); err != nil {
 		return 0, getDBError(err)
 	}
@@ -46,9 +64,7 @@ values ($1, $2, $3, $4, $5, $6) RETURNING id`, taskTable)
 }
 
 func (r *TaskPostgres) GetTaskByID(ctx context.Context, id uint64) (*models.Task, error) {
-	query := fmt.Sprintf(`
-SELECT id, project_id, title, description, assignee_id, importance_status_id, progress_status_id
-FROM %s WHERE id = $1`, taskTable)
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, taskColumns, taskTable)
 	var task models.Task
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -65,44 +81,71 @@ FROM %s WHERE id = $1`, taskTable)
 	return &task, nil
 }
 
-func (r *TaskPostgres) UpdateTask(ctx context.Context, task models.Task) error {
+// UpdateTask updates task if its version was not changed and returns new version of task.
+// Zero version is returned if task with such version was not found.
+func (r *TaskPostgres) UpdateTask(ctx context.Context, task models.Task, actorID uint64) (uint64, error) {
 	query := fmt.Sprintf(`
-UPDATE %s SET title = :title, description = :description, assignee_id = :assignee_id,
-importance_status_id = :importance_status_id, progress_status_id = :progress_status_id WHERE id = :id`, taskTable)
+UPDATE %s SET title = $1, description = $2, assignee_id = $3,
+importance_status_id = $4, progress_status_id = $5, start_date = $6, due_date = $7, parent_id = $8,
+sprint_id = $9, original_estimate = $10, remaining_estimate = $11
+WHERE id = $12 AND version = $13 RETURNING version`, taskTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.NamedExecContext(dbCtx, query, &task)
+	tx, err := r.db.BeginTx(dbCtx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
+	defer tx.Rollback()
 
-	return nil
-}
+	if err = setActor(dbCtx, tx, actorID); err != nil {
+		return 0, err
+	}
 
-func (r *TaskPostgres) GetAllTasksToProject(ctx context.Context, projectID uint64) ([]models.Task, error) {
-	query := fmt.Sprintf(`
-SELECT id, project_id, title, description, assignee_id, importance_status_id, progress_status_id
-FROM %s WHERE project_id=$1 ORDER BY id ASC`, taskTable)
-	var tasks []models.Task
+	// board is locked before task like in board update functions, otherwise they deadlock with each other
+	if _, err = tx.ExecContext(dbCtx, fmt.Sprintf(`
+SELECT %s(project_id, NULL) FROM %s WHERE id = $1`, fnLockProjectBoard, taskTable), &task.ID); err != nil {
+		return 0, getDBError(err)
+	}
 
-	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
-	defer cancel()
+	var version uint64
+	if err = tx.QueryRowContext(dbCtx, query, &task.Title, &task.Description, &task.AssigneeID,
+		&task.ImportanceStatusID, &task.ProgressStatusID, &task.StartDate, &task.DueDate, &task.ParentID,
+		&task.SprintID, &task.OriginalEstimate, &task.RemainingEstimate, &task.ID, &task.Version,
+	).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
 
-	err := r.db.SelectContext(dbCtx, &tasks, query, &projectID)
+		return 0, getDBError(err)
+	}
 
-	return tasks, err
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return version, nil
 }
 
-func (r *TaskPostgres) GetAllTasksWithParameters(ctx context.Context, params models.TaskParams) ([]models.Task, error) {
-	query := fmt.Sprintf(`
-SELECT id, project_id, title, description, assignee_id, importance_status_id, progress_status_id
-FROM %s
-WHERE (id = $1 OR $1 is null) AND (project_id = $2 OR $2 is null) AND (title ILIKE $3 OR $3 is null) AND
+func (r *TaskPostgres) GetAllTasksToProject(
+	ctx context.Context, projectID uint64, page models.PageRequest,
+) ([]models.Task, int64, error) {
+	return r.getTasksPage(ctx, "project_id = $1", []interface{}{projectID}, page)
+}
+
+func (r *TaskPostgres) GetAllTasksWithParameters(
+	ctx context.Context, params models.TaskParams, page models.PageRequest,
+) ([]models.Task, int64, error) {
+	condition := fmt.Sprintf(`
+(id = $1 OR $1 is null) AND (project_id = $2 OR $2 is null) AND (title ILIKE $3 OR $3 is null) AND
 (description ILIKE $4 OR $4 is null) AND (assignee_id = $5 OR $5 is null) AND 
-(importance_status_id = $6 OR $6 is null) AND (progress_status_id = $7 OR $7 is null)
-ORDER BY id ASC`, taskTable)
+(importance_status_id = $6 OR $6 is null) AND (progress_status_id = $7 OR $7 is null) AND
+(due_date < $8::DATE OR $8::DATE is null) AND (due_date > $9::DATE OR $9::DATE is null) AND
+($10 = FALSE OR (due_date < CURRENT_DATE AND %s)) AND
+($11 = FALSE OR due_date BETWEEN date_trunc('week', CURRENT_DATE)::DATE AND date_trunc('week', CURRENT_DATE)::DATE + 6) AND
+(id IN (SELECT task_id FROM %s WHERE label_id = $12) OR $12 is null)`,
+		notDoneTaskCondition, taskLabelTable)
 
 	if params.Title != nil {
 		*params.Title = "%%" + *params.Title + "%%"
@@ -112,40 +155,136 @@ ORDER BY id ASC`, taskTable)
 		*params.Description = "%%" + *params.Description + "%%"
 	}
 
+	return r.getTasksPage(ctx, condition, []interface{}{
+		params.ID, params.ProjectID, params.Title, params.Description, params.AssigneeID,
+		params.ImportanceStatusID, params.ProgressStatusID, params.DueBefore, params.DueAfter,
+		params.Overdue, params.DueThisWeek, params.LabelID,
+	}, page)
+}
+
+// GetOverdueTasksToUser returns not done tasks with due date in the past assigned to the user
+// in the projects where user is a member.
+func (r *TaskPostgres) GetOverdueTasksToUser(ctx context.Context, userID uint64) ([]models.Task, error) {
+	query := fmt.Sprintf(`
+SELECT %s FROM %s
+WHERE assignee_id = $1 AND due_date < CURRENT_DATE AND %s AND
+project_id IN (SELECT project_id FROM %s WHERE user_id = $1)
+ORDER BY due_date ASC, id ASC`, taskColumns, taskTable, notDoneTaskCondition, projectUserTable)
 	var tasks []models.Task
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err := r.db.SelectContext(dbCtx, &tasks, query, &params.ID, &params.ProjectID, &params.Title,
-		&params.Description, &params.AssigneeID, &params.ImportanceStatusID, &params.ProgressStatusID)
+	err := r.db.SelectContext(dbCtx, &tasks, query, &userID)
 
 	return tasks, err
 }
 
-func (r *TaskPostgres) GetAllTasks(ctx context.Context) ([]models.Task, error) {
-	query := fmt.Sprintf(`
-SELECT id, project_id, title, description, assignee_id, importance_status_id, progress_status_id
-FROM %s ORDER BY id ASC`, taskTable)
+// GetTasksByFilter returns page of the project tasks matching the filter. User is used to resolve "me" values.
+func (r *TaskPostgres) GetTasksByFilter(
+	ctx context.Context, projectID, userID uint64, filter *taskfilter.Filter, page models.PageRequest,
+) ([]models.Task, int64, error) {
+	condition, args, err := compileTaskFilter(filter, userID, []interface{}{projectID})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if condition == "" {
+		condition = "project_id = $1"
+	} else {
+		condition = "project_id = $1 AND " + condition
+	}
+
+	return r.getTasksPage(ctx, condition, args, page)
+}
+
+func (r *TaskPostgres) GetAllTasks(ctx context.Context, page models.PageRequest) ([]models.Task, int64, error) {
+	return r.getTasksPage(ctx, "", nil, page)
+}
+
+// GetTasksProgressStatusIDs returns progress status ids of the tasks by task ids. Not found tasks are skipped.
+func (r *TaskPostgres) GetTasksProgressStatusIDs(ctx context.Context, ids []uint64) (map[uint64]int64, error) {
+	query := fmt.Sprintf(`SELECT id, progress_status_id FROM %s WHERE id = ANY($1)`, taskTable)
+	var rows []struct {
+		ID               uint64 `db:"id"`
+		ProgressStatusID int64  `db:"progress_status_id"`
+	}
+
+	// pq does not support arrays of unsigned integers
+	taskIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		taskIDs = append(taskIDs, int64(id))
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &rows, query, pq.Array(taskIDs)); err != nil {
+		return nil, err
+	}
+
+	statusIDs := make(map[uint64]int64, len(rows))
+	for _, row := range rows {
+		statusIDs[row.ID] = row.ProgressStatusID
+	}
+
+	return statusIDs, nil
+}
+
+// GetTaskWithSubtasks returns the task and its subtasks of all levels.
+func (r *TaskPostgres) GetTaskWithSubtasks(ctx context.Context, id uint64) ([]models.Task, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id IN (%s) ORDER BY id ASC`, taskColumns, taskTable, subtasksQuery)
 	var tasks []models.Task
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err := r.db.SelectContext(dbCtx, &tasks, query)
+	err := r.db.SelectContext(dbCtx, &tasks, query, &id)
 
 	return tasks, err
 }
 
-func (r *TaskPostgres) DeleteTask(ctx context.Context, id uint64) error {
-	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, taskTable)
+// DeleteTask deletes task with its subtasks by the policy and returns ids of deleted tasks.
+func (r *TaskPostgres) DeleteTask(ctx context.Context, id uint64, policy models.SubtasksPolicy) ([]uint64, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 RETURNING id`, taskTable)
+	if policy == models.SubtasksPolicyCascade {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE id IN (%s) RETURNING id`, taskTable, subtasksQuery)
+	}
+
+	var ids []uint64
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
-		return err
+	if err := r.db.SelectContext(dbCtx, &ids, query, &id); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// notDoneTaskConditionOf returns notDoneTaskCondition for task table with the alias.
+func notDoneTaskConditionOf(alias string) string {
+	return fmt.Sprintf("%s.completed_at IS NULL", alias)
+}
+
+func (r *TaskPostgres) getTasksPage(
+	ctx context.Context, condition string, args []interface{}, page models.PageRequest,
+) ([]models.Task, int64, error) {
+	q, err := newPageQuery(taskColumns, taskTable, condition, args, page, taskSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var tasks []models.Task
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	total, err := selectPage(dbCtx, r.db, &tasks, q)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return nil
+	return tasks, total, nil
 }
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/taskfilter"
+	"github.com/pkg/errors"
+)
+
+var taskFilterOperators = map[taskfilter.Operator]string{
+	taskfilter.OperatorEqual:          "=",
+	taskfilter.OperatorLess:           "<",
+	taskfilter.OperatorLessOrEqual:    "<=",
+	taskfilter.OperatorGreater:        ">",
+	taskfilter.OperatorGreaterOrEqual: ">=",
+}
+
+type TaskFilterPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewTaskFilterPostgres(db *sqlx.DB, dbTimeout time.Duration) *TaskFilterPostgres {
+	return &TaskFilterPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *TaskFilterPostgres) CreateTaskFilter(
+	ctx context.Context, filter models.TaskFilterToCreate, ownerID uint64,
+) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (project_id, owner_id, name, query, is_shared) values ($1, $2, $3, $4, $5) RETURNING id`,
+		taskFilterTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &filter.ProjectID, &ownerID, &filter.Name, &filter.Query, &filter.IsShared)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *TaskFilterPostgres) GetTaskFilterByID(ctx context.Context, id uint64) (*models.TaskFilter, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, owner_id, name, query, is_shared, created_at, updated_at
+FROM %s WHERE id = $1`, taskFilterTable)
+	var filter models.TaskFilter
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &filter, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+// GetAllProjectTaskFiltersToUser returns filters of the project owned by user or shared with project members.
+func (r *TaskFilterPostgres) GetAllProjectTaskFiltersToUser(
+	ctx context.Context, projectID, userID uint64,
+) ([]models.TaskFilter, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, owner_id, name, query, is_shared, created_at, updated_at
+FROM %s WHERE project_id = $1 AND (owner_id = $2 OR is_shared) ORDER BY name ASC, id ASC`, taskFilterTable)
+	var filters []models.TaskFilter
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &filters, query, &projectID, &userID)
+
+	return filters, err
+}
+
+func (r *TaskFilterPostgres) UpdateTaskFilter(ctx context.Context, filter models.TaskFilterToUpdate) error {
+	query := fmt.Sprintf(`UPDATE %s SET name = $1, query = $2, is_shared = $3 WHERE id = $4`, taskFilterTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &filter.Name, &filter.Query, &filter.IsShared, &filter.ID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *TaskFilterPostgres) DeleteTaskFilter(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, taskFilterTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// compileTaskFilter compiles task filter to sql condition on task table appending values to args.
+// Values are never inserted into sql, so condition is safe. User is used to resolve "me" values.
+// Empty condition is returned for empty filter.
+func compileTaskFilter(
+	filter *taskfilter.Filter, userID uint64, args []interface{},
+) (string, []interface{}, error) {
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := make([]string, 0, len(filter.Conditions)+1)
+	for _, cond := range filter.Conditions {
+		var condition string
+
+		switch cond.Field {
+		case taskfilter.FieldAssignee:
+			switch {
+			case cond.Value == taskfilter.ValueMe:
+				condition = fmt.Sprintf("assignee_id = %s", arg(userID))
+			case strings.Contains(cond.Value, "@"):
+				condition = fmt.Sprintf("assignee_id IN (SELECT id FROM %s WHERE lower(email) = lower(%s))",
+					userTable, arg(cond.Value))
+			default:
+				condition = fmt.Sprintf("assignee_id = %s::BIGINT", arg(cond.Value))
+			}
+		case taskfilter.FieldStatus:
+			condition = fmt.Sprintf(
+				"progress_status_id IN (SELECT id FROM %s WHERE project_id = %s.project_id AND lower(name) = lower(%s))",
+				progressStatusTable, taskTable, arg(cond.Value))
+		case taskfilter.FieldImportance:
+			condition = fmt.Sprintf(
+				"importance_status_id IN (SELECT id FROM %s WHERE project_id = %s.project_id AND lower(name) = lower(%s))",
+				importanceStatusTable, taskTable, arg(cond.Value))
+		case taskfilter.FieldLabel:
+			condition = fmt.Sprintf(`id IN (SELECT tl.task_id FROM %s AS tl INNER JOIN %s AS l ON l.id = tl.label_id
+WHERE l.project_id = %s.project_id AND lower(l.name) = lower(%s))`,
+				taskLabelTable, labelTable, taskTable, arg(cond.Value))
+		case taskfilter.FieldDue, taskfilter.FieldStart:
+			column := "due_date"
+			if cond.Field == taskfilter.FieldStart {
+				column = "start_date"
+			}
+
+			if cond.Value == taskfilter.ValueNone {
+				condition = fmt.Sprintf("%s IS NULL", column)
+			} else {
+				condition = fmt.Sprintf("%s %s %s::DATE", column, taskFilterOperators[cond.Operator], arg(cond.Value))
+			}
+		case taskfilter.FieldCreated, taskfilter.FieldUpdated:
+			column := "created_at"
+			if cond.Field == taskfilter.FieldUpdated {
+				column = "updated_at"
+			}
+
+			condition = fmt.Sprintf("%s::DATE %s %s::DATE", column, taskFilterOperators[cond.Operator], arg(cond.Value))
+		case taskfilter.FieldIs:
+			switch cond.Value {
+			case taskfilter.ValueOverdue:
+				condition = fmt.Sprintf("due_date < CURRENT_DATE AND %s", notDoneTaskCondition)
+			case taskfilter.ValueOpen:
+				condition = notDoneTaskCondition
+			case taskfilter.ValueDone:
+				condition = fmt.Sprintf("NOT (%s)", notDoneTaskCondition)
+			}
+		}
+
+		if condition == "" {
+			return "", nil, fmt.Errorf("not supported filter condition %s%s%s", cond.Field, cond.Operator, cond.Value)
+		}
+
+		if cond.Negated {
+			// null values do not match both condition and negated condition without coalesce
+			condition = fmt.Sprintf("NOT COALESCE((%s), FALSE)", condition)
+		}
+
+		conditions = append(conditions, "("+condition+")")
+	}
+
+	if filter.Text != "" {
+		conditions = append(conditions, fmt.Sprintf("search_vector @@ websearch_to_tsquery('%s', %s)",
+			searchConfig, arg(filter.Text)))
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/taskfilter"
+)
+
+func TestCompileTaskFilter(t *testing.T) {
+	const userID = 7
+
+	tests := []struct {
+		name          string
+		query         string
+		args          []interface{}
+		wantCondition string
+		wantArgs      []interface{}
+	}{
+		{
+			name:          "empty filter",
+			query:         "",
+			wantCondition: "",
+		},
+		{
+			name:          "assignee me",
+			query:         "assignee:me",
+			wantCondition: "(assignee_id = $1)",
+			wantArgs:      []interface{}{uint64(userID)},
+		},
+		{
+			name:          "assignee by id",
+			query:         "assignee:42",
+			wantCondition: "(assignee_id = $1::BIGINT)",
+			wantArgs:      []interface{}{"42"},
+		},
+		{
+			name:  "assignee by email",
+			query: "assignee:John@example.com",
+			wantCondition: "(assignee_id IN (SELECT id FROM r_user " +
+				"WHERE lower(email) = lower($1)))",
+			wantArgs: []interface{}{"John@example.com"},
+		},
+		{
+			name:  "status of task project",
+			query: `status:"in progress"`,
+			wantCondition: "(progress_status_id IN (SELECT id FROM s_project_progress_status " +
+				"WHERE project_id = r_task.project_id AND lower(name) = lower($1)))",
+			wantArgs: []interface{}{"in progress"},
+		},
+		{
+			name:          "negated date comparison",
+			query:         "-due<2026-11-01",
+			wantCondition: "(NOT COALESCE((due_date < $1::DATE), FALSE))",
+			wantArgs:      []interface{}{"2026-11-01"},
+		},
+		{
+			name:          "date is not set",
+			query:         "start:none",
+			wantCondition: "(start_date IS NULL)",
+		},
+		{
+			name:          "created date",
+			query:         "created>=2026-01-01",
+			wantCondition: "(created_at::DATE >= $1::DATE)",
+			wantArgs:      []interface{}{"2026-01-01"},
+		},
+		{
+			name:          "overdue",
+			query:         "is:overdue",
+			wantCondition: "(due_date < CURRENT_DATE AND r_task.completed_at IS NULL)",
+		},
+		{
+			name:          "done",
+			query:         "is:done",
+			wantCondition: "(NOT (r_task.completed_at IS NULL))",
+		},
+		{
+			name:  "conditions and text after existing args",
+			query: "assignee:me login bug",
+			args:  []interface{}{uint64(1)},
+			wantCondition: "(assignee_id = $2) AND " +
+				"search_vector @@ websearch_to_tsquery('task_tracker_search', $3)",
+			wantArgs: []interface{}{uint64(1), uint64(userID), "login bug"},
+		},
+		{
+			name:  "sql in value is passed as arg",
+			query: `label:"x') OR TRUE --"`,
+			wantCondition: "(id IN (SELECT tl.task_id FROM nn_task_label AS tl " +
+				"INNER JOIN s_project_label AS l ON l.id = tl.label_id\n" +
+				"WHERE l.project_id = r_task.project_id AND lower(l.name) = lower($1)))",
+			wantArgs: []interface{}{"x') OR TRUE --"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := taskfilter.Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+
+			condition, args, err := compileTaskFilter(filter, userID, tt.args)
+			if err != nil {
+				t.Fatalf("compileTaskFilter() error = %v", err)
+			}
+
+			if condition != tt.wantCondition {
+				t.Errorf("condition = %q, want %q", condition, tt.wantCondition)
+			}
+
+			if len(args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(args, tt.wantArgs) {
+					t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
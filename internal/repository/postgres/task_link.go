@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type TaskLinkPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewTaskLinkPostgres(db *sqlx.DB, dbTimeout time.Duration) *TaskLinkPostgres {
+	return &TaskLinkPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *TaskLinkPostgres) CreateTaskLink(ctx context.Context, link models.TaskLinkToCreate) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (task_id, linked_task_id, type) values ($1, $2, $3) RETURNING id`, taskLinkTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &link.TaskID, &link.LinkedTaskID, &link.Type)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *TaskLinkPostgres) GetTaskLinkByID(ctx context.Context, id uint64) (*models.TaskLink, error) {
+	query := fmt.Sprintf(`
+SELECT id, task_id, linked_task_id, type, created_at FROM %s WHERE id = $1`, taskLinkTable)
+	var link models.TaskLink
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &link, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// GetAllTaskLinks returns links from the task and links to the task.
+func (r *TaskLinkPostgres) GetAllTaskLinks(ctx context.Context, taskID uint64) ([]models.TaskLink, error) {
+	query := fmt.Sprintf(`
+SELECT id, task_id, linked_task_id, type, created_at FROM %s
+WHERE task_id = $1 OR linked_task_id = $1 ORDER BY id ASC`, taskLinkTable)
+	var links []models.TaskLink
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &links, query, &taskID)
+
+	return links, err
+}
+
+func (r *TaskLinkPostgres) DeleteTaskLink(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, taskLinkTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetBlockedProjectTasks returns not done tasks of the project which are blocked by not done tasks.
+func (r *TaskLinkPostgres) GetBlockedProjectTasks(ctx context.Context, projectID uint64) ([]models.BlockedTask, error) {
+	query := fmt.Sprintf(`
+SELECT %s, blockers.blocked_by FROM %s
+INNER JOIN LATERAL (
+SELECT array_agg(l.task_id ORDER BY l.task_id) AS blocked_by FROM %s AS l
+INNER JOIN %s AS b ON b.id = l.task_id
+WHERE l.linked_task_id = %s.id AND l.type = $2 AND %s
+) AS blockers ON blockers.blocked_by IS NOT NULL
+WHERE project_id = $1 AND %s ORDER BY id ASC`,
+		taskColumns, taskTable, taskLinkTable, taskTable, taskTable,
+		notDoneTaskConditionOf("b"), notDoneTaskCondition)
+	var rows []struct {
+		models.Task
+		BlockedBy pq.Int64Array `db:"blocked_by"`
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &rows, query, &projectID, models.TaskLinkTypeBlocks); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]models.BlockedTask, 0, len(rows))
+	for _, row := range rows {
+		blockedBy := make([]uint64, 0, len(row.BlockedBy))
+		for _, id := range row.BlockedBy {
+			blockedBy = append(blockedBy, uint64(id))
+		}
+
+		tasks = append(tasks, models.BlockedTask{
+			Task:      row.Task,
+			BlockedBy: blockedBy,
+		})
+	}
+
+	return tasks, nil
+}
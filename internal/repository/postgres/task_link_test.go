@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestTaskLinkPostgres_CreateTaskLink_Cycle(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	r := NewTaskLinkPostgres(db, testDBTimeout)
+
+	firstID := p.createTask(t, nil)
+	secondID := p.createTask(t, nil)
+	thirdID := p.createTask(t, nil)
+	otherProjectTaskID := newTestProject(t, db).createTask(t, nil)
+
+	// first blocks second, second blocks third
+	for _, link := range []models.TaskLinkToCreate{
+		{TaskID: firstID, LinkedTaskID: secondID, Type: models.TaskLinkTypeBlocks},
+		{TaskID: secondID, LinkedTaskID: thirdID, Type: models.TaskLinkTypeBlocks},
+	} {
+		if _, err := r.CreateTaskLink(context.Background(), link); err != nil {
+			t.Fatalf("CreateTaskLink() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		link       models.TaskLinkToCreate
+		wantDetail string
+	}{
+		{
+			name:       "blocked task blocks its blocker",
+			link:       models.TaskLinkToCreate{TaskID: secondID, LinkedTaskID: firstID, Type: models.TaskLinkTypeBlocks},
+			wantDetail: "task can not block the task which blocks it",
+		},
+		{
+			name:       "transitively blocked task blocks its blocker",
+			link:       models.TaskLinkToCreate{TaskID: thirdID, LinkedTaskID: firstID, Type: models.TaskLinkTypeBlocks},
+			wantDetail: "task can not block the task which blocks it",
+		},
+		{
+			name:       "linked task is in other project",
+			link:       models.TaskLinkToCreate{TaskID: firstID, LinkedTaskID: otherProjectTaskID, Type: models.TaskLinkTypeRelatesTo},
+			wantDetail: "linked task should be in the same project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.CreateTaskLink(context.Background(), tt.link)
+			checkBusinessError(t, err, tt.wantDetail)
+		})
+	}
+
+	t.Run("not blocking link back is created", func(t *testing.T) {
+		link := models.TaskLinkToCreate{TaskID: thirdID, LinkedTaskID: firstID, Type: models.TaskLinkTypeRelatesTo}
+		if _, err := r.CreateTaskLink(context.Background(), link); err != nil {
+			t.Errorf("CreateTaskLink() error = %v", err)
+		}
+	})
+
+	t.Run("blocking link without cycle is created", func(t *testing.T) {
+		link := models.TaskLinkToCreate{TaskID: firstID, LinkedTaskID: thirdID, Type: models.TaskLinkTypeBlocks}
+		if _, err := r.CreateTaskLink(context.Background(), link); err != nil {
+			t.Errorf("CreateTaskLink() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+)
+
+func TestTaskPostgres_UpdateTask_ParentCycle(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	r := NewTaskPostgres(db, testDBTimeout)
+
+	// root <- child <- grandchild
+	rootID := p.createTask(t, nil)
+	childID := p.createTask(t, &rootID)
+	grandchildID := p.createTask(t, &childID)
+	otherProjectTaskID := newTestProject(t, db).createTask(t, nil)
+
+	setParent := func(taskID, parentID uint64) error {
+		task, err := r.GetTaskByID(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+
+		task.ParentID = &parentID
+		_, err = r.UpdateTask(context.Background(), *task, p.UserID)
+
+		return err
+	}
+
+	tests := []struct {
+		name       string
+		taskID     uint64
+		parentID   uint64
+		wantDetail string
+	}{
+		{
+			name:       "task is its own parent",
+			taskID:     rootID,
+			parentID:   rootID,
+			wantDetail: "task can not be a subtask of itself or of its subtask",
+		},
+		{
+			name:       "parent is subtask",
+			taskID:     rootID,
+			parentID:   childID,
+			wantDetail: "task can not be a subtask of itself or of its subtask",
+		},
+		{
+			name:       "parent is subtask of subtask",
+			taskID:     rootID,
+			parentID:   grandchildID,
+			wantDetail: "task can not be a subtask of itself or of its subtask",
+		},
+		{
+			name:       "parent is in other project",
+			taskID:     childID,
+			parentID:   otherProjectTaskID,
+			wantDetail: "parent task should be in the same project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkBusinessError(t, setParent(tt.taskID, tt.parentID), tt.wantDetail)
+		})
+	}
+
+	t.Run("subtask is moved to other parent", func(t *testing.T) {
+		if err := setParent(grandchildID, rootID); err != nil {
+			t.Errorf("UpdateTask() error = %v", err)
+		}
+	})
+}
+
+func TestTaskPostgres_UpdateTask_ConcurrentBoardUpdate(t *testing.T) {
+	db := newTestDB(t)
+	p := newTestProject(t, db)
+	taskRepo := NewTaskPostgres(db, testDBTimeout)
+	boardRepo := NewProjectBoardPostgres(db, testDBTimeout)
+
+	firstID := p.createTask(t, nil)
+	secondID := p.createTask(t, nil)
+
+	// task update locks task before board is locked by trigger, board reorder locks board before tasks
+	for i := 0; i < 50; i++ {
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			task, err := taskRepo.GetTaskByID(context.Background(), firstID)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			task.Title = fmt.Sprintf("test %d", i)
+			_, err = taskRepo.UpdateTask(context.Background(), *task, p.UserID)
+			errs <- err
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := boardRepo.UpdateProjectBoardProgressStatusTasks(
+				context.Background(), p.ID, models.ProjectBoardProgressStatusTasks{
+					{TaskID: secondID, TaskOrderNum: i % 2},
+					{TaskID: firstID, TaskOrderNum: (i + 1) % 2},
+				}, nil, p.UserID,
+			)
+			errs <- err
+		}()
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("concurrent update error = %v", err)
+			}
+		}
+	}
+}
@@ -8,9 +8,14 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
+// userColumns are columns of user selected to lists. Password is not selected.
+const userColumns = `id, email, firstname, lastname, is_email_confirmed, avatar_url, is_admin, is_disabled,
+created_at, updated_at`
+
 type UserPostgres struct {
 	db        *sqlx.DB
 	dbTimeout time.Duration
@@ -46,9 +51,37 @@ VALUES ($1, $2, $3, $4) RETURNING id`, userTable)
 	return id, nil
 }
 
+// CreateProjectMember creates user with confirmed email and adds the user to the project in one statement,
+// so user is not created if the user can not be added to the project.
+func (r *UserPostgres) CreateProjectMember(
+	ctx context.Context, user models.UserToCreate, projectID uint64, role models.ProjectRole,
+) (uint64, error) {
+	query := fmt.Sprintf(`
+WITH u AS (
+    INSERT INTO %s (email, firstname, lastname, password, is_email_confirmed)
+    VALUES ($1, $2, $3, $4, TRUE) RETURNING id
+), pu AS (
+    INSERT INTO %s (project_id, user_id, role) SELECT $5, id, $6 FROM u
+)
+SELECT id FROM u`, userTable, projectUserTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var id uint64
+	if err := r.db.QueryRowContext(dbCtx, query,
+		&user.Email, &user.FirstName, &user.LastName, &user.Password, &projectID, &role,
+	).Scan(&id); err != nil {
+		return 0, getDBError(err)
+	}
+
+	return id, nil
+}
+
 func (r *UserPostgres) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := fmt.Sprintf(`
-SELECT id, email, firstname, lastname, password, is_email_confirmed, avatar_url
+SELECT id, email, firstname, lastname, password, is_email_confirmed, avatar_url, is_admin, is_disabled,
+created_at, updated_at
 FROM %s WHERE email=$1`, userTable)
 	var user models.User
 	var err error
@@ -69,7 +102,8 @@ FROM %s WHERE email=$1`, userTable)
 
 func (r *UserPostgres) GetUserByID(ctx context.Context, id uint64) (*models.User, error) {
 	query := fmt.Sprintf(`
-SELECT id, email, firstname, lastname, password, is_email_confirmed, avatar_url
+SELECT id, email, firstname, lastname, password, is_email_confirmed, avatar_url, is_admin, is_disabled,
+created_at, updated_at
 FROM %s WHERE id=$1`, userTable)
 	var user models.User
 	var err error
@@ -90,7 +124,7 @@ FROM %s WHERE id=$1`, userTable)
 
 func (r *UserPostgres) UpdateUser(ctx context.Context, user models.User) error {
 	query := fmt.Sprintf(`
-UPDATE %s SET firstname = :firstname, lastname = :lastname, avatar_url = :avatar_url
+UPDATE %s SET firstname = :firstname, lastname = :lastname
 WHERE id = :id`, userTable)
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
@@ -104,6 +138,58 @@ WHERE id = :id`, userTable)
 	return nil
 }
 
+// UpdateUserEmail sets confirmed email.
+func (r *UserPostgres) UpdateUserEmail(ctx context.Context, userID uint64, email string) error {
+	query := fmt.Sprintf(`UPDATE %s SET email = $1, is_email_confirmed = true WHERE id = $2`, userTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &email, &userID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// UpdateUserAvatar sets avatar url and keys of avatar blobs. Blobs of previous avatar are queued
+// for deletion by db trigger.
+func (r *UserPostgres) UpdateUserAvatar(ctx context.Context, userID uint64, avatarURL string, blobKeys []string) error {
+	query := fmt.Sprintf(`UPDATE %s SET avatar_url = $1, avatar_blob_keys = $2 WHERE id = $3`, userTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if blobKeys == nil {
+		blobKeys = []string{}
+	}
+
+	if _, err := r.db.ExecContext(dbCtx, query, &avatarURL, pq.Array(blobKeys), &userID); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// GetUserAvatarBlobKeys returns keys of blobs of uploaded avatar. Empty list is returned if avatar is not uploaded.
+func (r *UserPostgres) GetUserAvatarBlobKeys(ctx context.Context, userID uint64) ([]string, error) {
+	query := fmt.Sprintf(`SELECT avatar_blob_keys FROM %s WHERE id = $1`, userTable)
+	var keys pq.StringArray
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &keys, query, &userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return keys, nil
+}
+
 func (r *UserPostgres) UpdateUserPassword(ctx context.Context, userID uint64, password string) error {
 	query := fmt.Sprintf(`UPDATE %s SET password = $1 WHERE id = $2`, userTable)
 
@@ -117,27 +203,31 @@ func (r *UserPostgres) UpdateUserPassword(ctx context.Context, userID uint64, pa
 	return nil
 }
 
-func (r *UserPostgres) GetAllUsers(ctx context.Context) ([]models.User, error) {
-	query := fmt.Sprintf(`
-SELECT id, email, firstname, lastname, is_email_confirmed, avatar_url FROM %s ORDER BY id ASC`, userTable)
+func (r *UserPostgres) GetAllUsers(ctx context.Context, page models.PageRequest) ([]models.User, int64, error) {
+	q, err := newPageQuery(userColumns, userTable, "", nil, page, userSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	var users []models.User
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	if err := r.db.SelectContext(dbCtx, &users, query); err != nil {
-		return nil, err
+	total, err := selectPage(dbCtx, r.db, &users, q)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
-func (r *UserPostgres) GetAllUsersWithParameters(ctx context.Context, params models.UserParams) ([]models.User, error) {
-	query := fmt.Sprintf(`
-SELECT id, email, firstname, lastname, is_email_confirmed, avatar_url FROM %s
-WHERE (id = $1 OR $1 is null) AND (email ILIKE $2 OR $2 is null) AND (firstname ILIKE $3 OR $3 is null) AND
-(lastname = $4 OR $4 is null) AND (is_email_confirmed = $5 OR $5 is null)
-ORDER BY id ASC`, userTable)
+func (r *UserPostgres) GetAllUsersWithParameters(
+	ctx context.Context, params models.UserParams, page models.PageRequest,
+) ([]models.User, int64, error) {
+	condition := `(id = $1 OR $1 is null) AND (email ILIKE $2 OR $2 is null) AND (firstname ILIKE $3 OR $3 is null) AND
+(lastname = $4 OR $4 is null) AND (is_email_confirmed = $5 OR $5 is null) AND (is_admin = $6 OR $6 is null) AND
+(is_disabled = $7 OR $7 is null)`
 
 	if params.Email != nil {
 		*params.Email = "%%" + *params.Email + "%%"
@@ -151,15 +241,25 @@ ORDER BY id ASC`, userTable)
 		*params.LastName = "%%" + *params.LastName + "%%"
 	}
 
+	q, err := newPageQuery(userColumns, userTable, condition, []interface{}{
+		params.ID, params.Email, params.FirstName, params.LastName, params.IsEmailConfirmed,
+		params.IsAdmin, params.IsDisabled,
+	}, page, userSortColumns)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	var users []models.User
 
 	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	err := r.db.SelectContext(dbCtx, &users, query, params.ID, params.Email,
-		params.FirstName, params.LastName, params.IsEmailConfirmed)
+	total, err := selectPage(dbCtx, r.db, &users, q)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return users, err
+	return users, total, nil
 }
 
 func (r *UserPostgres) DeleteUser(ctx context.Context, id uint64) error {
@@ -169,12 +269,42 @@ func (r *UserPostgres) DeleteUser(ctx context.Context, id uint64) error {
 	defer cancel()
 
 	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
-		return err
+		return getDBError(err)
 	}
 
 	return nil
 }
 
+// CountUserAssignedTasks returns number of tasks assigned to user.
+func (r *UserPostgres) CountUserAssignedTasks(ctx context.Context, userID uint64) (int64, error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE assignee_id = $1`, taskTable)
+	var count int64
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &count, query, &userID); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountUserOwnedProjects returns number of projects owned by user.
+func (r *UserPostgres) CountUserOwnedProjects(ctx context.Context, userID uint64) (int64, error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE user_id = $1 AND role = $2`, projectUserTable)
+	var count int64
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &count, query, &userID, models.ProjectRoleOwner); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *UserPostgres) ConfirmEmail(ctx context.Context, id uint64) error {
 	query := fmt.Sprintf(`UPDATE %s SET is_email_confirmed = true WHERE id = $1`, userTable)
 
@@ -187,3 +317,29 @@ func (r *UserPostgres) ConfirmEmail(ctx context.Context, id uint64) error {
 
 	return nil
 }
+
+func (r *UserPostgres) SetUserAdmin(ctx context.Context, id uint64, isAdmin bool) error {
+	query := fmt.Sprintf(`UPDATE %s SET is_admin = $1 WHERE id = $2`, userTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &isAdmin, &id); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *UserPostgres) SetUserDisabled(ctx context.Context, id uint64, isDisabled bool) error {
+	query := fmt.Sprintf(`UPDATE %s SET is_disabled = $1 WHERE id = $2`, userTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &isDisabled, &id); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+type WebhookPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewWebhookPostgres(db *sqlx.DB, dbTimeout time.Duration) *WebhookPostgres {
+	return &WebhookPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *WebhookPostgres) CreateWebhook(ctx context.Context, webhook models.WebhookToCreate) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (project_id, url, secret, events) values ($1, $2, $3, $4) RETURNING id`, webhookTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query, &webhook.ProjectID, &webhook.URL, &webhook.Secret, &webhook.Events)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *WebhookPostgres) GetWebhookByID(ctx context.Context, id uint64) (*models.Webhook, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, url, secret, events, is_active, created_at, updated_at
+FROM %s WHERE id = $1`, webhookTable)
+	var webhook models.Webhook
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &webhook, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *WebhookPostgres) UpdateWebhook(ctx context.Context, webhook models.WebhookToUpdate) error {
+	query := fmt.Sprintf(`
+UPDATE %s SET url = $1, secret = COALESCE(NULLIF($2, ''), secret), events = $3, is_active = $4
+WHERE id = $5`, webhookTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query,
+		&webhook.URL, &webhook.Secret, &webhook.Events, &webhook.IsActive, &webhook.ID,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *WebhookPostgres) GetAllProjectWebhooks(ctx context.Context, projectID uint64) ([]models.Webhook, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, url, secret, events, is_active, created_at, updated_at
+FROM %s WHERE project_id = $1 ORDER BY id ASC`, webhookTable)
+	var webhooks []models.Webhook
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &webhooks, query, &projectID)
+
+	return webhooks, err
+}
+
+// GetProjectWebhooksByEvent returns active webhooks of the project subscribed to the event.
+func (r *WebhookPostgres) GetProjectWebhooksByEvent(
+	ctx context.Context, projectID uint64, event models.WebhookEvent,
+) ([]models.Webhook, error) {
+	query := fmt.Sprintf(`
+SELECT id, project_id, url, secret, events, is_active, created_at, updated_at
+FROM %s WHERE project_id = $1 AND is_active AND (events = '[]'::JSONB OR events ? $2)
+ORDER BY id ASC`, webhookTable)
+	var webhooks []models.Webhook
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &webhooks, query, &projectID, &event)
+
+	return webhooks, err
+}
+
+func (r *WebhookPostgres) DeleteWebhook(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, webhookTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookPostgres) CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	query := fmt.Sprintf(`
+INSERT INTO %s (webhook_id, event, payload, attempt, status_code, error, is_success)
+values ($1, $2, $3, $4, $5, $6, $7)`, webhookDeliveryTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &delivery.WebhookID, &delivery.Event, &delivery.Payload,
+		&delivery.Attempt, &delivery.StatusCode, &delivery.Error, &delivery.IsSuccess,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the latest delivery attempts of the webhook.
+func (r *WebhookPostgres) GetWebhookDeliveries(
+	ctx context.Context, webhookID uint64, limit int,
+) ([]models.WebhookDelivery, error) {
+	query := fmt.Sprintf(`
+SELECT id, webhook_id, event, payload, attempt, status_code, error, is_success, created_at
+FROM %s WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2`, webhookDeliveryTable)
+	var deliveries []models.WebhookDelivery
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &deliveries, query, &webhookID, &limit)
+
+	return deliveries, err
+}
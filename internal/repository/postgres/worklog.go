@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+const worklogColumns = `id, task_id, user_id, duration, started_at, note, created_at, updated_at`
+
+type WorklogPostgres struct {
+	db        *sqlx.DB
+	dbTimeout time.Duration
+}
+
+func NewWorklogPostgres(db *sqlx.DB, dbTimeout time.Duration) *WorklogPostgres {
+	return &WorklogPostgres{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+func (r *WorklogPostgres) CreateWorklog(ctx context.Context, worklog models.WorklogToCreate, userID uint64) (uint64, error) {
+	query := fmt.Sprintf(`
+INSERT INTO %s (task_id, user_id, duration, started_at, note) values ($1, $2, $3, $4, $5) RETURNING id`,
+		worklogTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	row := r.db.QueryRowContext(dbCtx, query,
+		&worklog.TaskID, &userID, &worklog.Duration, &worklog.StartedAt, &worklog.Note)
+	if err := row.Err(); err != nil {
+		return 0, getDBError(err)
+	}
+
+	var id uint64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *WorklogPostgres) GetWorklogByID(ctx context.Context, id uint64) (*models.Worklog, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, worklogColumns, worklogTable)
+	var worklog models.Worklog
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.GetContext(dbCtx, &worklog, query, &id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &worklog, nil
+}
+
+func (r *WorklogPostgres) UpdateWorklog(ctx context.Context, id uint64, worklog models.WorklogToUpdate) error {
+	query := fmt.Sprintf(`
+UPDATE %s SET duration = $1, started_at = $2, note = $3 WHERE id = $4`, worklogTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query,
+		&worklog.Duration, &worklog.StartedAt, &worklog.Note, &id,
+	); err != nil {
+		return getDBError(err)
+	}
+
+	return nil
+}
+
+func (r *WorklogPostgres) GetAllTaskWorklogs(ctx context.Context, taskID uint64) ([]models.Worklog, error) {
+	query := fmt.Sprintf(`
+SELECT %s FROM %s WHERE task_id = $1 ORDER BY started_at ASC, id ASC`, worklogColumns, worklogTable)
+	var worklogs []models.Worklog
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	err := r.db.SelectContext(dbCtx, &worklogs, query, &taskID)
+
+	return worklogs, err
+}
+
+func (r *WorklogPostgres) DeleteWorklog(ctx context.Context, id uint64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, worklogTable)
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(dbCtx, query, &id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTimesheet returns time logged per user per day in the period in tasks of projects where viewer is a member.
+// Only time of the user is returned if user id is not nil. Time of deleted users is returned after other users.
+func (r *WorklogPostgres) GetTimesheet(
+	ctx context.Context, viewerID uint64, userID *uint64, period models.ReportPeriod,
+) ([]models.TimesheetEntry, error) {
+	query := fmt.Sprintf(`
+SELECT w.user_id, COALESCE(u.email, '') AS email, COALESCE(u.firstname, '') AS firstname,
+       COALESCE(u.lastname, '') AS lastname, w.started_at::DATE AS day, SUM(w.duration) AS duration
+FROM %s AS w
+INNER JOIN %s AS t ON t.id = w.task_id
+INNER JOIN %s AS pu ON pu.project_id = t.project_id AND pu.user_id = $1
+LEFT JOIN %s AS u ON u.id = w.user_id
+WHERE w.started_at >= $2::DATE AND w.started_at < $3::DATE + 1 AND (w.user_id = $4 OR $4 IS NULL)
+GROUP BY w.user_id, u.id, w.started_at::DATE ORDER BY w.user_id ASC NULLS LAST, day ASC`,
+		worklogTable, taskTable, projectUserTable, userTable)
+	var entries []models.TimesheetEntry
+
+	dbCtx, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if err := r.db.SelectContext(dbCtx, &entries, query, &viewerID, period.From, period.To, userID); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
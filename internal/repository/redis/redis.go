@@ -1,8 +1,11 @@
 package redis
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -18,6 +21,14 @@ const (
 	userBlockingKeyPrefix              = "ub:"
 	emailConfirmTokenKeyPrefix         = "eConf:"
 	passwordResetConfirmTokenKeyPrefix = "rpConf:"
+	emailChangeTokenKeyPrefix          = "eChange:"
+	projectInvitationTokenKeyPrefix    = "pInv:"
+	jobLockKeyPrefix                   = "jobLock:"
+	jobLastRunKeyPrefix                = "jobLastRun:"
+	boardEventsChannelPrefix           = "boardEvents:"
+
+	// pubSubHealthCheckPeriod is the period of pings to check pub/sub connection.
+	pubSubHealthCheckPeriod = time.Minute
 )
 
 type (
@@ -27,6 +38,7 @@ type (
 		UserBlockingLifetime              int
 		EmailConfirmTokenLifetime         int
 		PasswordResetConfirmTokenLifetime int
+		ProjectInvitationTokenLifetime    int
 	}
 	Redis struct {
 		log     *logrus.Entry
@@ -215,6 +227,51 @@ func (r *Redis) DeleteAccessToken(accessTokenID string) error {
 	return nil
 }
 
+// DeleteAllUserSessions deletes all sessions and access tokens of user.
+func (r *Redis) DeleteAllUserSessions(userID string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	keyPrefix := userToSessionKeyPrefix + userID + ":"
+	cursor := 0
+	for {
+		values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", keyPrefix+"*"))
+		if err != nil {
+			return err
+		}
+
+		var keys []string
+		if _, err = redis.Scan(values, &cursor, &keys); err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			accessTokenID, err := redis.String(conn.Do("GET", key))
+			if err != nil && !errors.Is(err, redis.ErrNil) {
+				return err
+			}
+
+			refreshToken := strings.TrimPrefix(key, keyPrefix)
+			if _, err = conn.Do("DEL", key, sessionKeyPrefix+refreshToken,
+				accessTokenKeyPrefix+accessTokenID,
+			); err != nil {
+				return err
+			}
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
 func (r *Redis) AddUserBlocking(fingerprint string) (int64, error) {
 	conn, err := r.getConnect()
 	if err != nil {
@@ -387,3 +444,300 @@ func (r *Redis) DeletePasswordResetConfirmToken(token string) error {
 
 	return nil
 }
+
+// PutEmailChangeToken puts email change with lifetime of email confirmation token.
+func (r *Redis) PutEmailChangeToken(change models.EmailChange, token string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	changeBytes, err := json.Marshal(&change)
+	if err != nil {
+		return err
+	}
+
+	if _, err = conn.Do("SETEX", emailChangeTokenKeyPrefix+token,
+		r.options.EmailConfirmTokenLifetime, changeBytes,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Redis) GetEmailChangeTokenData(token string) (*models.EmailChange, error) {
+	conn, err := r.getConnect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	resp, err := redis.Bytes(conn.Do("GET", emailChangeTokenKeyPrefix+token))
+	if err != nil {
+		return nil, err
+	}
+
+	change := &models.EmailChange{}
+	if err = json.Unmarshal(resp, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func (r *Redis) DeleteEmailChangeToken(token string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	if _, err = conn.Do("DEL", emailChangeTokenKeyPrefix+token); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Redis) PutProjectInvitationToken(invitation models.ProjectInvitation, token string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	invitationBytes, err := json.Marshal(&invitation)
+	if err != nil {
+		return err
+	}
+
+	if _, err = conn.Do("SETEX", projectInvitationTokenKeyPrefix+token,
+		r.options.ProjectInvitationTokenLifetime, invitationBytes,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Redis) GetProjectInvitationTokenData(token string) (*models.ProjectInvitation, error) {
+	conn, err := r.getConnect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	resp, err := redis.Bytes(conn.Do("GET", projectInvitationTokenKeyPrefix+token))
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &models.ProjectInvitation{}
+	if err = json.Unmarshal(resp, invitation); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r *Redis) DeleteProjectInvitationToken(token string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	if _, err = conn.Do("DEL", projectInvitationTokenKeyPrefix+token); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AcquireJobLock sets lock for the job if it is not set yet. Lock expires after ttl
+// if it is not released, so crashed app instance does not block the job.
+func (r *Redis) AcquireJobLock(jobName string, ttl time.Duration) (bool, error) {
+	conn, err := r.getConnect()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	reply, err := conn.Do("SET", jobLockKeyPrefix+jobName, time.Now().Unix(), "PX", ttl.Milliseconds(), "NX")
+	if err != nil {
+		return false, err
+	}
+
+	return reply != nil, nil
+}
+
+func (r *Redis) ReleaseJobLock(jobName string) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	if _, err = conn.Do("DEL", jobLockKeyPrefix+jobName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetJobLastRun returns time of the last successful run of the job or zero time if it was not run.
+func (r *Redis) GetJobLastRun(jobName string) (time.Time, error) {
+	conn, err := r.getConnect()
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	lastRun, err := redis.Int64(conn.Do("GET", jobLastRunKeyPrefix+jobName))
+	if err != nil {
+		if errors.Is(err, redis.ErrNil) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, lastRun), nil
+}
+
+func (r *Redis) SetJobLastRun(jobName string, lastRun time.Time) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	if _, err = conn.Do("SET", jobLastRunKeyPrefix+jobName, lastRun.UnixNano()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Redis) PublishBoardEvent(projectID uint64, event []byte) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	if _, err = conn.Do("PUBLISH", boardEventsChannelPrefix+strconv.FormatUint(projectID, 10), event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SubscribeBoardEvents receives board events of all projects and passes them to handle.
+// It blocks until ctx is done or connection fails.
+func (r *Redis) SubscribeBoardEvents(ctx context.Context, handle func(projectID uint64, event []byte)) error {
+	conn, err := r.getConnect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = conn.Close(); err != nil {
+			r.log.Error(err)
+		}
+	}()
+
+	psc := redis.PubSubConn{Conn: conn}
+	if err = psc.PSubscribe(boardEventsChannelPrefix + "*"); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// ping connection to detect failures, receiving is stopped by unsubscribing
+	go func() {
+		ticker := time.NewTicker(pubSubHealthCheckPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				if err := psc.PUnsubscribe(); err != nil {
+					r.log.Error(err)
+				}
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := psc.Ping(""); err != nil {
+					r.log.Error(err)
+				}
+			}
+		}
+	}()
+
+	for {
+		switch v := psc.ReceiveWithTimeout(2 * pubSubHealthCheckPeriod).(type) {
+		case redis.Message:
+			projectID, err := strconv.ParseUint(strings.TrimPrefix(v.Channel, boardEventsChannelPrefix), 10, 64)
+			if err != nil {
+				r.log.Errorf("not valid board events channel %s: %v", v.Channel, err)
+				continue
+			}
+
+			handle(projectID, v.Data)
+		case redis.Subscription:
+			if v.Count == 0 {
+				return nil
+			}
+		case error:
+			return v
+		}
+	}
+}
@@ -2,45 +2,76 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/l-orlov/task-tracker/internal/config"
 	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/internal/repository/postgres"
 	"github.com/l-orlov/task-tracker/internal/repository/redis"
+	"github.com/l-orlov/task-tracker/internal/taskfilter"
 	"github.com/sirupsen/logrus"
 )
 
 type (
 	User interface {
 		CreateUser(ctx context.Context, user models.UserToCreate) (uint64, error)
+		CreateProjectMember(
+			ctx context.Context, user models.UserToCreate, projectID uint64, role models.ProjectRole,
+		) (uint64, error)
 		GetUserByID(ctx context.Context, id uint64) (*models.User, error)
 		GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 		UpdateUser(ctx context.Context, user models.User) error
 		UpdateUserPassword(ctx context.Context, userID uint64, password string) error
-		GetAllUsers(ctx context.Context) ([]models.User, error)
-		GetAllUsersWithParameters(ctx context.Context, params models.UserParams) ([]models.User, error)
+		UpdateUserEmail(ctx context.Context, userID uint64, email string) error
+		UpdateUserAvatar(ctx context.Context, userID uint64, avatarURL string, blobKeys []string) error
+		GetUserAvatarBlobKeys(ctx context.Context, userID uint64) ([]string, error)
+		GetAllUsers(ctx context.Context, page models.PageRequest) ([]models.User, int64, error)
+		GetAllUsersWithParameters(
+			ctx context.Context, params models.UserParams, page models.PageRequest,
+		) ([]models.User, int64, error)
 		DeleteUser(ctx context.Context, id uint64) error
+		CountUserAssignedTasks(ctx context.Context, userID uint64) (int64, error)
+		CountUserOwnedProjects(ctx context.Context, userID uint64) (int64, error)
 		ConfirmEmail(ctx context.Context, id uint64) error
+		SetUserAdmin(ctx context.Context, id uint64, isAdmin bool) error
+		SetUserDisabled(ctx context.Context, id uint64, isDisabled bool) error
 	}
 	Project interface {
 		CreateProject(ctx context.Context, project models.ProjectToCreate, owner uint64) (uint64, error)
 		GetProjectByID(ctx context.Context, id uint64) (*models.Project, error)
+		GetProjectTaskCounts(ctx context.Context, projectID uint64) (*models.ProjectTaskCounts, error)
 		UpdateProject(ctx context.Context, project models.Project) error
-		GetAllProjects(ctx context.Context) ([]models.Project, error)
-		GetAllProjectsToUser(ctx context.Context, userID uint64) ([]models.Project, error)
-		GetAllProjectsWithParameters(ctx context.Context, params models.ProjectParams) ([]models.Project, error)
+		GetAllProjects(ctx context.Context, page models.PageRequest) ([]models.Project, int64, error)
+		GetAllProjectsToUser(ctx context.Context, userID uint64, page models.PageRequest) ([]models.Project, int64, error)
+		GetAllProjectsWithParameters(
+			ctx context.Context, params models.ProjectParams, page models.PageRequest,
+		) ([]models.Project, int64, error)
 		DeleteProject(ctx context.Context, id uint64) error
-		AddUserToProject(ctx context.Context, projectID, userID uint64) error
+		AddUserToProject(ctx context.Context, projectID, userID uint64, role models.ProjectRole) error
 		GetAllProjectUsers(ctx context.Context, projectID uint64) ([]models.ProjectUser, error)
+		GetProjectUser(ctx context.Context, projectID, userID uint64) (*models.ProjectUser, error)
+		UpdateProjectUserRole(ctx context.Context, projectID, userID uint64, role models.ProjectRole) error
+		TransferProjectOwnership(ctx context.Context, projectID, userID uint64) error
+		SetProjectOwner(ctx context.Context, projectID, userID uint64) error
 		DeleteUserFromProject(ctx context.Context, projectID, userID uint64) error
 	}
 	ProjectBoard interface {
-		GetProjectBoardBytes(ctx context.Context, projectID uint64) (jsonData []byte, err error)
+		GetProjectBoardBytes(
+			ctx context.Context, projectID uint64, sprintID *uint64,
+		) (jsonData []byte, version uint64, err error)
 		GetProjectBoard(ctx context.Context, projectID uint64) (*models.ProjectBoard, error)
-		UpdateProjectBoardParts(ctx context.Context, board models.ProjectBoard) error
-		UpdateProjectBoardProgressStatuses(ctx context.Context, statuses models.ProjectBoardProgressStatuses) error
-		UpdateProjectBoardProgressStatusTasks(ctx context.Context, tasks models.ProjectBoardProgressStatusTasks) error
+		UpdateProjectBoardParts(
+			ctx context.Context, projectID uint64, board models.ProjectBoard, version *uint64, actorID uint64,
+		) (uint64, error)
+		UpdateProjectBoardProgressStatuses(
+			ctx context.Context, projectID uint64, statuses models.ProjectBoardProgressStatuses, version *uint64,
+		) (uint64, error)
+		UpdateProjectBoardProgressStatusTasks(
+			ctx context.Context, projectID uint64, tasks models.ProjectBoardProgressStatusTasks, version *uint64,
+			actorID uint64,
+		) (uint64, error)
+		GetProjectIDsByBoardEntities(ctx context.Context, progressStatusIDs []int64, taskIDs []uint64) ([]uint64, error)
 	}
 	ImportanceStatus interface {
 		Create(ctx context.Context, status models.ImportanceStatusToCreate) (int64, error)
@@ -58,14 +89,137 @@ type (
 		GetAllToProject(ctx context.Context, projectID uint64) ([]models.ProgressStatus, error)
 		Delete(ctx context.Context, id int64) error
 	}
+	StatusTransition interface {
+		Create(ctx context.Context, transition models.StatusTransitionToCreate) (int64, error)
+		GetByID(ctx context.Context, id int64) (*models.StatusTransition, error)
+		Update(ctx context.Context, transition models.StatusTransitionToUpdate) error
+		GetAllToProject(ctx context.Context, projectID uint64) ([]models.StatusTransition, error)
+		Delete(ctx context.Context, id int64) error
+	}
+	Label interface {
+		Create(ctx context.Context, label models.LabelToCreate) (int64, error)
+		GetByID(ctx context.Context, id int64) (*models.Label, error)
+		Update(ctx context.Context, label models.Label) error
+		GetAllToProject(ctx context.Context, projectID uint64) ([]models.Label, error)
+		Delete(ctx context.Context, id int64) error
+		GetAllToTask(ctx context.Context, taskID uint64) ([]models.Label, error)
+		AddToTask(ctx context.Context, taskID uint64, labelID int64) error
+		DeleteFromTask(ctx context.Context, taskID uint64, labelID int64) error
+	}
 	Task interface {
 		CreateTaskToProject(ctx context.Context, task models.TaskToCreate) (uint64, error)
 		GetTaskByID(ctx context.Context, id uint64) (*models.Task, error)
-		UpdateTask(ctx context.Context, task models.Task) error
-		GetAllTasksToProject(ctx context.Context, id uint64) ([]models.Task, error)
-		GetAllTasksWithParameters(ctx context.Context, params models.TaskParams) ([]models.Task, error)
-		GetAllTasks(ctx context.Context) ([]models.Task, error)
-		DeleteTask(ctx context.Context, id uint64) error
+		UpdateTask(ctx context.Context, task models.Task, actorID uint64) (uint64, error)
+		GetAllTasksToProject(ctx context.Context, id uint64, page models.PageRequest) ([]models.Task, int64, error)
+		GetAllTasksWithParameters(
+			ctx context.Context, params models.TaskParams, page models.PageRequest,
+		) ([]models.Task, int64, error)
+		GetOverdueTasksToUser(ctx context.Context, userID uint64) ([]models.Task, error)
+		GetTasksByFilter(
+			ctx context.Context, projectID, userID uint64, filter *taskfilter.Filter, page models.PageRequest,
+		) ([]models.Task, int64, error)
+		GetAllTasks(ctx context.Context, page models.PageRequest) ([]models.Task, int64, error)
+		GetTasksProgressStatusIDs(ctx context.Context, ids []uint64) (map[uint64]int64, error)
+		GetTaskWithSubtasks(ctx context.Context, id uint64) ([]models.Task, error)
+		DeleteTask(ctx context.Context, id uint64, policy models.SubtasksPolicy) ([]uint64, error)
+	}
+	TaskLink interface {
+		CreateTaskLink(ctx context.Context, link models.TaskLinkToCreate) (uint64, error)
+		GetTaskLinkByID(ctx context.Context, id uint64) (*models.TaskLink, error)
+		GetAllTaskLinks(ctx context.Context, taskID uint64) ([]models.TaskLink, error)
+		DeleteTaskLink(ctx context.Context, id uint64) error
+		GetBlockedProjectTasks(ctx context.Context, projectID uint64) ([]models.BlockedTask, error)
+	}
+	Sprint interface {
+		CreateSprint(ctx context.Context, sprint models.SprintToCreate) (uint64, error)
+		GetSprintByID(ctx context.Context, id uint64) (*models.Sprint, error)
+		UpdateSprint(ctx context.Context, sprint models.SprintToUpdate) error
+		GetAllProjectSprints(ctx context.Context, projectID uint64) ([]models.Sprint, error)
+		DeleteSprint(ctx context.Context, id uint64) error
+		StartSprint(ctx context.Context, id uint64) (bool, error)
+		CloseSprint(ctx context.Context, id uint64, moveTo models.SprintMoveTo) (*models.SprintCloseResult, error)
+		GetAllSprintTasks(ctx context.Context, sprintID uint64) ([]models.Task, error)
+		GetSprintBurndown(ctx context.Context, sprintID uint64) ([]models.SprintBurndownCount, error)
+	}
+	Worklog interface {
+		CreateWorklog(ctx context.Context, worklog models.WorklogToCreate, userID uint64) (uint64, error)
+		GetWorklogByID(ctx context.Context, id uint64) (*models.Worklog, error)
+		UpdateWorklog(ctx context.Context, id uint64, worklog models.WorklogToUpdate) error
+		GetAllTaskWorklogs(ctx context.Context, taskID uint64) ([]models.Worklog, error)
+		DeleteWorklog(ctx context.Context, id uint64) error
+		GetTimesheet(
+			ctx context.Context, viewerID uint64, userID *uint64, period models.ReportPeriod,
+		) ([]models.TimesheetEntry, error)
+	}
+	Attachment interface {
+		CreateAttachment(
+			ctx context.Context, attachment models.AttachmentToCreate, blobKey string, uploaderID uint64,
+		) (uint64, error)
+		GetAttachmentByID(ctx context.Context, id uint64) (*models.Attachment, error)
+		GetAllTaskAttachments(ctx context.Context, taskID uint64) ([]models.Attachment, error)
+		DeleteAttachment(ctx context.Context, id uint64) error
+		DeleteAttachments(ctx context.Context, ids []uint64) error
+	}
+	Blob interface {
+		GetDeletedBlobKeys(ctx context.Context, limit int) ([]string, error)
+		DeleteDeletedBlobKeys(ctx context.Context, keys []string) error
+	}
+	Report interface {
+		GetCumulativeFlow(
+			ctx context.Context, projectID uint64, period models.ReportPeriod,
+		) ([]models.CumulativeFlowCount, error)
+		GetLeadCycleTime(
+			ctx context.Context, projectID uint64, period models.ReportPeriod,
+		) (*models.LeadCycleTimeReport, error)
+		GetThroughput(ctx context.Context, projectID uint64, period models.ReportPeriod) ([]models.ThroughputWeek, error)
+	}
+	Comment interface {
+		CreateComment(ctx context.Context, comment models.CommentToCreate, authorID uint64) (uint64, error)
+		GetCommentByID(ctx context.Context, id uint64) (*models.Comment, error)
+		UpdateComment(ctx context.Context, id uint64, text string) error
+		GetAllTaskComments(ctx context.Context, taskID uint64) ([]models.Comment, error)
+		GetCommentEdits(ctx context.Context, commentID uint64) ([]models.CommentEdit, error)
+		DeleteComment(ctx context.Context, id uint64) error
+	}
+	Activity interface {
+		GetTaskActivity(ctx context.Context, taskID uint64, page models.PageRequest) ([]models.TaskActivity, int64, error)
+		GetProjectActivity(
+			ctx context.Context, projectID uint64, page models.PageRequest,
+		) ([]models.TaskActivity, int64, error)
+	}
+	Notification interface {
+		GetNotificationSettings(ctx context.Context, userID uint64) (*models.NotificationSettings, error)
+		UpdateNotificationSettings(ctx context.Context, settings models.NotificationSettings) error
+		GetDailyDigestTasks(ctx context.Context, since, until time.Time) ([]models.TaskNotification, error)
+		GetDueSoonTasks(ctx context.Context, days int) ([]models.TaskNotification, error)
+	}
+	Webhook interface {
+		CreateWebhook(ctx context.Context, webhook models.WebhookToCreate) (uint64, error)
+		GetWebhookByID(ctx context.Context, id uint64) (*models.Webhook, error)
+		UpdateWebhook(ctx context.Context, webhook models.WebhookToUpdate) error
+		GetAllProjectWebhooks(ctx context.Context, projectID uint64) ([]models.Webhook, error)
+		GetProjectWebhooksByEvent(
+			ctx context.Context, projectID uint64, event models.WebhookEvent,
+		) ([]models.Webhook, error)
+		DeleteWebhook(ctx context.Context, id uint64) error
+		CreateWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery) error
+		GetWebhookDeliveries(ctx context.Context, webhookID uint64, limit int) ([]models.WebhookDelivery, error)
+	}
+	TaskFilter interface {
+		CreateTaskFilter(ctx context.Context, filter models.TaskFilterToCreate, ownerID uint64) (uint64, error)
+		GetTaskFilterByID(ctx context.Context, id uint64) (*models.TaskFilter, error)
+		GetAllProjectTaskFiltersToUser(ctx context.Context, projectID, userID uint64) ([]models.TaskFilter, error)
+		UpdateTaskFilter(ctx context.Context, filter models.TaskFilterToUpdate) error
+		DeleteTaskFilter(ctx context.Context, id uint64) error
+	}
+	Search interface {
+		SearchTasks(ctx context.Context, userID uint64, params models.SearchParams) ([]models.TaskSearchResult, error)
+		SearchComments(
+			ctx context.Context, userID uint64, params models.SearchParams,
+		) ([]models.CommentSearchResult, error)
+		SearchProjects(
+			ctx context.Context, userID uint64, params models.SearchParams,
+		) ([]models.ProjectSearchResult, error)
 	}
 	SessionCache interface {
 		PutSessionAndAccessToken(session models.Session, refreshToken string) error
@@ -74,6 +228,7 @@ type (
 		DeleteUserToSession(userID, refreshToken string) error
 		GetAccessTokenData(accessTokenID string) (refreshToken string, err error)
 		DeleteAccessToken(accessTokenID string) error
+		DeleteAllUserSessions(userID string) error
 		AddUserBlocking(fingerprint string) (int64, error)
 		GetUserBlocking(fingerprint string) (int, error)
 		DeleteUserBlocking(fingerprint string) error
@@ -85,6 +240,22 @@ type (
 		PutPasswordResetConfirmToken(userID uint64, token string) error
 		GetPasswordResetConfirmTokenData(token string) (userID uint64, err error)
 		DeletePasswordResetConfirmToken(token string) error
+		PutEmailChangeToken(change models.EmailChange, token string) error
+		GetEmailChangeTokenData(token string) (*models.EmailChange, error)
+		DeleteEmailChangeToken(token string) error
+		PutProjectInvitationToken(invitation models.ProjectInvitation, token string) error
+		GetProjectInvitationTokenData(token string) (*models.ProjectInvitation, error)
+		DeleteProjectInvitationToken(token string) error
+	}
+	JobLock interface {
+		AcquireJobLock(jobName string, ttl time.Duration) (bool, error)
+		ReleaseJobLock(jobName string) error
+		GetJobLastRun(jobName string) (time.Time, error)
+		SetJobLastRun(jobName string, lastRun time.Time) error
+	}
+	BoardEventBroker interface {
+		PublishBoardEvent(projectID uint64, event []byte) error
+		SubscribeBoardEvents(ctx context.Context, handle func(projectID uint64, event []byte)) error
 	}
 	Repository struct {
 		User
@@ -92,9 +263,25 @@ type (
 		ProjectBoard
 		ImportanceStatus
 		ProgressStatus
+		StatusTransition
+		Label
 		Task
+		TaskLink
+		Sprint
+		Worklog
+		Attachment
+		Blob
+		Report
+		Comment
+		Activity
+		Notification
+		Webhook
+		TaskFilter
+		Search
 		SessionCache
 		VerificationCache
+		JobLock
+		BoardEventBroker
 	}
 )
 
@@ -110,6 +297,7 @@ func NewRepository(
 		UserBlockingLifetime:              int(cfg.UserBlocking.Lifetime.Duration().Seconds()),
 		EmailConfirmTokenLifetime:         int(cfg.Verification.EmailConfirmTokenLifetime.Duration().Seconds()),
 		PasswordResetConfirmTokenLifetime: int(cfg.Verification.PasswordResetConfirmTokenLifetime.Duration().Seconds()),
+		ProjectInvitationTokenLifetime:    int(cfg.Verification.ProjectInvitationTokenLifetime.Duration().Seconds()),
 	}
 	cache := redis.New(cfg.Redis, cacheLogEntry, cacheOptions)
 
@@ -119,8 +307,24 @@ func NewRepository(
 		ProjectBoard:      postgres.NewProjectBoardPostgres(db, dbTimeout),
 		ImportanceStatus:  postgres.NewImportanceStatusPostgres(db, dbTimeout),
 		ProgressStatus:    postgres.NewProgressStatusPostgres(db, dbTimeout),
+		StatusTransition:  postgres.NewStatusTransitionPostgres(db, dbTimeout),
+		Label:             postgres.NewLabelPostgres(db, dbTimeout),
 		Task:              postgres.NewTaskPostgres(db, dbTimeout),
+		TaskLink:          postgres.NewTaskLinkPostgres(db, dbTimeout),
+		Sprint:            postgres.NewSprintPostgres(db, dbTimeout),
+		Worklog:           postgres.NewWorklogPostgres(db, dbTimeout),
+		Attachment:        postgres.NewAttachmentPostgres(db, dbTimeout),
+		Blob:              postgres.NewBlobPostgres(db, dbTimeout),
+		Report:            postgres.NewReportPostgres(db, dbTimeout),
+		Comment:           postgres.NewCommentPostgres(db, dbTimeout),
+		Activity:          postgres.NewActivityPostgres(db, dbTimeout),
+		Notification:      postgres.NewNotificationPostgres(db, dbTimeout),
+		Webhook:           postgres.NewWebhookPostgres(db, dbTimeout),
+		TaskFilter:        postgres.NewTaskFilterPostgres(db, dbTimeout),
+		Search:            postgres.NewSearchPostgres(db, dbTimeout),
 		SessionCache:      cache,
 		VerificationCache: cache,
+		JobLock:           cache,
+		BoardEventBroker:  cache,
 	}, nil
 }
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultCheckPeriod is the period of checking whether jobs are due.
+const defaultCheckPeriod = time.Minute
+
+type (
+	// Locker provides distributed lock and time of the last successful run of jobs,
+	// so every job is run only by one app instance once in the job interval.
+	Locker interface {
+		AcquireJobLock(jobName string, ttl time.Duration) (bool, error)
+		ReleaseJobLock(jobName string) error
+		// GetJobLastRun returns zero time if the job has not been run successfully yet.
+		GetJobLastRun(jobName string) (time.Time, error)
+		SetJobLastRun(jobName string, lastRun time.Time) error
+	}
+	// Period is the period from the start of the last successful run of the job to the start of the current run.
+	// From is zero if the job has not been run successfully yet.
+	Period struct {
+		From time.Time
+		To   time.Time
+	}
+	Job struct {
+		Name     string
+		Interval time.Duration
+		Run      func(ctx context.Context, period Period) error
+	}
+	Scheduler struct {
+		log         *logrus.Entry
+		locker      Locker
+		jobs        []Job
+		checkPeriod time.Duration
+		cancel      context.CancelFunc
+		wg          sync.WaitGroup
+	}
+)
+
+// New creates new Scheduler. You should add jobs and call Start() for properly work.
+func New(log *logrus.Entry, locker Locker) *Scheduler {
+	return &Scheduler{
+		log:         log,
+		locker:      locker,
+		checkPeriod: defaultCheckPeriod,
+	}
+}
+
+func (s *Scheduler) AddJob(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job periodically with the job interval. Job is run on start only if its interval
+// has passed since the last successful run, so restarts do not cause extra runs.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.log.Errorf("job %s is disabled: not valid interval %s", job.Name, job.Interval)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.runJobPeriodically(ctx, job)
+	}
+}
+
+// Shutdown stops scheduling and waits for running jobs to finish.
+func (s *Scheduler) Shutdown() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) runJobPeriodically(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	// job is checked several times in the interval, so it is run late at most by the check period
+	checkPeriod := s.checkPeriod
+	if checkPeriod > job.Interval/2 {
+		checkPeriod = job.Interval / 2
+	}
+
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+
+	for {
+		s.runJobIfDue(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJobIfDue runs the job under lock if the job interval has passed since its last successful run.
+func (s *Scheduler) runJobIfDue(ctx context.Context, job Job) {
+	acquired, err := s.locker.AcquireJobLock(job.Name, job.Interval)
+	if err != nil {
+		s.log.Errorf("failed to acquire lock for job %s: %v", job.Name, err)
+		return
+	}
+
+	if !acquired {
+		s.log.Debugf("job %s is skipped: run by another instance", job.Name)
+		return
+	}
+	defer func() {
+		if err := s.locker.ReleaseJobLock(job.Name); err != nil {
+			s.log.Errorf("failed to release lock for job %s: %v", job.Name, err)
+		}
+	}()
+
+	lastRun, err := s.locker.GetJobLastRun(job.Name)
+	if err != nil {
+		s.log.Errorf("failed to get last run of job %s: %v", job.Name, err)
+		return
+	}
+
+	start := time.Now()
+	if !lastRun.IsZero() && start.Sub(lastRun) < job.Interval {
+		return
+	}
+
+	if err = job.Run(ctx, Period{From: lastRun, To: start}); err != nil {
+		s.log.Errorf("failed to run job %s: %v", job.Name, err)
+		return
+	}
+
+	if err = s.locker.SetJobLastRun(job.Name, start); err != nil {
+		s.log.Errorf("failed to set last run of job %s: %v", job.Name, err)
+		return
+	}
+
+	s.log.Infof("job %s is done in %s", job.Name, time.Since(start))
+}
@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeLocker keeps locks and last runs of jobs in memory like shared storage of app instances.
+type fakeLocker struct {
+	mu       sync.Mutex
+	locks    map[string]time.Time
+	lastRuns map[string]time.Time
+}
+
+func newFakeLocker() *fakeLocker {
+	return &fakeLocker{
+		locks:    make(map[string]time.Time),
+		lastRuns: make(map[string]time.Time),
+	}
+}
+
+func (l *fakeLocker) AcquireJobLock(jobName string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if expiresAt, ok := l.locks[jobName]; ok && time.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	l.locks[jobName] = time.Now().Add(ttl)
+
+	return true, nil
+}
+
+func (l *fakeLocker) ReleaseJobLock(jobName string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.locks, jobName)
+
+	return nil
+}
+
+func (l *fakeLocker) GetJobLastRun(jobName string) (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.lastRuns[jobName], nil
+}
+
+func (l *fakeLocker) SetJobLastRun(jobName string, lastRun time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastRuns[jobName] = lastRun
+
+	return nil
+}
+
+// periodRecorder records periods of job runs.
+type periodRecorder struct {
+	mu      sync.Mutex
+	periods []Period
+	err     error
+}
+
+func (r *periodRecorder) run(_ context.Context, period Period) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.periods = append(r.periods, period)
+
+	return r.err
+}
+
+func (r *periodRecorder) getPeriods() []Period {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Period(nil), r.periods...)
+}
+
+func newTestScheduler(locker Locker, checkPeriod time.Duration) *Scheduler {
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	s := New(logrus.NewEntry(log), locker)
+	s.checkPeriod = checkPeriod
+
+	return s
+}
+
+func runScheduler(s *Scheduler, duration time.Duration) {
+	s.Start()
+	time.Sleep(duration)
+	s.Shutdown()
+}
+
+func TestScheduler_RunsJobEveryInterval(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	locker := newFakeLocker()
+	recorder := &periodRecorder{}
+
+	s := newTestScheduler(locker, 5*time.Millisecond)
+	s.AddJob(Job{Name: "job", Interval: interval, Run: recorder.run})
+	runScheduler(s, 25*interval)
+
+	periods := recorder.getPeriods()
+	// job skipped every other interval would be run about 13 times
+	if len(periods) < 18 || len(periods) > 26 {
+		t.Fatalf("job is run %d times, want about 25", len(periods))
+	}
+
+	if !periods[0].From.IsZero() {
+		t.Errorf("first period starts at %s, want zero time", periods[0].From)
+	}
+
+	for i := 1; i < len(periods); i++ {
+		if !periods[i].From.Equal(periods[i-1].To) {
+			t.Fatalf("period %d starts at %s, want end of previous period %s",
+				i, periods[i].From, periods[i-1].To)
+		}
+
+		if periods[i].To.Sub(periods[i].From) < interval {
+			t.Errorf("period %d is shorter than interval: %s", i, periods[i].To.Sub(periods[i].From))
+		}
+	}
+}
+
+func TestScheduler_DoesNotRunJobOnRestartBeforeInterval(t *testing.T) {
+	locker := newFakeLocker()
+	lastRun := time.Now().Add(-time.Minute)
+	_ = locker.SetJobLastRun("job", lastRun)
+
+	recorder := &periodRecorder{}
+
+	s := newTestScheduler(locker, 5*time.Millisecond)
+	s.AddJob(Job{Name: "job", Interval: time.Hour, Run: recorder.run})
+	runScheduler(s, 30*time.Millisecond)
+
+	if periods := recorder.getPeriods(); len(periods) != 0 {
+		t.Fatalf("job is run %d times after restart, want 0", len(periods))
+	}
+}
+
+func TestScheduler_RunsOverdueJobFromLastRun(t *testing.T) {
+	locker := newFakeLocker()
+	lastRun := time.Now().Add(-3 * time.Hour)
+	_ = locker.SetJobLastRun("job", lastRun)
+
+	recorder := &periodRecorder{}
+
+	s := newTestScheduler(locker, 5*time.Millisecond)
+	s.AddJob(Job{Name: "job", Interval: time.Hour, Run: recorder.run})
+	runScheduler(s, 30*time.Millisecond)
+
+	periods := recorder.getPeriods()
+	if len(periods) != 1 {
+		t.Fatalf("job is run %d times, want 1", len(periods))
+	}
+
+	if !periods[0].From.Equal(lastRun) {
+		t.Errorf("period starts at %s, want last run %s", periods[0].From, lastRun)
+	}
+}
+
+func TestScheduler_SkipsJobLockedByAnotherInstance(t *testing.T) {
+	locker := newFakeLocker()
+	if _, err := locker.AcquireJobLock("job", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &periodRecorder{}
+
+	s := newTestScheduler(locker, 5*time.Millisecond)
+	s.AddJob(Job{Name: "job", Interval: time.Hour, Run: recorder.run})
+	runScheduler(s, 30*time.Millisecond)
+
+	if periods := recorder.getPeriods(); len(periods) != 0 {
+		t.Fatalf("locked job is run %d times, want 0", len(periods))
+	}
+}
+
+func TestScheduler_RunsJobOnceForAllInstances(t *testing.T) {
+	locker := newFakeLocker()
+	recorder := &periodRecorder{}
+
+	instances := make([]*Scheduler, 3)
+	for i := range instances {
+		instances[i] = newTestScheduler(locker, 5*time.Millisecond)
+		instances[i].AddJob(Job{Name: "job", Interval: time.Hour, Run: recorder.run})
+		instances[i].Start()
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	for _, s := range instances {
+		s.Shutdown()
+	}
+
+	if periods := recorder.getPeriods(); len(periods) != 1 {
+		t.Fatalf("job is run %d times by all instances, want 1", len(periods))
+	}
+}
+
+func TestScheduler_RetriesFailedJobFromSameLastRun(t *testing.T) {
+	locker := newFakeLocker()
+	recorder := &periodRecorder{err: errors.New("failed")}
+
+	s := newTestScheduler(locker, 5*time.Millisecond)
+	s.AddJob(Job{Name: "job", Interval: time.Hour, Run: recorder.run})
+	runScheduler(s, 30*time.Millisecond)
+
+	periods := recorder.getPeriods()
+	if len(periods) < 2 {
+		t.Fatalf("failed job is run %d times, want retries", len(periods))
+	}
+
+	for _, period := range periods {
+		if !period.From.IsZero() {
+			t.Errorf("period of retry starts at %s, want zero time", period.From)
+		}
+	}
+
+	if lastRun, _ := locker.GetJobLastRun("job"); !lastRun.IsZero() {
+		t.Errorf("last run of failed job is set to %s", lastRun)
+	}
+}
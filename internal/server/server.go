@@ -26,7 +26,7 @@ func New(
 		Handler:        handler,
 		MaxHeaderBytes: maxHeaderBytes,
 		ReadTimeout:    timeout,
-		WriteTimeout:   timeout,
+		// write timeout is not set because it would break event streams and downloads of large attachments
 	}
 
 	return s
@@ -36,6 +36,11 @@ func (s *Server) Run() error {
 	return s.httpServer.ListenAndServe()
 }
 
+// RegisterOnShutdown registers function to call on shutdown, for example to end long-lived requests.
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	return s.httpServer.Shutdown(ctx)
 }
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+)
+
+// activityDefaultSort is sort of activity from the newest to the oldest.
+const activityDefaultSort = sortDescPrefix + string(models.SortFieldID)
+
+type ActivityService struct {
+	repo repository.Activity
+}
+
+func NewActivityService(repo repository.Activity) *ActivityService {
+	return &ActivityService{repo: repo}
+}
+
+func (s *ActivityService) GetTaskActivity(
+	ctx context.Context, taskID uint64, pageParams models.PageParams,
+) (*models.TaskActivityPage, error) {
+	req, err := newActivityPageRequest(pageParams)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, total, err := s.repo.GetTaskActivity(ctx, taskID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskActivityPage(activity, total, req), nil
+}
+
+func (s *ActivityService) GetProjectActivity(
+	ctx context.Context, projectID uint64, pageParams models.PageParams,
+) (*models.TaskActivityPage, error) {
+	req, err := newActivityPageRequest(pageParams)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, total, err := s.repo.GetProjectActivity(ctx, projectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskActivityPage(activity, total, req), nil
+}
+
+// newActivityPageRequest validates page params of activity which is sorted from the newest by default.
+func newActivityPageRequest(pageParams models.PageParams) (models.PageRequest, error) {
+	if pageParams.Sort == "" {
+		pageParams.Sort = activityDefaultSort
+	}
+
+	return newPageRequest(pageParams, activitySortFields)
+}
+
+func newTaskActivityPage(
+	activity []models.TaskActivity, total int64, req models.PageRequest,
+) *models.TaskActivityPage {
+	page := &models.TaskActivityPage{
+		Items:      activity,
+		TotalCount: pageTotalCount(req, total),
+	}
+
+	if len(activity) > req.Limit {
+		page.Items = activity[:req.Limit]
+		last := page.Items[req.Limit-1]
+		page.NextCursor = encodePageCursor(req, activitySortValue(last, req.SortField), last.ID)
+	}
+
+	if page.Items == nil {
+		page.Items = []models.TaskActivity{}
+	}
+
+	return page
+}
+
+func activitySortValue(activity models.TaskActivity, field models.SortField) string {
+	if field == models.SortFieldCreatedAt {
+		return models.FormatSortTime(activity.CreatedAt)
+	}
+
+	return ""
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+// fakeActivityRepo returns activity of one task sorted by id like repository does.
+type fakeActivityRepo struct {
+	repository.Activity
+	activity []models.TaskActivity
+	requests []models.PageRequest
+}
+
+func (r *fakeActivityRepo) GetTaskActivity(
+	_ context.Context, _ uint64, page models.PageRequest,
+) ([]models.TaskActivity, int64, error) {
+	r.requests = append(r.requests, page)
+
+	var items []models.TaskActivity
+	for i := range r.activity {
+		item := r.activity[i]
+		if page.SortDesc {
+			item = r.activity[len(r.activity)-1-i]
+		}
+
+		if page.After != nil && (page.SortDesc && item.ID >= page.After.ID ||
+			!page.SortDesc && item.ID <= page.After.ID) {
+			continue
+		}
+
+		if len(items) == page.Limit+1 {
+			break
+		}
+
+		items = append(items, item)
+	}
+
+	return items, int64(len(r.activity)), nil
+}
+
+func TestActivityService_GetTaskActivity(t *testing.T) {
+	repo := &fakeActivityRepo{}
+	for id := uint64(1); id <= 5; id++ {
+		repo.activity = append(repo.activity, models.TaskActivity{
+			ID: id, TaskID: 1, CreatedAt: time.Unix(int64(id), 0),
+		})
+	}
+
+	s := NewActivityService(repo)
+
+	var ids []uint64
+	pageParams := models.PageParams{Limit: 2, WithTotal: true}
+	for {
+		page, err := s.GetTaskActivity(context.Background(), 1, pageParams)
+		if err != nil {
+			t.Fatalf("GetTaskActivity() error = %v", err)
+		}
+
+		if page.TotalCount == nil || *page.TotalCount != 5 {
+			t.Errorf("total count = %v, want 5", page.TotalCount)
+		}
+
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		pageParams.Cursor = page.NextCursor
+	}
+
+	// activity is sorted from the newest by default
+	want := []uint64{5, 4, 3, 2, 1}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+
+	if req := repo.requests[0]; req.SortField != models.SortFieldID || !req.SortDesc {
+		t.Errorf("default sort = %s desc %v, want id desc", req.SortField, req.SortDesc)
+	}
+}
+
+func TestActivityService_GetTaskActivityNotValidParams(t *testing.T) {
+	s := NewActivityService(&fakeActivityRepo{})
+	ascendingCursor := encodePageCursor(models.PageRequest{SortField: models.SortFieldID}, "", 1)
+
+	tests := []struct {
+		name       string
+		pageParams models.PageParams
+		wantErr    error
+	}{
+		{
+			name:       "not valid cursor",
+			pageParams: models.PageParams{Cursor: "MTA"},
+			wantErr:    ErrNotValidCursor,
+		},
+		{
+			name:       "cursor of another sort",
+			pageParams: models.PageParams{Cursor: ascendingCursor},
+			wantErr:    ErrNotValidCursor,
+		},
+		{
+			name:       "not allowed sort field",
+			pageParams: models.PageParams{Sort: "title"},
+			wantErr:    ErrNotValidSortField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.GetTaskActivity(context.Background(), 1, tt.pageParams)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Errorf("GetTaskActivity() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
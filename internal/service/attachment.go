@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/google/uuid"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/pkg/blobstore"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultAttachmentContentType = "application/octet-stream"
+
+var (
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrAttachmentContentNotFound = errors.New("attachment content not found")
+	ErrAttachmentTooLarge        = errors.New("attachment is too large")
+	ErrEmptyAttachment           = errors.New("attachment is empty")
+	ErrNotValidAttachmentName    = errors.New("not valid attachment file name")
+	ErrNotAllowedAttachmentType  = errors.New("attachment content type is not allowed")
+)
+
+type (
+	AttachmentServiceConfig struct {
+		MaxSize int64
+		// AllowedContentTypes are media types like "image/png" or "image/*". Empty list allows any type.
+		AllowedContentTypes []string
+	}
+	AttachmentService struct {
+		cfg       AttachmentServiceConfig
+		log       *logrus.Entry
+		repo      repository.Attachment
+		blobStore blobstore.BlobStore
+	}
+)
+
+func NewAttachmentService(
+	cfg AttachmentServiceConfig, log *logrus.Entry, repo repository.Attachment, blobStore blobstore.BlobStore,
+) *AttachmentService {
+	return &AttachmentService{
+		cfg:       cfg,
+		log:       log,
+		repo:      repo,
+		blobStore: blobStore,
+	}
+}
+
+// ValidateAttachment checks attachment limits and normalizes its file name and content type.
+func (s *AttachmentService) ValidateAttachment(attachment models.AttachmentToCreate) (models.AttachmentToCreate, error) {
+	if attachment.Size == 0 {
+		return attachment, ierrors.NewBusiness(ErrEmptyAttachment, fmt.Sprintf("file %q is empty", attachment.FileName))
+	}
+
+	if attachment.Size > s.cfg.MaxSize {
+		return attachment, ierrors.NewBusiness(ErrAttachmentTooLarge,
+			fmt.Sprintf("file %q is larger than %d bytes", attachment.FileName, s.cfg.MaxSize))
+	}
+
+	// browsers can send file name with path
+	fileName := attachment.FileName
+	if i := strings.LastIndexAny(fileName, `/\`); i >= 0 {
+		fileName = fileName[i+1:]
+	}
+
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" || len(fileName) > 255 {
+		return attachment, ierrors.NewBusiness(ErrNotValidAttachmentName, "")
+	}
+
+	attachment.FileName = fileName
+
+	contentType := defaultAttachmentContentType
+	if attachment.ContentType != "" {
+		mediaType, _, err := mime.ParseMediaType(attachment.ContentType)
+		if err != nil {
+			return attachment, ierrors.NewBusiness(ErrNotAllowedAttachmentType,
+				fmt.Sprintf("not valid content type of file %q", attachment.FileName))
+		}
+
+		contentType = mediaType
+	}
+
+	if !isContentTypeAllowed(contentType, s.cfg.AllowedContentTypes) {
+		return attachment, ierrors.NewBusiness(ErrNotAllowedAttachmentType,
+			fmt.Sprintf("content type should be one of: %s", strings.Join(s.cfg.AllowedContentTypes, ", ")))
+	}
+
+	attachment.ContentType = contentType
+
+	return attachment, nil
+}
+
+// CreateAttachment validates attachment and stores its content to blob storage.
+func (s *AttachmentService) CreateAttachment(
+	ctx context.Context, attachment models.AttachmentToCreate, content io.Reader, uploaderID uint64,
+) (uint64, error) {
+	attachment, err := s.ValidateAttachment(attachment)
+	if err != nil {
+		return 0, err
+	}
+
+	blobKey := fmt.Sprintf("tasks/%d/%s", attachment.TaskID, uuid.New().String())
+	if err = s.blobStore.Put(ctx, blobKey, content, attachment.Size, attachment.ContentType); err != nil {
+		return 0, errors.Wrap(err, "failed to put attachment blob")
+	}
+
+	id, err := s.repo.CreateAttachment(ctx, attachment, blobKey, uploaderID)
+	if err != nil {
+		if deleteErr := s.blobStore.Delete(ctx, blobKey); deleteErr != nil {
+			s.log.Errorf("failed to delete blob %s of not created attachment: %v", blobKey, deleteErr)
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *AttachmentService) GetAttachmentByID(ctx context.Context, id uint64) (*models.Attachment, error) {
+	return s.repo.GetAttachmentByID(ctx, id)
+}
+
+func (s *AttachmentService) GetAllTaskAttachments(ctx context.Context, taskID uint64) ([]models.Attachment, error) {
+	return s.repo.GetAllTaskAttachments(ctx, taskID)
+}
+
+// GetAttachmentContent returns reader of attachment content which should be closed by caller.
+func (s *AttachmentService) GetAttachmentContent(
+	ctx context.Context, attachment models.Attachment,
+) (io.ReadCloser, error) {
+	content, err := s.blobStore.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, ierrors.NewBusiness(ErrAttachmentContentNotFound, "")
+		}
+
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// DeleteAttachment deletes attachment. Its content is deleted from blob storage by BlobService.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id uint64) error {
+	return s.repo.DeleteAttachment(ctx, id)
+}
+
+// DeleteAttachments deletes attachments. Their content is deleted from blob storage by BlobService.
+func (s *AttachmentService) DeleteAttachments(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.repo.DeleteAttachments(ctx, ids)
+}
+
+// isContentTypeAllowed checks media type against allowed types which can have wildcard subtype like "image/*".
+func isContentTypeAllowed(contentType string, allowedTypes []string) bool {
+	if len(allowedTypes) == 0 {
+		return true
+	}
+
+	for _, allowed := range allowedTypes {
+		allowed = strings.ToLower(allowed)
+		if allowed == contentType {
+			return true
+		}
+
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
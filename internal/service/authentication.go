@@ -51,6 +51,10 @@ func (s *AuthenticationService) AuthenticateUserByEmail(
 		return 0, err
 	}
 
+	if user.IsDisabled {
+		return 0, ierrors.NewForbidden(ErrUserIsDisabled, "")
+	}
+
 	if err = s.repo.SessionCache.DeleteUserBlocking(fingerprint); err != nil {
 		s.log.Errorf("err while DeleteUserBlocking: %v", err)
 	}
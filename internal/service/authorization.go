@@ -131,6 +131,11 @@ func (s *AuthorizationService) RevokeSession(accessToken string) error {
 	return nil
 }
 
+// RevokeAllUserSessions logs user out on all devices.
+func (s *AuthorizationService) RevokeAllUserSessions(userID string) error {
+	return s.repo.DeleteAllUserSessions(userID)
+}
+
 func (s *AuthorizationService) GetAccessTokenClaims(accessToken string) (*jwt.StandardClaims, error) {
 	return getTokenClaims(accessToken, s.cfg.JWT.SigningKey)
 }
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/google/uuid"
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/pkg/avatar"
+	"github.com/l-orlov/task-tracker/pkg/blobstore"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrAvatarTooLarge     = errors.New("avatar is too large")
+	ErrNotValidAvatar     = errors.New("not valid avatar image")
+	ErrNotValidAvatarSize = errors.New("not valid avatar size")
+)
+
+type (
+	AvatarServiceConfig struct {
+		// MaxSize is max size of uploaded image in bytes
+		MaxSize int64
+		// MaxDimension is max width and height of uploaded image in pixels
+		MaxDimension int
+	}
+	AvatarService struct {
+		cfg       AvatarServiceConfig
+		log       *logrus.Entry
+		repo      repository.User
+		blobStore blobstore.BlobStore
+	}
+)
+
+func NewAvatarService(
+	cfg AvatarServiceConfig, log *logrus.Entry, repo repository.User, blobStore blobstore.BlobStore,
+) *AvatarService {
+	return &AvatarService{
+		cfg:       cfg,
+		log:       log,
+		repo:      repo,
+		blobStore: blobStore,
+	}
+}
+
+// UploadUserAvatar crops uploaded image to square, stores it in all avatar sizes and returns new avatar url.
+// Previous avatar is deleted from blob storage in background.
+func (s *AvatarService) UploadUserAvatar(ctx context.Context, userID uint64, content io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(content, s.cfg.MaxSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(data)) > s.cfg.MaxSize {
+		return "", ierrors.NewBusiness(ErrAvatarTooLarge, fmt.Sprintf("max size of image is %d bytes", s.cfg.MaxSize))
+	}
+
+	img, err := avatar.Decode(data, s.cfg.MaxDimension)
+	if err != nil {
+		if errors.Is(err, avatar.ErrTooLargeImage) {
+			return "", ierrors.NewBusiness(ErrAvatarTooLarge,
+				fmt.Sprintf("max dimensions of image are %dx%d", s.cfg.MaxDimension, s.cfg.MaxDimension))
+		}
+
+		return "", ierrors.NewBusiness(ErrNotValidAvatar, "image should be in PNG, JPEG or GIF format")
+	}
+
+	images, err := avatar.Square(img, models.AvatarSizes)
+	if err != nil {
+		return "", err
+	}
+
+	version := uuid.New().String()
+	blobKeys := make([]string, 0, len(images))
+	for i, image := range images {
+		key := avatarBlobKey(userID, version, models.AvatarSizes[i])
+		if err = s.blobStore.Put(
+			ctx, key, bytes.NewReader(image), int64(len(image)), avatar.ContentTypePNG,
+		); err != nil {
+			s.deleteBlobs(ctx, blobKeys)
+			return "", errors.Wrap(err, "failed to put avatar blob")
+		}
+
+		blobKeys = append(blobKeys, key)
+	}
+
+	avatarURL := models.UserAvatarURL(userID, version)
+	if err = s.repo.UpdateUserAvatar(ctx, userID, avatarURL, blobKeys); err != nil {
+		s.deleteBlobs(ctx, blobKeys)
+		return "", err
+	}
+
+	return avatarURL, nil
+}
+
+// GetUserAvatar returns uploaded avatar of the size or generated initials avatar if user has not uploaded one.
+func (s *AvatarService) GetUserAvatar(ctx context.Context, user models.User, size int) (*models.UserAvatar, error) {
+	if !isAvatarSizeAllowed(size) {
+		return nil, ierrors.NewBusiness(ErrNotValidAvatarSize,
+			fmt.Sprintf("size should be one of: %s", strings.Trim(fmt.Sprint(models.AvatarSizes), "[]")))
+	}
+
+	blobKeys, err := s.repo.GetUserAvatarBlobKeys(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := fmt.Sprintf("/%d.png", size)
+	for _, key := range blobKeys {
+		if !strings.HasSuffix(key, suffix) {
+			continue
+		}
+
+		content, err := s.blobStore.Get(ctx, key)
+		if err == nil {
+			return &models.UserAvatar{
+				Content:     content,
+				ContentType: avatar.ContentTypePNG,
+			}, nil
+		}
+
+		// user still gets avatar if blob is lost
+		s.log.Errorf("failed to get avatar blob %s: %v", key, err)
+	}
+
+	svg := avatar.InitialsSVG(avatar.Initials(user.FirstName, user.LastName), size, user.ID)
+
+	return &models.UserAvatar{
+		Content:     ioutil.NopCloser(bytes.NewReader(svg)),
+		ContentType: avatar.ContentTypeSVG,
+		IsGenerated: true,
+	}, nil
+}
+
+// DeleteUserAvatar makes user avatar generated. Uploaded avatar is deleted from blob storage in background.
+func (s *AvatarService) DeleteUserAvatar(ctx context.Context, userID uint64) error {
+	return s.repo.UpdateUserAvatar(ctx, userID, models.UserAvatarURL(userID, ""), nil)
+}
+
+// deleteBlobs deletes blobs of avatar which was not saved.
+func (s *AvatarService) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			s.log.Errorf("failed to delete blob %s of not saved avatar: %v", key, err)
+		}
+	}
+}
+
+func avatarBlobKey(userID uint64, version string, size int) string {
+	return fmt.Sprintf("avatars/%d/%s/%d.png", userID, version, size)
+}
+
+func isAvatarSizeAllowed(size int) bool {
+	for _, allowed := range models.AvatarSizes {
+		if allowed == size {
+			return true
+		}
+	}
+
+	return false
+}
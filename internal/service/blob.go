@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/pkg/blobstore"
+	"github.com/sirupsen/logrus"
+)
+
+// blobCleanupBatchSize is number of blobs deleted from storage per db query
+const blobCleanupBatchSize = 100
+
+type BlobService struct {
+	log       *logrus.Entry
+	repo      repository.Blob
+	blobStore blobstore.BlobStore
+}
+
+func NewBlobService(log *logrus.Entry, repo repository.Blob, blobStore blobstore.BlobStore) *BlobService {
+	return &BlobService{
+		log:       log,
+		repo:      repo,
+		blobStore: blobStore,
+	}
+}
+
+// DeleteQueuedBlobs deletes from blob storage blobs of deleted attachments and replaced avatars.
+func (s *BlobService) DeleteQueuedBlobs(ctx context.Context) error {
+	var deletedNum int
+	for {
+		keys, err := s.repo.GetDeletedBlobKeys(ctx, blobCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+
+		deletedKeys := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if err = s.blobStore.Delete(ctx, key); err != nil {
+				s.log.Errorf("failed to delete blob %s: %v", key, err)
+				continue
+			}
+
+			deletedKeys = append(deletedKeys, key)
+		}
+
+		if len(deletedKeys) != 0 {
+			if err = s.repo.DeleteDeletedBlobKeys(ctx, deletedKeys); err != nil {
+				return err
+			}
+		}
+
+		deletedNum += len(deletedKeys)
+
+		// failed blobs are left for the next run
+		if len(keys) < blobCleanupBatchSize || len(deletedKeys) < len(keys) {
+			break
+		}
+	}
+
+	s.log.Debugf("%d blobs are deleted", deletedNum)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	boardEventsSubscriberBufferSize = 32
+	boardEventsResubscribeDelay     = 5 * time.Second
+)
+
+type (
+	// BoardEventsService fans out board events received from the broker to local stream subscribers.
+	// Events are published through the broker, so subscribers of all app instances receive them.
+	BoardEventsService struct {
+		log    *logrus.Entry
+		broker repository.BoardEventBroker
+
+		mu          sync.RWMutex
+		subscribers map[uint64]map[chan []byte]struct{}
+	}
+)
+
+func NewBoardEventsService(log *logrus.Entry, broker repository.BoardEventBroker) *BoardEventsService {
+	return &BoardEventsService{
+		log:         log,
+		broker:      broker,
+		subscribers: make(map[uint64]map[chan []byte]struct{}),
+	}
+}
+
+// PublishBoardEvent publishes the event to board subscribers of the project.
+// Errors are only logged because publishing should not fail the event action.
+func (s *BoardEventsService) PublishBoardEvent(
+	projectID, actorID uint64, eventType models.BoardEventType, data interface{},
+) {
+	event, err := json.Marshal(models.BoardEvent{
+		Type:       eventType,
+		ProjectID:  projectID,
+		ActorID:    actorID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		s.log.Errorf("failed to marshal board event %s: %v", eventType, err)
+		return
+	}
+
+	if err = s.broker.PublishBoardEvent(projectID, event); err != nil {
+		s.log.Errorf("failed to publish board event %s: %v", eventType, err)
+	}
+}
+
+// SubscribeBoardEvents returns channel of json encoded board events of the project.
+// unsubscribe must be called when events are not needed anymore.
+func (s *BoardEventsService) SubscribeBoardEvents(projectID uint64) (events <-chan []byte, unsubscribe func()) {
+	ch := make(chan []byte, boardEventsSubscriberBufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[projectID] == nil {
+		s.subscribers[projectID] = make(map[chan []byte]struct{})
+	}
+	s.subscribers[projectID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers[projectID], ch)
+		if len(s.subscribers[projectID]) == 0 {
+			delete(s.subscribers, projectID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Run receives board events from the broker until ctx is done. It resubscribes on broker failures.
+func (s *BoardEventsService) Run(ctx context.Context) {
+	for {
+		err := s.broker.SubscribeBoardEvents(ctx, s.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.log.Errorf("failed to receive board events: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(boardEventsResubscribeDelay):
+		}
+	}
+}
+
+// dispatch sends the event to local subscribers of the project. Event is dropped for slow subscriber.
+func (s *BoardEventsService) dispatch(projectID uint64, event []byte) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for ch := range s.subscribers[projectID] {
+		select {
+		case ch <- event:
+		default:
+			s.log.Debugf("board event of project %d is dropped for slow subscriber", projectID)
+		}
+	}
+}
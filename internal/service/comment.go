@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrParentCommentNotFound = errors.New("parent comment not found in the task")
+	ErrNestedReplyNotAllowed = errors.New("reply to reply is not allowed")
+	ErrNotCommentAuthor      = errors.New("user is not an author of the comment")
+)
+
+// mentionRegexp matches mentions of users by email like @user@example.com.
+var mentionRegexp = regexp.MustCompile(`(?:^|\s)@([a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,})`)
+
+type (
+	CommentService struct {
+		log    *logrus.Entry
+		repo   *repository.Repository
+		mailer Mailer
+	}
+)
+
+func NewCommentService(log *logrus.Entry, repo *repository.Repository, mailer Mailer) *CommentService {
+	return &CommentService{
+		log:    log,
+		repo:   repo,
+		mailer: mailer,
+	}
+}
+
+func (s *CommentService) CreateComment(
+	ctx context.Context, comment models.CommentToCreate, authorID uint64,
+) (uint64, error) {
+	// only one level of replies is allowed
+	if comment.ParentID != nil {
+		parent, err := s.repo.Comment.GetCommentByID(ctx, *comment.ParentID)
+		if err != nil {
+			return 0, err
+		}
+
+		if parent == nil || parent.TaskID != comment.TaskID {
+			return 0, ierrors.NewBusiness(ErrParentCommentNotFound, "")
+		}
+
+		if parent.ParentID != nil {
+			return 0, ierrors.NewBusiness(ErrNestedReplyNotAllowed, "")
+		}
+	}
+
+	id, err := s.repo.Comment.CreateComment(ctx, comment, authorID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.notifyMentionedUsers(ctx, comment.TaskID, authorID, "", comment.Text)
+
+	return id, nil
+}
+
+func (s *CommentService) GetCommentByID(ctx context.Context, id uint64) (*models.Comment, error) {
+	return s.repo.Comment.GetCommentByID(ctx, id)
+}
+
+func (s *CommentService) UpdateComment(ctx context.Context, id, authorID uint64, text string) error {
+	comment, err := s.getAuthorComment(ctx, id, authorID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Comment.UpdateComment(ctx, id, text); err != nil {
+		return err
+	}
+
+	s.notifyMentionedUsers(ctx, comment.TaskID, authorID, comment.Text, text)
+
+	return nil
+}
+
+// GetAllTaskComments returns top level comments of the task with replies.
+func (s *CommentService) GetAllTaskComments(ctx context.Context, taskID uint64) ([]models.Comment, error) {
+	comments, err := s.repo.Comment.GetAllTaskComments(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make(map[uint64][]models.Comment)
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], comment)
+		}
+	}
+
+	var tree []models.Comment
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			comment.Replies = replies[comment.ID]
+			tree = append(tree, comment)
+		}
+	}
+
+	return tree, nil
+}
+
+func (s *CommentService) GetCommentEdits(ctx context.Context, commentID uint64) ([]models.CommentEdit, error) {
+	return s.repo.Comment.GetCommentEdits(ctx, commentID)
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, id uint64) error {
+	return s.repo.Comment.DeleteComment(ctx, id)
+}
+
+func (s *CommentService) getAuthorComment(ctx context.Context, id, authorID uint64) (*models.Comment, error) {
+	comment, err := s.repo.Comment.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment == nil {
+		return nil, ierrors.NewBusiness(ErrCommentNotFound, "")
+	}
+
+	if comment.AuthorID != authorID {
+		return nil, ierrors.NewForbidden(ErrNotCommentAuthor, "")
+	}
+
+	return comment, nil
+}
+
+// notifyMentionedUsers sends email to project users mentioned in the new text and not mentioned in the old one.
+// Errors are only logged because notification should not fail the comment saving.
+func (s *CommentService) notifyMentionedUsers(ctx context.Context, taskID, authorID uint64, oldText, newText string) {
+	mentions := parseMentions(newText)
+	for email := range parseMentions(oldText) {
+		delete(mentions, email)
+	}
+
+	if len(mentions) == 0 {
+		return
+	}
+
+	task, err := s.repo.Task.GetTaskByID(ctx, taskID)
+	if err != nil || task == nil {
+		s.log.Errorf("failed to get task %d to notify mentioned users: %v", taskID, err)
+		return
+	}
+
+	author, err := s.repo.User.GetUserByID(ctx, authorID)
+	if err != nil || author == nil {
+		s.log.Errorf("failed to get author %d to notify mentioned users: %v", authorID, err)
+		return
+	}
+
+	users, err := s.repo.Project.GetAllProjectUsers(ctx, task.ProjectID)
+	if err != nil {
+		s.log.Errorf("failed to get project users to notify mentioned users: %v", err)
+		return
+	}
+
+	authorName := author.FirstName + " " + author.LastName
+	for _, user := range users {
+		if _, ok := mentions[strings.ToLower(user.Email)]; ok && user.ID != authorID {
+			s.mailer.SendCommentMention(user.Email, authorName, task.Title, newText)
+		}
+	}
+}
+
+// parseMentions returns set of lowercase emails mentioned in the text.
+func parseMentions(text string) map[string]struct{} {
+	mentions := make(map[string]struct{})
+	for _, match := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		mentions[strings.ToLower(match[1])] = struct{}{}
+	}
+
+	return mentions
+}
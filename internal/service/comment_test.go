@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testTaskID      = 1
+	testOtherTaskID = 2
+
+	testCommentID      = 1
+	testReplyID        = 2
+	testOtherCommentID = 3
+)
+
+// fakeCommentRepo keeps comments in memory. Not implemented methods panic.
+type fakeCommentRepo struct {
+	repository.Comment
+	comments map[uint64]models.Comment
+	// updatedTexts are texts saved by UpdateComment by comment ids
+	updatedTexts map[uint64]string
+}
+
+func (r *fakeCommentRepo) CreateComment(
+	_ context.Context, comment models.CommentToCreate, authorID uint64,
+) (uint64, error) {
+	id := uint64(len(r.comments) + 1)
+	r.comments[id] = models.Comment{
+		ID: id, TaskID: comment.TaskID, ParentID: comment.ParentID, AuthorID: authorID, Text: comment.Text,
+	}
+
+	return id, nil
+}
+
+func (r *fakeCommentRepo) GetCommentByID(_ context.Context, id uint64) (*models.Comment, error) {
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &comment, nil
+}
+
+func (r *fakeCommentRepo) UpdateComment(_ context.Context, id uint64, text string) error {
+	r.updatedTexts[id] = text
+	return nil
+}
+
+// newTestCommentService returns service with top level comment and reply to it in test task
+// and comment in other task. All comments are written by test member.
+func newTestCommentService() (*CommentService, *fakeCommentRepo) {
+	commentID := uint64(testCommentID)
+	repo := &fakeCommentRepo{
+		comments: map[uint64]models.Comment{
+			testCommentID:      {ID: testCommentID, TaskID: testTaskID, AuthorID: testMemberID},
+			testReplyID:        {ID: testReplyID, TaskID: testTaskID, ParentID: &commentID, AuthorID: testMemberID},
+			testOtherCommentID: {ID: testOtherCommentID, TaskID: testOtherTaskID, AuthorID: testMemberID},
+		},
+		updatedTexts: make(map[uint64]string),
+	}
+
+	log := logrus.New()
+	log.SetOutput(ioutil.Discard)
+
+	return NewCommentService(logrus.NewEntry(log), &repository.Repository{Comment: repo}, nil), repo
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	uint64Ptr := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name     string
+		parentID *uint64
+		wantErr  error
+	}{
+		{
+			name: "top level comment",
+		},
+		{
+			name:     "reply to top level comment",
+			parentID: uint64Ptr(testCommentID),
+		},
+		{
+			name:     "reply to reply",
+			parentID: uint64Ptr(testReplyID),
+			wantErr:  ErrNestedReplyNotAllowed,
+		},
+		{
+			name:     "reply to comment of other task",
+			parentID: uint64Ptr(testOtherCommentID),
+			wantErr:  ErrParentCommentNotFound,
+		},
+		{
+			name:     "reply to not existing comment",
+			parentID: uint64Ptr(100),
+			wantErr:  ErrParentCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestCommentService()
+			commentsNum := len(repo.comments)
+
+			_, err := s.CreateComment(context.Background(), models.CommentToCreate{
+				TaskID: testTaskID, ParentID: tt.parentID, Text: "text",
+			}, testMemberID)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("CreateComment() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && len(repo.comments) != commentsNum {
+				t.Error("comment is created")
+			}
+		})
+	}
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		commentID uint64
+		authorID  uint64
+		wantErr   error
+	}{
+		{
+			name:      "author updates comment",
+			commentID: testReplyID,
+			authorID:  testMemberID,
+		},
+		{
+			name:      "not author updates comment",
+			commentID: testReplyID,
+			authorID:  testAdminID,
+			wantErr:   ErrNotCommentAuthor,
+		},
+		{
+			name:      "not existing comment",
+			commentID: 100,
+			authorID:  testMemberID,
+			wantErr:   ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestCommentService()
+
+			err := s.UpdateComment(context.Background(), tt.commentID, tt.authorID, "new text")
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("UpdateComment() error = %v, want %v", err, tt.wantErr)
+			}
+
+			text, isUpdated := repo.updatedTexts[tt.commentID]
+			if isUpdated != (tt.wantErr == nil) || isUpdated && text != "new text" {
+				t.Errorf("updated texts = %v", repo.updatedTexts)
+			}
+		})
+	}
+}
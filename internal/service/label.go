@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+)
+
+const defaultLabelColor = "#808080"
+
+type LabelService struct {
+	repo repository.Label
+}
+
+func NewLabelService(repo repository.Label) *LabelService {
+	return &LabelService{repo: repo}
+}
+
+func (s *LabelService) Create(ctx context.Context, label models.LabelToCreate) (int64, error) {
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+
+	return s.repo.Create(ctx, label)
+}
+
+func (s *LabelService) GetByID(ctx context.Context, id int64) (*models.Label, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *LabelService) Update(ctx context.Context, label models.Label) error {
+	return s.repo.Update(ctx, label)
+}
+
+func (s *LabelService) GetAllToProject(ctx context.Context, projectID uint64) ([]models.Label, error) {
+	return s.repo.GetAllToProject(ctx, projectID)
+}
+
+func (s *LabelService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *LabelService) GetAllToTask(ctx context.Context, taskID uint64) ([]models.Label, error) {
+	return s.repo.GetAllToTask(ctx, taskID)
+}
+
+func (s *LabelService) AddToTask(ctx context.Context, taskID uint64, labelID int64) error {
+	return s.repo.AddToTask(ctx, taskID, labelID)
+}
+
+func (s *LabelService) DeleteFromTask(ctx context.Context, taskID uint64, labelID int64) error {
+	return s.repo.DeleteFromTask(ctx, taskID, labelID)
+}
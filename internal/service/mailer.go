@@ -1,6 +1,9 @@
 package service
 
 import (
+	"strings"
+
+	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/pkg/mailer"
 	"gopkg.in/mail.v2"
 )
@@ -37,6 +40,20 @@ func (m *MailerService) SendEmailConfirm(toEmail, token string) {
 	m.mailer.SendMessage(msg)
 }
 
+func (m *MailerService) SendEmailChangeConfirm(toEmail, token string) {
+	msg := mail.NewMessage()
+
+	msg.SetHeader("From", m.cfg.From)
+	msg.SetHeader("To", toEmail)
+	msg.SetHeader("Subject", "TaskTracker email change")
+	msg.SetBody("text/plain",
+		"Hello.\nTo confirm this email for your account go by this link.\n"+
+			m.cfg.AppDomain+"/confirm-email-change?token="+token+
+			"\nIf you did not request email change, ignore this message.")
+
+	m.mailer.SendMessage(msg)
+}
+
 func (m *MailerService) SendResetPasswordConfirm(toEmail, token string) {
 	msg := mail.NewMessage()
 
@@ -50,3 +67,77 @@ func (m *MailerService) SendResetPasswordConfirm(toEmail, token string) {
 
 	m.mailer.SendMessage(msg)
 }
+
+func (m *MailerService) SendProjectInvitation(toEmail, projectName, token string) {
+	msg := mail.NewMessage()
+
+	msg.SetHeader("From", m.cfg.From)
+	msg.SetHeader("To", toEmail)
+	msg.SetHeader("Subject", "TaskTracker project invitation")
+	msg.SetBody("text/plain",
+		"Hello.\nYou are invited to the project \""+projectName+"\".\n"+
+			"To accept the invitation go by this link.\n"+
+			m.cfg.AppDomain+"/accept-invitation?token="+token+
+			"\nTo decline the invitation go by this link.\n"+
+			m.cfg.AppDomain+"/decline-invitation?token="+token+
+			"\nThank you for choosing us :)")
+
+	m.mailer.SendMessage(msg)
+}
+
+func (m *MailerService) SendCommentMention(toEmail, authorName, taskTitle, text string) {
+	msg := mail.NewMessage()
+
+	msg.SetHeader("From", m.cfg.From)
+	msg.SetHeader("To", toEmail)
+	msg.SetHeader("Subject", "TaskTracker mention in task \""+taskTitle+"\"")
+	msg.SetBody("text/plain",
+		"Hello.\n"+authorName+" mentioned you in a comment to the task \""+taskTitle+"\":\n\n"+
+			text+
+			"\n\nThank you for choosing us :)")
+
+	m.mailer.SendMessage(msg)
+}
+
+func (m *MailerService) SendDailyDigest(toEmail, firstName string, tasks []models.TaskNotification) {
+	msg := mail.NewMessage()
+
+	msg.SetHeader("From", m.cfg.From)
+	msg.SetHeader("To", toEmail)
+	msg.SetHeader("Subject", "TaskTracker daily digest")
+	msg.SetBody("text/plain",
+		"Hello, "+firstName+".\nThese tasks assigned to you were changed during the day:\n\n"+
+			formatTaskNotifications(tasks)+
+			"\nYou can disable the digest in notification settings."+
+			"\nThank you for choosing us :)")
+
+	m.mailer.SendMessage(msg)
+}
+
+func (m *MailerService) SendDueSoonReminder(toEmail, firstName string, tasks []models.TaskNotification) {
+	msg := mail.NewMessage()
+
+	msg.SetHeader("From", m.cfg.From)
+	msg.SetHeader("To", toEmail)
+	msg.SetHeader("Subject", "TaskTracker tasks are due soon")
+	msg.SetBody("text/plain",
+		"Hello, "+firstName+".\nThese tasks assigned to you are due soon:\n\n"+
+			formatTaskNotifications(tasks)+
+			"\nYou can disable the reminders in notification settings."+
+			"\nThank you for choosing us :)")
+
+	m.mailer.SendMessage(msg)
+}
+
+func formatTaskNotifications(tasks []models.TaskNotification) string {
+	var b strings.Builder
+	for _, task := range tasks {
+		b.WriteString("- \"" + task.TaskTitle + "\" in the project \"" + task.ProjectName + "\"")
+		if task.DueDate != nil {
+			b.WriteString(", due " + task.DueDate.String())
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
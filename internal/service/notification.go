@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	NotificationService struct {
+		log             *logrus.Entry
+		repo            repository.Notification
+		mailer          Mailer
+		dueReminderDays int
+	}
+)
+
+func NewNotificationService(
+	log *logrus.Entry, repo repository.Notification, mailer Mailer, dueReminderDays int,
+) *NotificationService {
+	return &NotificationService{
+		log:             log,
+		repo:            repo,
+		mailer:          mailer,
+		dueReminderDays: dueReminderDays,
+	}
+}
+
+func (s *NotificationService) GetNotificationSettings(
+	ctx context.Context, userID uint64,
+) (*models.NotificationSettings, error) {
+	return s.repo.GetNotificationSettings(ctx, userID)
+}
+
+func (s *NotificationService) UpdateNotificationSettings(
+	ctx context.Context, settings models.NotificationSettings,
+) error {
+	return s.repo.UpdateNotificationSettings(ctx, settings)
+}
+
+// SendDailyDigests sends to every user the digest of assigned tasks changed in the period [since, until).
+func (s *NotificationService) SendDailyDigests(ctx context.Context, since, until time.Time) error {
+	tasks, err := s.repo.GetDailyDigestTasks(ctx, since, until)
+	if err != nil {
+		return err
+	}
+
+	for _, userTasks := range groupTaskNotificationsByUser(tasks) {
+		s.mailer.SendDailyDigest(userTasks[0].Email, userTasks[0].FirstName, userTasks)
+	}
+
+	s.log.Debugf("daily digest is sent for %d tasks", len(tasks))
+
+	return nil
+}
+
+// SendDueSoonReminders sends to every user the reminder about assigned tasks which are due soon.
+func (s *NotificationService) SendDueSoonReminders(ctx context.Context) error {
+	tasks, err := s.repo.GetDueSoonTasks(ctx, s.dueReminderDays)
+	if err != nil {
+		return err
+	}
+
+	for _, userTasks := range groupTaskNotificationsByUser(tasks) {
+		s.mailer.SendDueSoonReminder(userTasks[0].Email, userTasks[0].FirstName, userTasks)
+	}
+
+	s.log.Debugf("due soon reminders are sent for %d tasks", len(tasks))
+
+	return nil
+}
+
+// groupTaskNotificationsByUser splits tasks ordered by user into groups of the same user.
+func groupTaskNotificationsByUser(tasks []models.TaskNotification) [][]models.TaskNotification {
+	var groups [][]models.TaskNotification
+
+	start := 0
+	for i := 1; i <= len(tasks); i++ {
+		if i == len(tasks) || tasks[i].UserID != tasks[start].UserID {
+			groups = append(groups, tasks[start:i])
+			start = i
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	pageDefaultLimit = 50
+	pageMaxLimit     = 200
+	// sortDescPrefix is prefix of sort field sorted in descending order
+	sortDescPrefix = "-"
+	// sortValueInfinity is sort value of not set date which is sorted after all dates
+	sortValueInfinity = "infinity"
+)
+
+var (
+	ErrNotValidSortField = errors.New("not valid sort field")
+	ErrNotValidCursor    = errors.New("not valid cursor")
+)
+
+var (
+	userSortFields = []models.SortField{
+		models.SortFieldID, models.SortFieldCreatedAt, models.SortFieldUpdatedAt, models.SortFieldEmail,
+	}
+	projectSortFields = []models.SortField{
+		models.SortFieldID, models.SortFieldCreatedAt, models.SortFieldUpdatedAt, models.SortFieldName,
+	}
+	taskSortFields = []models.SortField{
+		models.SortFieldID, models.SortFieldCreatedAt, models.SortFieldUpdatedAt,
+		models.SortFieldTitle, models.SortFieldDueDate,
+	}
+	activitySortFields = []models.SortField{models.SortFieldID, models.SortFieldCreatedAt}
+)
+
+// newPageRequest validates page params of list which can be sorted by the fields.
+// List is sorted by id in ascending order by default.
+func newPageRequest(params models.PageParams, sortFields []models.SortField) (models.PageRequest, error) {
+	sort := params.Sort
+	if sort == "" {
+		sort = string(models.SortFieldID)
+	}
+
+	req := models.PageRequest{
+		SortField: models.SortField(strings.TrimPrefix(sort, sortDescPrefix)),
+		SortDesc:  strings.HasPrefix(sort, sortDescPrefix),
+		Limit:     normalizePageLimit(params.Limit),
+		WithTotal: params.WithTotal,
+	}
+
+	if !isSortFieldAllowed(req.SortField, sortFields) {
+		return models.PageRequest{}, ierrors.NewBusiness(
+			ErrNotValidSortField, fmt.Sprintf("sort field should be one of: %s", joinSortFields(sortFields)),
+		)
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodePageCursor(params.Cursor)
+		// cursor can not be used with another sort
+		if err != nil || cursor.Sort != sort || !isSortValueValid(req.SortField, cursor.SortValue) {
+			return models.PageRequest{}, ierrors.NewBusiness(ErrNotValidCursor, "")
+		}
+
+		req.After = cursor
+	}
+
+	return req, nil
+}
+
+func normalizePageLimit(limit int) int {
+	if limit <= 0 {
+		return pageDefaultLimit
+	}
+
+	if limit > pageMaxLimit {
+		return pageMaxLimit
+	}
+
+	return limit
+}
+
+// isSortValueValid checks sort value of cursor, so tampered cursor is rejected before it is cast in query.
+func isSortValueValid(field models.SortField, value string) bool {
+	// text with null character can not be passed to database
+	if strings.ContainsRune(value, 0) {
+		return false
+	}
+
+	switch field {
+	case models.SortFieldCreatedAt, models.SortFieldUpdatedAt:
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case models.SortFieldDueDate:
+		if value == sortValueInfinity {
+			return true
+		}
+
+		_, err := time.Parse(models.DateLayout, value)
+		return err == nil
+	}
+
+	return true
+}
+
+func isSortFieldAllowed(field models.SortField, sortFields []models.SortField) bool {
+	for _, f := range sortFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinSortFields(sortFields []models.SortField) string {
+	fields := make([]string, 0, len(sortFields))
+	for _, f := range sortFields {
+		fields = append(fields, string(f))
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// encodePageCursor returns cursor pointing to the item with sort value and id.
+func encodePageCursor(req models.PageRequest, sortValue string, id uint64) string {
+	sort := string(req.SortField)
+	if req.SortDesc {
+		sort = sortDescPrefix + sort
+	}
+
+	data, _ := json.Marshal(models.PageCursor{
+		Sort:      sort,
+		SortValue: sortValue,
+		ID:        id,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageCursor(cursor string) (*models.PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageCursor models.PageCursor
+	if err = json.Unmarshal(data, &pageCursor); err != nil {
+		return nil, err
+	}
+
+	if pageCursor.ID == 0 {
+		return nil, ErrNotValidCursor
+	}
+
+	return &pageCursor, nil
+}
+
+// pageTotalCount returns total count if it was requested.
+func pageTotalCount(req models.PageRequest, total int64) *int64 {
+	if !req.WithTotal {
+		return nil
+	}
+
+	return &total
+}
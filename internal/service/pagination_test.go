@@ -0,0 +1,144 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/pkg/errors"
+)
+
+// testPageCursor encodes cursor like encodePageCursor, but allows any values in it.
+func testPageCursor(t *testing.T, cursor models.PageCursor) string {
+	t.Helper()
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		t.Fatalf("failed to marshal cursor: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestNewPageRequest_Cursor(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 12, 30, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		name      string
+		params    models.PageParams
+		wantAfter *models.PageCursor
+		wantErr   error
+	}{
+		{
+			name: "cursor of previous page",
+			params: models.PageParams{
+				Sort: "-createdAt",
+				Cursor: encodePageCursor(
+					models.PageRequest{SortField: models.SortFieldCreatedAt, SortDesc: true},
+					models.FormatSortTime(createdAt), 7,
+				),
+			},
+			wantAfter: &models.PageCursor{Sort: "-createdAt", SortValue: models.FormatSortTime(createdAt), ID: 7},
+		},
+		{
+			name: "cursor of tasks without due date",
+			params: models.PageParams{Sort: "dueDate", Cursor: testPageCursor(t, models.PageCursor{
+				Sort: "dueDate", SortValue: sortValueInfinity, ID: 7,
+			})},
+			wantAfter: &models.PageCursor{Sort: "dueDate", SortValue: sortValueInfinity, ID: 7},
+		},
+		{
+			name:    "not base64 cursor",
+			params:  models.PageParams{Cursor: "not a cursor!"},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name:    "not json cursor",
+			params:  models.PageParams{Cursor: base64.RawURLEncoding.EncodeToString([]byte("garbage"))},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name:    "cursor without id",
+			params:  models.PageParams{Cursor: testPageCursor(t, models.PageCursor{Sort: "id"})},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name: "cursor of another sort",
+			params: models.PageParams{Sort: "-createdAt", Cursor: testPageCursor(t, models.PageCursor{
+				Sort: "createdAt", SortValue: models.FormatSortTime(createdAt), ID: 7,
+			})},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name: "not valid time in cursor",
+			params: models.PageParams{Sort: "createdAt", Cursor: testPageCursor(t, models.PageCursor{
+				Sort: "createdAt", SortValue: "'; DROP TABLE tasks; --", ID: 7,
+			})},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name: "not valid date in cursor",
+			params: models.PageParams{Sort: "dueDate", Cursor: testPageCursor(t, models.PageCursor{
+				Sort: "dueDate", SortValue: "2026-13-01", ID: 7,
+			})},
+			wantErr: ErrNotValidCursor,
+		},
+		{
+			name: "null character in cursor",
+			params: models.PageParams{Sort: "title", Cursor: testPageCursor(t, models.PageCursor{
+				Sort: "title", SortValue: "a\x00b", ID: 7,
+			})},
+			wantErr: ErrNotValidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := newPageRequest(tt.params, taskSortFields)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("newPageRequest() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				// tampered cursor is error of client
+				if customErr, ok := err.(*ierrors.Error); !ok || customErr.Level != ierrors.Business {
+					t.Errorf("newPageRequest() error = %#v, want business error", err)
+				}
+
+				return
+			}
+
+			if req.After == nil || *req.After != *tt.wantAfter {
+				t.Errorf("cursor = %+v, want %+v", req.After, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestNewPageRequest_Limit(t *testing.T) {
+	tests := []struct {
+		limit     int
+		wantLimit int
+	}{
+		{limit: 0, wantLimit: pageDefaultLimit},
+		{limit: -1, wantLimit: pageDefaultLimit},
+		{limit: 10, wantLimit: 10},
+		{limit: pageMaxLimit, wantLimit: pageMaxLimit},
+		{limit: pageMaxLimit + 1, wantLimit: pageMaxLimit},
+		{limit: 1000000, wantLimit: pageMaxLimit},
+	}
+
+	for _, tt := range tests {
+		req, err := newPageRequest(models.PageParams{Limit: tt.limit}, taskSortFields)
+		if err != nil {
+			t.Fatalf("newPageRequest() error = %v", err)
+		}
+
+		if req.Limit != tt.wantLimit {
+			t.Errorf("limit %d is normalized to %d, want %d", tt.limit, req.Limit, tt.wantLimit)
+		}
+	}
+}
@@ -16,6 +16,10 @@ func NewProgressStatusService(repo repository.ProgressStatus) *ProgressStatusSer
 }
 
 func (s *ProgressStatusService) Create(ctx context.Context, status models.ProgressStatusToCreate) (int64, error) {
+	if status.Category == "" {
+		status.Category = models.ProgressStatusCategoryTodo
+	}
+
 	return s.repo.Create(ctx, status)
 }
 
@@ -3,8 +3,17 @@ package service
 import (
 	"context"
 
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
 	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotValidProjectRole       = errors.New("not valid project role")
+	ErrUserNotProjectMember      = errors.New("user is not a member of the project")
+	ErrProjectOwnerChange        = errors.New("project owner can not be changed or deleted, transfer ownership first")
+	ErrUserIsAlreadyProjectOwner = errors.New("user is already the project owner")
 )
 
 type ProjectService struct {
@@ -23,34 +32,190 @@ func (s *ProjectService) GetProjectByID(ctx context.Context, id uint64) (*models
 	return s.repo.GetProjectByID(ctx, id)
 }
 
+func (s *ProjectService) GetProjectTaskCounts(
+	ctx context.Context, projectID uint64,
+) (*models.ProjectTaskCounts, error) {
+	return s.repo.GetProjectTaskCounts(ctx, projectID)
+}
+
 func (s *ProjectService) UpdateProject(ctx context.Context, project models.Project) error {
 	return s.repo.UpdateProject(ctx, project)
 }
 
-func (s *ProjectService) GetAllProjects(ctx context.Context) ([]models.Project, error) {
-	return s.repo.GetAllProjects(ctx)
+func (s *ProjectService) GetAllProjects(ctx context.Context, pageParams models.PageParams) (*models.ProjectPage, error) {
+	req, err := newPageRequest(pageParams, projectSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, total, err := s.repo.GetAllProjects(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectPage(projects, total, req), nil
 }
 
-func (s *ProjectService) GetAllProjectsToUser(ctx context.Context, userID uint64) ([]models.Project, error) {
-	return s.repo.GetAllProjectsToUser(ctx, userID)
+func (s *ProjectService) GetAllProjectsToUser(
+	ctx context.Context, userID uint64, pageParams models.PageParams,
+) (*models.ProjectPage, error) {
+	req, err := newPageRequest(pageParams, projectSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, total, err := s.repo.GetAllProjectsToUser(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectPage(projects, total, req), nil
 }
 
-func (s *ProjectService) GetAllProjectsWithParameters(ctx context.Context, params models.ProjectParams) ([]models.Project, error) {
-	return s.repo.GetAllProjectsWithParameters(ctx, params)
+func (s *ProjectService) GetAllProjectsWithParameters(
+	ctx context.Context, params models.ProjectParams, pageParams models.PageParams,
+) (*models.ProjectPage, error) {
+	req, err := newPageRequest(pageParams, projectSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, total, err := s.repo.GetAllProjectsWithParameters(ctx, params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProjectPage(projects, total, req), nil
 }
 
 func (s *ProjectService) DeleteProject(ctx context.Context, id uint64) error {
 	return s.repo.DeleteProject(ctx, id)
 }
 
-func (s *ProjectService) AddUserToProject(ctx context.Context, projectID, userID uint64) error {
-	return s.repo.AddUserToProject(ctx, projectID, userID)
+func (s *ProjectService) AddUserToProject(
+	ctx context.Context, projectID, userID uint64, role models.ProjectRole,
+) error {
+	if role == "" {
+		role = models.ProjectRoleMember
+	}
+
+	if !role.IsValid() || role == models.ProjectRoleOwner {
+		return ierrors.NewBusiness(ErrNotValidProjectRole, "")
+	}
+
+	return s.repo.AddUserToProject(ctx, projectID, userID, role)
 }
 
 func (s *ProjectService) GetAllProjectUsers(ctx context.Context, projectID uint64) ([]models.ProjectUser, error) {
 	return s.repo.GetAllProjectUsers(ctx, projectID)
 }
 
+func (s *ProjectService) GetProjectUser(ctx context.Context, projectID, userID uint64) (*models.ProjectUser, error) {
+	return s.repo.GetProjectUser(ctx, projectID, userID)
+}
+
+func (s *ProjectService) UpdateProjectUserRole(
+	ctx context.Context, projectID, userID uint64, role models.ProjectRole,
+) error {
+	if !role.IsValid() || role == models.ProjectRoleOwner {
+		return ierrors.NewBusiness(ErrNotValidProjectRole, "")
+	}
+
+	if _, err := s.getNotOwnerProjectUser(ctx, projectID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateProjectUserRole(ctx, projectID, userID, role)
+}
+
+func (s *ProjectService) TransferProjectOwnership(ctx context.Context, projectID, userID uint64) error {
+	user, err := s.repo.GetProjectUser(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ierrors.NewBusiness(ErrUserNotProjectMember, "")
+	}
+
+	if user.Role == models.ProjectRoleOwner {
+		return ierrors.NewBusiness(ErrUserIsAlreadyProjectOwner, "")
+	}
+
+	return s.repo.TransferProjectOwnership(ctx, projectID, userID)
+}
+
+// ReassignProjectOwnership makes user the project owner even if the user is not a member of the project.
+func (s *ProjectService) ReassignProjectOwnership(ctx context.Context, projectID, userID uint64) error {
+	user, err := s.repo.GetProjectUser(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user != nil && user.Role == models.ProjectRoleOwner {
+		return ierrors.NewBusiness(ErrUserIsAlreadyProjectOwner, "")
+	}
+
+	return s.repo.SetProjectOwner(ctx, projectID, userID)
+}
+
 func (s *ProjectService) DeleteUserFromProject(ctx context.Context, projectID, userID uint64) error {
+	if _, err := s.getNotOwnerProjectUser(ctx, projectID, userID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteUserFromProject(ctx, projectID, userID)
 }
+
+// getNotOwnerProjectUser returns project user which is not the project owner.
+// owner has to transfer ownership before leaving the project or changing the role.
+func (s *ProjectService) getNotOwnerProjectUser(
+	ctx context.Context, projectID, userID uint64,
+) (*models.ProjectUser, error) {
+	user, err := s.repo.GetProjectUser(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ierrors.NewBusiness(ErrUserNotProjectMember, "")
+	}
+
+	if user.Role == models.ProjectRoleOwner {
+		return nil, ierrors.NewBusiness(ErrProjectOwnerChange, "")
+	}
+
+	return user, nil
+}
+
+func newProjectPage(projects []models.Project, total int64, req models.PageRequest) *models.ProjectPage {
+	page := &models.ProjectPage{
+		Items:      projects,
+		TotalCount: pageTotalCount(req, total),
+	}
+
+	if len(projects) > req.Limit {
+		page.Items = projects[:req.Limit]
+		last := page.Items[req.Limit-1]
+		page.NextCursor = encodePageCursor(req, projectSortValue(last, req.SortField), last.ID)
+	}
+
+	if page.Items == nil {
+		page.Items = []models.Project{}
+	}
+
+	return page
+}
+
+func projectSortValue(project models.Project, field models.SortField) string {
+	switch field {
+	case models.SortFieldCreatedAt:
+		return models.FormatSortTime(project.CreatedAt)
+	case models.SortFieldUpdatedAt:
+		return models.FormatSortTime(project.UpdatedAt)
+	case models.SortFieldName:
+		return project.Name
+	}
+
+	return ""
+}
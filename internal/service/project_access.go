@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotProjectMember         = errors.New("access denied: not a member of the project")
+	ErrNoProjectPermission      = errors.New("user role does not allow this action in the project")
+	ErrProjectEntitiesNotFound  = errors.New("project entities not found")
+	ErrProjectEntitiesMismatch  = errors.New("entities belong to different projects")
+	ErrAssigneeNotProjectMember = errors.New("assignee is not a member of the project")
+	ErrImportanceStatusNotFound = errors.New("importance status not found in the project")
+	ErrProgressStatusNotFound   = errors.New("progress status not found in the project")
+)
+
+// projectRolePermissions is the matrix of actions allowed to project roles.
+var projectRolePermissions = map[models.ProjectRole][]models.ProjectPermission{
+	models.ProjectRoleViewer: {
+		models.ProjectPermissionRead,
+	},
+	models.ProjectRoleMember: {
+		models.ProjectPermissionRead,
+		models.ProjectPermissionCreateTask,
+		models.ProjectPermissionUpdateTask,
+		models.ProjectPermissionDeleteTask,
+		models.ProjectPermissionUpdateBoardOrder,
+		models.ProjectPermissionCreateComment,
+	},
+	models.ProjectRoleAdmin: {
+		models.ProjectPermissionRead,
+		models.ProjectPermissionUpdateProject,
+		models.ProjectPermissionCreateTask,
+		models.ProjectPermissionUpdateTask,
+		models.ProjectPermissionDeleteTask,
+		models.ProjectPermissionCreateStatus,
+		models.ProjectPermissionUpdateStatus,
+		models.ProjectPermissionDeleteStatus,
+		models.ProjectPermissionUpdateBoardOrder,
+		models.ProjectPermissionCreateComment,
+		models.ProjectPermissionDeleteAnyComment,
+		models.ProjectPermissionDeleteAnyWorklog,
+		models.ProjectPermissionManageMembers,
+		models.ProjectPermissionManageWebhooks,
+		models.ProjectPermissionManageSprints,
+	},
+	models.ProjectRoleOwner: {
+		models.ProjectPermissionRead,
+		models.ProjectPermissionUpdateProject,
+		models.ProjectPermissionDeleteProject,
+		models.ProjectPermissionCreateTask,
+		models.ProjectPermissionUpdateTask,
+		models.ProjectPermissionDeleteTask,
+		models.ProjectPermissionCreateStatus,
+		models.ProjectPermissionUpdateStatus,
+		models.ProjectPermissionDeleteStatus,
+		models.ProjectPermissionUpdateBoardOrder,
+		models.ProjectPermissionCreateComment,
+		models.ProjectPermissionDeleteAnyComment,
+		models.ProjectPermissionDeleteAnyWorklog,
+		models.ProjectPermissionManageMembers,
+		models.ProjectPermissionManageAdmins,
+		models.ProjectPermissionManageWebhooks,
+		models.ProjectPermissionManageSprints,
+		models.ProjectPermissionTransferOwnership,
+	},
+}
+
+type (
+	ProjectAccessService struct {
+		repo *repository.Repository
+	}
+)
+
+func NewProjectAccessService(repo *repository.Repository) *ProjectAccessService {
+	return &ProjectAccessService{repo: repo}
+}
+
+func (s *ProjectAccessService) CheckProjectPermission(
+	ctx context.Context, projectID, userID uint64, permission models.ProjectPermission,
+) error {
+	user, err := s.repo.Project.GetProjectUser(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ierrors.NewForbidden(ErrNotProjectMember, "")
+	}
+
+	if !HasProjectPermission(user.Role, permission) {
+		return ierrors.NewForbidden(ErrNoProjectPermission, string(permission))
+	}
+
+	return nil
+}
+
+// CheckProjectUserManagement checks that actor can add, change role of or delete the project user.
+// role is the new role of the user, it is empty on deleting.
+func (s *ProjectAccessService) CheckProjectUserManagement(
+	ctx context.Context, projectID, actorID, userID uint64, role models.ProjectRole,
+) error {
+	permission := models.ProjectPermissionManageMembers
+
+	if role == models.ProjectRoleAdmin {
+		permission = models.ProjectPermissionManageAdmins
+	} else {
+		user, err := s.repo.Project.GetProjectUser(ctx, projectID, userID)
+		if err != nil {
+			return err
+		}
+
+		if user != nil && user.Role != models.ProjectRoleMember && user.Role != models.ProjectRoleViewer {
+			permission = models.ProjectPermissionManageAdmins
+		}
+	}
+
+	return s.CheckProjectPermission(ctx, projectID, actorID, permission)
+}
+
+// GetProjectIDByBoardEntities returns id of the project which all given progress statuses and tasks belong to.
+func (s *ProjectAccessService) GetProjectIDByBoardEntities(
+	ctx context.Context, progressStatusIDs []int64, taskIDs []uint64,
+) (uint64, error) {
+	projectIDs, err := s.repo.ProjectBoard.GetProjectIDsByBoardEntities(ctx, progressStatusIDs, taskIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(projectIDs) {
+	case 0:
+		return 0, ierrors.NewBusiness(ErrProjectEntitiesNotFound, "")
+	case 1:
+		return projectIDs[0], nil
+	default:
+		return 0, ierrors.NewBusiness(ErrProjectEntitiesMismatch, "")
+	}
+}
+
+// CheckTaskProjectEntities checks that assignee and statuses of the task belong to the task project.
+func (s *ProjectAccessService) CheckTaskProjectEntities(
+	ctx context.Context, projectID, assigneeID uint64, importanceStatusID, progressStatusID int64,
+) error {
+	assignee, err := s.repo.Project.GetProjectUser(ctx, projectID, assigneeID)
+	if err != nil {
+		return err
+	}
+
+	if assignee == nil {
+		return ierrors.NewBusiness(ErrAssigneeNotProjectMember, "")
+	}
+
+	importanceStatus, err := s.repo.ImportanceStatus.GetByID(ctx, importanceStatusID)
+	if err != nil {
+		return err
+	}
+
+	if importanceStatus == nil || importanceStatus.ProjectID != projectID {
+		return ierrors.NewBusiness(ErrImportanceStatusNotFound, "")
+	}
+
+	progressStatus, err := s.repo.ProgressStatus.GetByID(ctx, progressStatusID)
+	if err != nil {
+		return err
+	}
+
+	if progressStatus == nil || progressStatus.ProjectID != projectID {
+		return ierrors.NewBusiness(ErrProgressStatusNotFound, "")
+	}
+
+	return nil
+}
+
+// HasProjectPermission checks permission of the role by the permission matrix.
+func HasProjectPermission(role models.ProjectRole, permission models.ProjectPermission) bool {
+	for _, p := range projectRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
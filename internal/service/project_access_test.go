@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+// fakeProjectRepo keeps roles of project members in memory. Not implemented methods panic.
+type fakeProjectRepo struct {
+	repository.Project
+	// roles are roles of users by project id and user id
+	roles map[uint64]map[uint64]models.ProjectRole
+}
+
+func (r *fakeProjectRepo) GetProjectUser(_ context.Context, projectID, userID uint64) (*models.ProjectUser, error) {
+	role, ok := r.roles[projectID][userID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &models.ProjectUser{ID: userID, Role: role}, nil
+}
+
+const (
+	testProjectID  = 1
+	testOwnerID    = 1
+	testAdminID    = 2
+	testMemberID   = 3
+	testViewerID   = 4
+	testOutsiderID = 5
+)
+
+// errorCause returns error wrapped by custom error.
+func errorCause(err error) error {
+	if customErr, ok := err.(*ierrors.Error); ok {
+		return customErr.Err
+	}
+
+	return err
+}
+
+func newTestProjectAccessService() *ProjectAccessService {
+	return NewProjectAccessService(&repository.Repository{
+		Project: &fakeProjectRepo{roles: map[uint64]map[uint64]models.ProjectRole{
+			testProjectID: {
+				testOwnerID:  models.ProjectRoleOwner,
+				testAdminID:  models.ProjectRoleAdmin,
+				testMemberID: models.ProjectRoleMember,
+				testViewerID: models.ProjectRoleViewer,
+			},
+		}},
+	})
+}
+
+func TestHasProjectPermission(t *testing.T) {
+	tests := []struct {
+		permission models.ProjectPermission
+		// wantRoles are roles having the permission
+		wantRoles []models.ProjectRole
+	}{
+		{
+			permission: models.ProjectPermissionRead,
+			wantRoles: []models.ProjectRole{
+				models.ProjectRoleViewer, models.ProjectRoleMember, models.ProjectRoleAdmin, models.ProjectRoleOwner,
+			},
+		},
+		{
+			permission: models.ProjectPermissionUpdateTask,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleMember, models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionUpdateBoardOrder,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleMember, models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionCreateStatus,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionDeleteAnyComment,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionManageMembers,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionManageAdmins,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionDeleteProject,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleOwner},
+		},
+		{
+			permission: models.ProjectPermissionTransferOwnership,
+			wantRoles:  []models.ProjectRole{models.ProjectRoleOwner},
+		},
+	}
+
+	allRoles := []models.ProjectRole{
+		models.ProjectRoleViewer, models.ProjectRoleMember, models.ProjectRoleAdmin, models.ProjectRoleOwner, "unknown",
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.permission), func(t *testing.T) {
+			for _, role := range allRoles {
+				want := false
+				for _, wantRole := range tt.wantRoles {
+					want = want || role == wantRole
+				}
+
+				if got := HasProjectPermission(role, tt.permission); got != want {
+					t.Errorf("HasProjectPermission(%s) = %v, want %v", role, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHasProjectPermission_HigherRolesHaveAllPermissionsOfLowerRoles(t *testing.T) {
+	roles := []models.ProjectRole{
+		models.ProjectRoleViewer, models.ProjectRoleMember, models.ProjectRoleAdmin, models.ProjectRoleOwner,
+	}
+
+	for i := 1; i < len(roles); i++ {
+		for _, permission := range projectRolePermissions[roles[i-1]] {
+			if !HasProjectPermission(roles[i], permission) {
+				t.Errorf("%s does not have permission %s of %s", roles[i], permission, roles[i-1])
+			}
+		}
+	}
+}
+
+func TestProjectAccessService_CheckProjectPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint64
+		permission models.ProjectPermission
+		wantErr    error
+	}{
+		{
+			name:       "viewer reads",
+			userID:     testViewerID,
+			permission: models.ProjectPermissionRead,
+		},
+		{
+			name:       "viewer updates task",
+			userID:     testViewerID,
+			permission: models.ProjectPermissionUpdateTask,
+			wantErr:    ErrNoProjectPermission,
+		},
+		{
+			name:       "member updates task",
+			userID:     testMemberID,
+			permission: models.ProjectPermissionUpdateTask,
+		},
+		{
+			name:       "admin deletes project",
+			userID:     testAdminID,
+			permission: models.ProjectPermissionDeleteProject,
+			wantErr:    ErrNoProjectPermission,
+		},
+		{
+			name:       "owner deletes project",
+			userID:     testOwnerID,
+			permission: models.ProjectPermissionDeleteProject,
+		},
+		{
+			name:       "not member reads",
+			userID:     testOutsiderID,
+			permission: models.ProjectPermissionRead,
+			wantErr:    ErrNotProjectMember,
+		},
+	}
+
+	s := newTestProjectAccessService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckProjectPermission(context.Background(), testProjectID, tt.userID, tt.permission)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Errorf("CheckProjectPermission() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProjectAccessService_CheckProjectUserManagement(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID uint64
+		userID  uint64
+		role    models.ProjectRole
+		wantErr error
+	}{
+		{
+			name:    "admin adds member",
+			actorID: testAdminID,
+			userID:  testOutsiderID,
+			role:    models.ProjectRoleMember,
+		},
+		{
+			name:    "admin makes member viewer",
+			actorID: testAdminID,
+			userID:  testMemberID,
+			role:    models.ProjectRoleViewer,
+		},
+		{
+			name:    "admin makes member admin",
+			actorID: testAdminID,
+			userID:  testMemberID,
+			role:    models.ProjectRoleAdmin,
+			wantErr: ErrNoProjectPermission,
+		},
+		{
+			name:    "admin deletes owner",
+			actorID: testAdminID,
+			userID:  testOwnerID,
+			wantErr: ErrNoProjectPermission,
+		},
+		{
+			name:    "admin demotes admin",
+			actorID: testAdminID,
+			userID:  testAdminID,
+			role:    models.ProjectRoleMember,
+			wantErr: ErrNoProjectPermission,
+		},
+		{
+			name:    "owner makes member admin",
+			actorID: testOwnerID,
+			userID:  testMemberID,
+			role:    models.ProjectRoleAdmin,
+		},
+		{
+			name:    "owner deletes admin",
+			actorID: testOwnerID,
+			userID:  testAdminID,
+		},
+		{
+			name:    "member adds member",
+			actorID: testMemberID,
+			userID:  testOutsiderID,
+			role:    models.ProjectRoleMember,
+			wantErr: ErrNoProjectPermission,
+		},
+	}
+
+	s := newTestProjectAccessService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckProjectUserManagement(context.Background(), testProjectID, tt.actorID, tt.userID, tt.role)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Errorf("CheckProjectUserManagement() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProjectAccessService_CheckProjectPermission_NotMemberIsForbidden(t *testing.T) {
+	err := newTestProjectAccessService().CheckProjectPermission(
+		context.Background(), testProjectID, testOutsiderID, models.ProjectPermissionRead,
+	)
+
+	customErr, ok := err.(*ierrors.Error)
+	if !ok || customErr.Level != ierrors.Forbidden {
+		t.Errorf("CheckProjectPermission() error = %#v, want forbidden error", err)
+	}
+}
+
+// fakeProjectBoardRepo keeps project ids of board entities in memory. Not implemented methods panic.
+type fakeProjectBoardRepo struct {
+	repository.ProjectBoard
+	statusProjectIDs map[int64]uint64
+	taskProjectIDs   map[uint64]uint64
+}
+
+func (r *fakeProjectBoardRepo) GetProjectIDsByBoardEntities(
+	_ context.Context, progressStatusIDs []int64, taskIDs []uint64,
+) ([]uint64, error) {
+	var projectIDs []uint64
+	addProjectID := func(projectID uint64, ok bool) {
+		if !ok {
+			return
+		}
+
+		for _, id := range projectIDs {
+			if id == projectID {
+				return
+			}
+		}
+
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	for _, id := range progressStatusIDs {
+		projectID, ok := r.statusProjectIDs[id]
+		addProjectID(projectID, ok)
+	}
+
+	for _, id := range taskIDs {
+		projectID, ok := r.taskProjectIDs[id]
+		addProjectID(projectID, ok)
+	}
+
+	return projectIDs, nil
+}
+
+func TestProjectAccessService_GetProjectIDByBoardEntities(t *testing.T) {
+	tests := []struct {
+		name              string
+		progressStatusIDs []int64
+		taskIDs           []uint64
+		wantProjectID     uint64
+		wantErr           error
+	}{
+		{
+			name:              "entities of one project",
+			progressStatusIDs: []int64{testStatusToDo, testStatusDone},
+			taskIDs:           []uint64{testTaskToDo},
+			wantProjectID:     testProjectID,
+		},
+		{
+			name:              "status of other project",
+			progressStatusIDs: []int64{testStatusToDo, testStatusOther},
+			wantErr:           ErrProjectEntitiesMismatch,
+		},
+		{
+			name:              "task of other project",
+			progressStatusIDs: []int64{testStatusToDo},
+			taskIDs:           []uint64{testTaskToDo, testTaskInProgress},
+			wantErr:           ErrProjectEntitiesMismatch,
+		},
+		{
+			name:    "not existing entities",
+			taskIDs: []uint64{100},
+			wantErr: ErrProjectEntitiesNotFound,
+		},
+	}
+
+	s := NewProjectAccessService(&repository.Repository{
+		ProjectBoard: &fakeProjectBoardRepo{
+			statusProjectIDs: map[int64]uint64{
+				testStatusToDo:  testProjectID,
+				testStatusDone:  testProjectID,
+				testStatusOther: testProjectWithoutTransitionsID,
+			},
+			taskProjectIDs: map[uint64]uint64{
+				testTaskToDo:       testProjectID,
+				testTaskInProgress: testProjectWithoutTransitionsID,
+			},
+		},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectID, err := s.GetProjectIDByBoardEntities(context.Background(), tt.progressStatusIDs, tt.taskIDs)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("GetProjectIDByBoardEntities() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if customErr, ok := err.(*ierrors.Error); !ok || customErr.Level != ierrors.Business {
+					t.Errorf("GetProjectIDByBoardEntities() error = %#v, want business error", err)
+				}
+			}
+
+			if projectID != tt.wantProjectID {
+				t.Errorf("GetProjectIDByBoardEntities() = %d, want %d", projectID, tt.wantProjectID)
+			}
+		})
+	}
+}
+
+type fakeImportanceStatusRepo struct {
+	repository.ImportanceStatus
+	statuses []models.ImportanceStatus
+}
+
+func (r *fakeImportanceStatusRepo) GetByID(_ context.Context, id int64) (*models.ImportanceStatus, error) {
+	for _, status := range r.statuses {
+		if status.ID == id {
+			return &status, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func TestProjectAccessService_CheckTaskProjectEntities(t *testing.T) {
+	const testImportanceHigh, testImportanceOther = 1, 2
+
+	tests := []struct {
+		name               string
+		assigneeID         uint64
+		importanceStatusID int64
+		progressStatusID   int64
+		wantErr            error
+	}{
+		{
+			name:               "entities of the project",
+			assigneeID:         testMemberID,
+			importanceStatusID: testImportanceHigh,
+			progressStatusID:   testStatusToDo,
+		},
+		{
+			name:               "assignee is member of other project",
+			assigneeID:         testOutsiderID,
+			importanceStatusID: testImportanceHigh,
+			progressStatusID:   testStatusToDo,
+			wantErr:            ErrAssigneeNotProjectMember,
+		},
+		{
+			name:               "importance status of other project",
+			assigneeID:         testMemberID,
+			importanceStatusID: testImportanceOther,
+			progressStatusID:   testStatusToDo,
+			wantErr:            ErrImportanceStatusNotFound,
+		},
+		{
+			name:               "progress status of other project",
+			assigneeID:         testMemberID,
+			importanceStatusID: testImportanceHigh,
+			progressStatusID:   testStatusOther,
+			wantErr:            ErrProgressStatusNotFound,
+		},
+		{
+			name:               "not existing progress status",
+			assigneeID:         testMemberID,
+			importanceStatusID: testImportanceHigh,
+			progressStatusID:   100,
+			wantErr:            ErrProgressStatusNotFound,
+		},
+	}
+
+	s := NewProjectAccessService(&repository.Repository{
+		Project: &fakeProjectRepo{roles: map[uint64]map[uint64]models.ProjectRole{
+			testProjectID:                   {testMemberID: models.ProjectRoleMember},
+			testProjectWithoutTransitionsID: {testOutsiderID: models.ProjectRoleMember},
+		}},
+		ImportanceStatus: &fakeImportanceStatusRepo{statuses: []models.ImportanceStatus{
+			{ID: testImportanceHigh, ProjectID: testProjectID, Name: "HIGH"},
+			{ID: testImportanceOther, ProjectID: testProjectWithoutTransitionsID, Name: "HIGH"},
+		}},
+		ProgressStatus: &fakeProgressStatusRepo{statuses: []models.ProgressStatus{
+			{ID: testStatusToDo, ProjectID: testProjectID, Name: "TO DO"},
+			{ID: testStatusOther, ProjectID: testProjectWithoutTransitionsID, Name: "TO DO"},
+		}},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckTaskProjectEntities(
+				context.Background(), testProjectID, tt.assigneeID, tt.importanceStatusID, tt.progressStatusID,
+			)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Errorf("CheckTaskProjectEntities() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,33 +10,57 @@ import (
 )
 
 type ProjectBoardService struct {
-	repo repository.ProjectBoard
+	repo             repository.ProjectBoard
+	statusTransition StatusTransition
 }
 
-func NewProjectBoardService(repo repository.ProjectBoard) *ProjectBoardService {
-	return &ProjectBoardService{repo: repo}
+func NewProjectBoardService(repo repository.ProjectBoard, statusTransition StatusTransition) *ProjectBoardService {
+	return &ProjectBoardService{
+		repo:             repo,
+		statusTransition: statusTransition,
+	}
 }
 
-func (s *ProjectBoardService) GetProjectBoardBytes(ctx context.Context, projectID uint64) (jsonData []byte, err error) {
-	return s.repo.GetProjectBoardBytes(ctx, projectID)
+func (s *ProjectBoardService) GetProjectBoardBytes(
+	ctx context.Context, projectID uint64, sprintID *uint64,
+) (jsonData []byte, version uint64, err error) {
+	return s.repo.GetProjectBoardBytes(ctx, projectID, sprintID)
 }
 
 func (s *ProjectBoardService) GetProjectBoard(ctx context.Context, projectID uint64) (*models.ProjectBoard, error) {
 	return s.repo.GetProjectBoard(ctx, projectID)
 }
 
-func (s *ProjectBoardService) UpdateProjectBoardParts(ctx context.Context, board models.ProjectBoard) error {
+func (s *ProjectBoardService) UpdateProjectBoardParts(
+	ctx context.Context, projectID uint64, board models.ProjectBoard, version *uint64, actorID uint64,
+) (uint64, error) {
 	if len(board) != 2 {
-		return ierrors.NewBusiness(ErrWrongProjectBoardPartsNum, "")
+		return 0, ierrors.NewBusiness(ErrWrongProjectBoardPartsNum, "")
+	}
+
+	statusIDs := make(map[uint64]int64)
+	for _, part := range board {
+		for _, task := range part.Tasks {
+			statusIDs[task.TaskID] = part.ProgressStatusId
+		}
+	}
+
+	if err := s.statusTransition.CheckTaskTransitions(ctx, projectID, actorID, statusIDs); err != nil {
+		return 0, err
 	}
 
-	return s.repo.UpdateProjectBoardParts(ctx, board)
+	return s.repo.UpdateProjectBoardParts(ctx, projectID, board, version, actorID)
 }
 
-func (s *ProjectBoardService) UpdateProjectBoardProgressStatuses(ctx context.Context, statuses models.ProjectBoardProgressStatuses) error {
-	return s.repo.UpdateProjectBoardProgressStatuses(ctx, statuses)
+func (s *ProjectBoardService) UpdateProjectBoardProgressStatuses(
+	ctx context.Context, projectID uint64, statuses models.ProjectBoardProgressStatuses, version *uint64,
+) (uint64, error) {
+	return s.repo.UpdateProjectBoardProgressStatuses(ctx, projectID, statuses, version)
 }
 
-func (s *ProjectBoardService) UpdateProjectBoardProgressStatusTasks(ctx context.Context, tasks models.ProjectBoardProgressStatusTasks) error {
-	return s.repo.UpdateProjectBoardProgressStatusTasks(ctx, tasks)
+func (s *ProjectBoardService) UpdateProjectBoardProgressStatusTasks(
+	ctx context.Context, projectID uint64, tasks models.ProjectBoardProgressStatusTasks, version *uint64,
+	actorID uint64,
+) (uint64, error) {
+	return s.repo.UpdateProjectBoardProgressStatusTasks(ctx, projectID, tasks, version, actorID)
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+const (
+	reportDefaultDays = 90
+	reportMaxDays     = 366
+)
+
+var ErrNotValidReportPeriod = errors.New("not valid report period")
+
+type ReportService struct {
+	repo *repository.Repository
+}
+
+func NewReportService(repo *repository.Repository) *ReportService {
+	return &ReportService{repo: repo}
+}
+
+// GetCumulativeFlow returns numbers of project tasks in each progress status at the end of each day of the period.
+// Statuses are sorted as on the board.
+func (s *ReportService) GetCumulativeFlow(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) (*models.CumulativeFlowReport, error) {
+	period, err := normalizeReportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.repo.ProgressStatus.GetAllToProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].OrderNum < statuses[j].OrderNum
+	})
+
+	counts, err := s.repo.Report.GetCumulativeFlow(ctx, projectID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	statusIndexes := make(map[int64]int, len(statuses))
+	for i, status := range statuses {
+		statusIndexes[status.ID] = i
+	}
+
+	dayCounts := make(map[string][]int64)
+	for _, count := range counts {
+		// tasks in deleted statuses are not shown
+		i, ok := statusIndexes[count.ProgressStatusID]
+		if !ok {
+			continue
+		}
+
+		day := count.Date.String()
+		if dayCounts[day] == nil {
+			dayCounts[day] = make([]int64, len(statuses))
+		}
+
+		dayCounts[day][i] = count.Count
+	}
+
+	report := &models.CumulativeFlowReport{
+		ReportPeriod: period,
+		Statuses:     statuses,
+	}
+	if report.Statuses == nil {
+		report.Statuses = []models.ProgressStatus{}
+	}
+
+	for day := period.From; !day.After(period.To.Time); day = models.NewDate(day.AddDate(0, 0, 1)) {
+		dayReport := models.CumulativeFlowDay{
+			Date:   day,
+			Counts: dayCounts[day.String()],
+		}
+		if dayReport.Counts == nil {
+			dayReport.Counts = make([]int64, len(statuses))
+		}
+
+		report.Days = append(report.Days, dayReport)
+	}
+
+	return report, nil
+}
+
+// GetLeadCycleTime returns lead and cycle time percentiles of project tasks completed in the period.
+func (s *ReportService) GetLeadCycleTime(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) (*models.LeadCycleTimeReport, error) {
+	period, err := normalizeReportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Report.GetLeadCycleTime(ctx, projectID, period)
+}
+
+// GetThroughput returns numbers of project tasks completed in the period by weeks.
+func (s *ReportService) GetThroughput(
+	ctx context.Context, projectID uint64, period models.ReportPeriod,
+) (*models.ThroughputReport, error) {
+	period, err := normalizeReportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	weeks, err := s.repo.Report.GetThroughput(ctx, projectID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if weeks == nil {
+		weeks = []models.ThroughputWeek{}
+	}
+
+	return &models.ThroughputReport{
+		ReportPeriod: period,
+		Weeks:        weeks,
+	}, nil
+}
+
+// normalizeReportPeriod validates report period. Period ends today and lasts 90 days by default.
+func normalizeReportPeriod(period models.ReportPeriod) (models.ReportPeriod, error) {
+	if period.To.IsZero() {
+		period.To = models.NewDate(time.Now().UTC())
+	}
+
+	if period.From.IsZero() {
+		period.From = models.NewDate(period.To.AddDate(0, 0, -(reportDefaultDays - 1)))
+	}
+
+	if period.From.After(period.To.Time) {
+		return models.ReportPeriod{}, ierrors.NewBusiness(ErrNotValidReportPeriod, "from should not be after to")
+	}
+
+	if period.To.Sub(period.From.Time) >= reportMaxDays*24*time.Hour {
+		return models.ReportPeriod{}, ierrors.NewBusiness(
+			ErrNotValidReportPeriod, fmt.Sprintf("period should not be longer than %d days", reportMaxDays),
+		)
+	}
+
+	return period, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+type fakeReportRepo struct {
+	repository.Report
+	counts []models.CumulativeFlowCount
+	// period is the period passed to repository
+	period models.ReportPeriod
+}
+
+func (r *fakeReportRepo) GetCumulativeFlow(
+	_ context.Context, _ uint64, period models.ReportPeriod,
+) ([]models.CumulativeFlowCount, error) {
+	r.period = period
+
+	return r.counts, nil
+}
+
+func (r *fakeReportRepo) GetThroughput(
+	_ context.Context, _ uint64, period models.ReportPeriod,
+) ([]models.ThroughputWeek, error) {
+	r.period = period
+
+	return nil, nil
+}
+
+func testDate(t *testing.T, s string) models.Date {
+	t.Helper()
+
+	date, err := models.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return date
+}
+
+func TestReportService_GetCumulativeFlow(t *testing.T) {
+	const deletedStatusID = 100
+
+	reportRepo := &fakeReportRepo{counts: []models.CumulativeFlowCount{
+		{Date: testDate(t, "2026-10-01"), ProgressStatusID: testStatusToDo, Count: 3},
+		{Date: testDate(t, "2026-10-01"), ProgressStatusID: deletedStatusID, Count: 7},
+		{Date: testDate(t, "2026-10-03"), ProgressStatusID: testStatusToDo, Count: 1},
+		{Date: testDate(t, "2026-10-03"), ProgressStatusID: testStatusDone, Count: 2},
+	}}
+	s := NewReportService(&repository.Repository{
+		// statuses are returned not in board order
+		ProgressStatus: &fakeProgressStatusRepo{statuses: []models.ProgressStatus{
+			{ID: testStatusDone, ProjectID: testProjectID, Name: "DONE", OrderNum: 2},
+			{ID: testStatusToDo, ProjectID: testProjectID, Name: "TO DO", OrderNum: 0},
+			{ID: testStatusInProgress, ProjectID: testProjectID, Name: "IN PROGRESS", OrderNum: 1},
+		}},
+		Report: reportRepo,
+	})
+
+	report, err := s.GetCumulativeFlow(context.Background(), testProjectID, models.ReportPeriod{
+		From: testDate(t, "2026-10-01"),
+		To:   testDate(t, "2026-10-03"),
+	})
+	if err != nil {
+		t.Fatalf("GetCumulativeFlow() error = %v", err)
+	}
+
+	var statusIDs []int64
+	for _, status := range report.Statuses {
+		statusIDs = append(statusIDs, status.ID)
+	}
+
+	if want := []int64{testStatusToDo, testStatusInProgress, testStatusDone}; !reflect.DeepEqual(statusIDs, want) {
+		t.Errorf("status ids = %v, want %v", statusIDs, want)
+	}
+
+	// every day of period is in report, tasks in deleted statuses are not counted
+	wantDays := []models.CumulativeFlowDay{
+		{Date: testDate(t, "2026-10-01"), Counts: []int64{3, 0, 0}},
+		{Date: testDate(t, "2026-10-02"), Counts: []int64{0, 0, 0}},
+		{Date: testDate(t, "2026-10-03"), Counts: []int64{1, 0, 2}},
+	}
+	if !reflect.DeepEqual(report.Days, wantDays) {
+		t.Errorf("days = %+v, want %+v", report.Days, wantDays)
+	}
+}
+
+func TestReportService_GetThroughput_Period(t *testing.T) {
+	today := models.NewDate(time.Now().UTC())
+
+	tests := []struct {
+		name       string
+		period     models.ReportPeriod
+		wantPeriod models.ReportPeriod
+		wantErr    error
+	}{
+		{
+			name:       "default period",
+			wantPeriod: models.ReportPeriod{From: models.NewDate(today.AddDate(0, 0, -89)), To: today},
+		},
+		{
+			name:   "default from",
+			period: models.ReportPeriod{To: testDate(t, "2026-03-31")},
+			wantPeriod: models.ReportPeriod{
+				From: testDate(t, "2026-01-01"),
+				To:   testDate(t, "2026-03-31"),
+			},
+		},
+		{
+			name: "one day",
+			period: models.ReportPeriod{
+				From: testDate(t, "2026-01-01"),
+				To:   testDate(t, "2026-01-01"),
+			},
+			wantPeriod: models.ReportPeriod{
+				From: testDate(t, "2026-01-01"),
+				To:   testDate(t, "2026-01-01"),
+			},
+		},
+		{
+			name: "longest period",
+			period: models.ReportPeriod{
+				From: testDate(t, "2025-01-01"),
+				To:   testDate(t, "2026-01-01"),
+			},
+			wantPeriod: models.ReportPeriod{
+				From: testDate(t, "2025-01-01"),
+				To:   testDate(t, "2026-01-01"),
+			},
+		},
+		{
+			name: "from after to",
+			period: models.ReportPeriod{
+				From: testDate(t, "2026-01-02"),
+				To:   testDate(t, "2026-01-01"),
+			},
+			wantErr: ErrNotValidReportPeriod,
+		},
+		{
+			name: "too long period",
+			period: models.ReportPeriod{
+				From: testDate(t, "2025-01-01"),
+				To:   testDate(t, "2026-01-02"),
+			},
+			wantErr: ErrNotValidReportPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportRepo := &fakeReportRepo{}
+			s := NewReportService(&repository.Repository{Report: reportRepo})
+
+			report, err := s.GetThroughput(context.Background(), testProjectID, tt.period)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("GetThroughput() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if report.ReportPeriod != tt.wantPeriod || reportRepo.period != tt.wantPeriod {
+				t.Errorf("period = %v, period of repository = %v, want %v",
+					report.ReportPeriod, reportRepo.period, tt.wantPeriod)
+			}
+
+			if report.Weeks == nil {
+				t.Error("weeks are nil, want empty")
+			}
+		})
+	}
+}
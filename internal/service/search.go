@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+const (
+	searchDefaultLimit   = 20
+	searchMaxLimit       = 100
+	searchQueryMaxLength = 200
+)
+
+var (
+	ErrEmptySearchQuery   = errors.New("empty search query")
+	ErrTooLongSearchQuery = errors.New("too long search query")
+)
+
+type SearchService struct {
+	repo repository.Search
+}
+
+func NewSearchService(repo repository.Search) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search searches tasks, comments and projects in the projects where user is a member.
+func (s *SearchService) Search(
+	ctx context.Context, userID uint64, params models.SearchParams,
+) (*models.SearchResults, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return nil, ierrors.NewBusiness(ErrEmptySearchQuery, "")
+	}
+
+	if utf8.RuneCountInString(params.Query) > searchQueryMaxLength {
+		return nil, ierrors.NewBusiness(ErrTooLongSearchQuery, "")
+	}
+
+	params.Limit = normalizeSearchLimit(params.Limit)
+
+	tasks, err := s.repo.SearchTasks(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.SearchComments(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.repo.SearchProjects(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &models.SearchResults{
+		Tasks:    tasks,
+		Comments: comments,
+		Projects: projects,
+	}
+
+	if results.Tasks == nil {
+		results.Tasks = []models.TaskSearchResult{}
+	}
+
+	if results.Comments == nil {
+		results.Comments = []models.CommentSearchResult{}
+	}
+
+	if results.Projects == nil {
+		results.Projects = []models.ProjectSearchResult{}
+	}
+
+	return results, nil
+}
+
+func normalizeSearchLimit(limit int) int {
+	if limit <= 0 {
+		return searchDefaultLimit
+	}
+
+	if limit > searchMaxLimit {
+		return searchMaxLimit
+	}
+
+	return limit
+}
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/l-orlov/task-tracker/internal/config"
 	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/pkg/blobstore"
 	"github.com/l-orlov/task-tracker/pkg/mailer"
+	"github.com/l-orlov/task-tracker/pkg/webhook"
 	"github.com/pkg/errors"
 	"github.com/sethvargo/go-password/password"
 	"github.com/sirupsen/logrus"
@@ -25,34 +29,65 @@ type (
 	}
 	User interface {
 		CreateUser(ctx context.Context, user models.UserToCreate) (uint64, error)
+		CreateInvitedUser(
+			ctx context.Context, user models.UserToCreate, invitation models.ProjectInvitation,
+		) (uint64, error)
 		GetUserByID(ctx context.Context, id uint64) (*models.User, error)
 		GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 		UpdateUser(ctx context.Context, user models.User) error
 		SetUserPassword(ctx context.Context, userID uint64, password string) error
 		ChangeUserPassword(ctx context.Context, userID uint64, oldPassword, newPassword string) error
-		GetAllUsers(ctx context.Context) ([]models.User, error)
-		GetAllUsersWithParameters(ctx context.Context, params models.UserParams) ([]models.User, error)
+		GetAllUsers(ctx context.Context, pageParams models.PageParams) (*models.UserPage, error)
+		GetAllUsersWithParameters(
+			ctx context.Context, params models.UserParams, pageParams models.PageParams,
+		) (*models.UserPage, error)
 		DeleteUser(ctx context.Context, id uint64) error
 		ConfirmEmail(ctx context.Context, id uint64) error
+		CheckEmailIsFree(ctx context.Context, email string) error
+		ChangeUserEmail(ctx context.Context, change models.EmailChange) error
+		SetUserAdmin(ctx context.Context, id uint64, isAdmin bool) error
+		SetUserDisabled(ctx context.Context, id uint64, isDisabled bool) error
+		BootstrapAdmin(ctx context.Context) error
+	}
+	Avatar interface {
+		UploadUserAvatar(ctx context.Context, userID uint64, content io.Reader) (string, error)
+		GetUserAvatar(ctx context.Context, user models.User, size int) (*models.UserAvatar, error)
+		DeleteUserAvatar(ctx context.Context, userID uint64) error
 	}
 	Project interface {
 		CreateProject(ctx context.Context, project models.ProjectToCreate, owner uint64) (uint64, error)
 		GetProjectByID(ctx context.Context, id uint64) (*models.Project, error)
+		GetProjectTaskCounts(ctx context.Context, projectID uint64) (*models.ProjectTaskCounts, error)
 		UpdateProject(ctx context.Context, project models.Project) error
-		GetAllProjects(ctx context.Context) ([]models.Project, error)
-		GetAllProjectsToUser(ctx context.Context, userID uint64) ([]models.Project, error)
-		GetAllProjectsWithParameters(ctx context.Context, params models.ProjectParams) ([]models.Project, error)
+		GetAllProjects(ctx context.Context, pageParams models.PageParams) (*models.ProjectPage, error)
+		GetAllProjectsToUser(ctx context.Context, userID uint64, pageParams models.PageParams) (*models.ProjectPage, error)
+		GetAllProjectsWithParameters(
+			ctx context.Context, params models.ProjectParams, pageParams models.PageParams,
+		) (*models.ProjectPage, error)
 		DeleteProject(ctx context.Context, id uint64) error
-		AddUserToProject(ctx context.Context, projectID, userID uint64) error
+		AddUserToProject(ctx context.Context, projectID, userID uint64, role models.ProjectRole) error
 		GetAllProjectUsers(ctx context.Context, projectID uint64) ([]models.ProjectUser, error)
+		GetProjectUser(ctx context.Context, projectID, userID uint64) (*models.ProjectUser, error)
+		UpdateProjectUserRole(ctx context.Context, projectID, userID uint64, role models.ProjectRole) error
+		TransferProjectOwnership(ctx context.Context, projectID, userID uint64) error
+		ReassignProjectOwnership(ctx context.Context, projectID, userID uint64) error
 		DeleteUserFromProject(ctx context.Context, projectID, userID uint64) error
 	}
 	ProjectBoard interface {
-		GetProjectBoardBytes(ctx context.Context, projectID uint64) (jsonData []byte, err error)
+		GetProjectBoardBytes(
+			ctx context.Context, projectID uint64, sprintID *uint64,
+		) (jsonData []byte, version uint64, err error)
 		GetProjectBoard(ctx context.Context, projectID uint64) (*models.ProjectBoard, error)
-		UpdateProjectBoardParts(ctx context.Context, board models.ProjectBoard) error
-		UpdateProjectBoardProgressStatuses(ctx context.Context, statuses models.ProjectBoardProgressStatuses) error
-		UpdateProjectBoardProgressStatusTasks(ctx context.Context, tasks models.ProjectBoardProgressStatusTasks) error
+		UpdateProjectBoardParts(
+			ctx context.Context, projectID uint64, board models.ProjectBoard, version *uint64, actorID uint64,
+		) (uint64, error)
+		UpdateProjectBoardProgressStatuses(
+			ctx context.Context, projectID uint64, statuses models.ProjectBoardProgressStatuses, version *uint64,
+		) (uint64, error)
+		UpdateProjectBoardProgressStatusTasks(
+			ctx context.Context, projectID uint64, tasks models.ProjectBoardProgressStatusTasks, version *uint64,
+			actorID uint64,
+		) (uint64, error)
 	}
 	ImportanceStatus interface {
 		Create(ctx context.Context, status models.ImportanceStatusToCreate) (int64, error)
@@ -70,14 +105,150 @@ type (
 		GetAllToProject(ctx context.Context, projectID uint64) ([]models.ProgressStatus, error)
 		Delete(ctx context.Context, id int64) error
 	}
+	StatusTransition interface {
+		Create(ctx context.Context, transition models.StatusTransitionToCreate) (int64, error)
+		GetByID(ctx context.Context, id int64) (*models.StatusTransition, error)
+		Update(ctx context.Context, transition models.StatusTransitionToUpdate) error
+		GetAllToProject(ctx context.Context, projectID uint64) ([]models.StatusTransition, error)
+		Delete(ctx context.Context, id int64) error
+		CheckTaskTransitions(ctx context.Context, projectID, actorID uint64, statusIDs map[uint64]int64) error
+	}
+	Label interface {
+		Create(ctx context.Context, label models.LabelToCreate) (int64, error)
+		GetByID(ctx context.Context, id int64) (*models.Label, error)
+		Update(ctx context.Context, label models.Label) error
+		GetAllToProject(ctx context.Context, projectID uint64) ([]models.Label, error)
+		Delete(ctx context.Context, id int64) error
+		GetAllToTask(ctx context.Context, taskID uint64) ([]models.Label, error)
+		AddToTask(ctx context.Context, taskID uint64, labelID int64) error
+		DeleteFromTask(ctx context.Context, taskID uint64, labelID int64) error
+	}
 	Task interface {
 		CreateTaskToProject(ctx context.Context, task models.TaskToCreate) (uint64, error)
 		GetTaskByID(ctx context.Context, id uint64) (*models.Task, error)
-		UpdateTask(ctx context.Context, task models.Task) error
-		GetAllTasksToProject(ctx context.Context, id uint64) ([]models.Task, error)
-		GetAllTasksWithParameters(ctx context.Context, params models.TaskParams) ([]models.Task, error)
-		GetAllTasks(ctx context.Context) ([]models.Task, error)
-		DeleteTask(ctx context.Context, id uint64) error
+		UpdateTask(ctx context.Context, task models.Task, actorID uint64) (uint64, error)
+		GetAllTasksToProject(ctx context.Context, id uint64, pageParams models.PageParams) (*models.TaskPage, error)
+		GetAllTasksWithParameters(
+			ctx context.Context, params models.TaskParams, pageParams models.PageParams,
+		) (*models.TaskPage, error)
+		GetOverdueTasksToUser(ctx context.Context, userID uint64) ([]models.Task, error)
+		GetTasksByFilter(
+			ctx context.Context, projectID, userID uint64, query string, pageParams models.PageParams,
+		) (*models.TaskPage, error)
+		GetAllTasks(ctx context.Context, pageParams models.PageParams) (*models.TaskPage, error)
+		GetTaskTree(ctx context.Context, id uint64) (*models.TaskTree, error)
+		DeleteTask(ctx context.Context, id uint64, policy models.SubtasksPolicy) ([]uint64, error)
+	}
+	TaskLink interface {
+		CreateTaskLink(ctx context.Context, link models.TaskLinkToCreate) (uint64, error)
+		GetTaskLinkByID(ctx context.Context, id uint64) (*models.TaskLink, error)
+		GetAllTaskLinks(ctx context.Context, taskID uint64) ([]models.TaskLink, error)
+		DeleteTaskLink(ctx context.Context, id uint64) error
+		GetBlockedProjectTasks(ctx context.Context, projectID uint64) ([]models.BlockedTask, error)
+	}
+	Sprint interface {
+		CreateSprint(ctx context.Context, sprint models.SprintToCreate) (uint64, error)
+		GetSprintByID(ctx context.Context, id uint64) (*models.Sprint, error)
+		UpdateSprint(ctx context.Context, sprint models.SprintToUpdate) error
+		GetAllProjectSprints(ctx context.Context, projectID uint64) ([]models.Sprint, error)
+		DeleteSprint(ctx context.Context, id uint64) error
+		StartSprint(ctx context.Context, sprint models.Sprint) error
+		CloseSprint(
+			ctx context.Context, sprint models.Sprint, moveTo models.SprintMoveTo,
+		) (*models.SprintCloseResult, error)
+		GetAllSprintTasks(ctx context.Context, sprintID uint64) ([]models.Task, error)
+		GetSprintBurndown(ctx context.Context, sprint models.Sprint) (*models.SprintBurndown, error)
+	}
+	Worklog interface {
+		CreateWorklog(ctx context.Context, worklog models.WorklogToCreate, userID uint64) (uint64, error)
+		GetWorklogByID(ctx context.Context, id uint64) (*models.Worklog, error)
+		UpdateWorklog(ctx context.Context, id, userID uint64, worklog models.WorklogToUpdate) error
+		GetAllTaskWorklogs(ctx context.Context, taskID uint64) ([]models.Worklog, error)
+		DeleteWorklog(ctx context.Context, id uint64) error
+		GetTimesheet(
+			ctx context.Context, viewerID uint64, userID *uint64, period models.ReportPeriod,
+		) (*models.Timesheet, error)
+	}
+	Attachment interface {
+		ValidateAttachment(attachment models.AttachmentToCreate) (models.AttachmentToCreate, error)
+		CreateAttachment(
+			ctx context.Context, attachment models.AttachmentToCreate, content io.Reader, uploaderID uint64,
+		) (uint64, error)
+		GetAttachmentByID(ctx context.Context, id uint64) (*models.Attachment, error)
+		GetAllTaskAttachments(ctx context.Context, taskID uint64) ([]models.Attachment, error)
+		GetAttachmentContent(ctx context.Context, attachment models.Attachment) (io.ReadCloser, error)
+		DeleteAttachment(ctx context.Context, id uint64) error
+		DeleteAttachments(ctx context.Context, ids []uint64) error
+	}
+	Blob interface {
+		DeleteQueuedBlobs(ctx context.Context) error
+	}
+	Report interface {
+		GetCumulativeFlow(
+			ctx context.Context, projectID uint64, period models.ReportPeriod,
+		) (*models.CumulativeFlowReport, error)
+		GetLeadCycleTime(
+			ctx context.Context, projectID uint64, period models.ReportPeriod,
+		) (*models.LeadCycleTimeReport, error)
+		GetThroughput(ctx context.Context, projectID uint64, period models.ReportPeriod) (*models.ThroughputReport, error)
+	}
+	Comment interface {
+		CreateComment(ctx context.Context, comment models.CommentToCreate, authorID uint64) (uint64, error)
+		GetCommentByID(ctx context.Context, id uint64) (*models.Comment, error)
+		UpdateComment(ctx context.Context, id, authorID uint64, text string) error
+		GetAllTaskComments(ctx context.Context, taskID uint64) ([]models.Comment, error)
+		GetCommentEdits(ctx context.Context, commentID uint64) ([]models.CommentEdit, error)
+		DeleteComment(ctx context.Context, id uint64) error
+	}
+	Activity interface {
+		GetTaskActivity(
+			ctx context.Context, taskID uint64, pageParams models.PageParams,
+		) (*models.TaskActivityPage, error)
+		GetProjectActivity(
+			ctx context.Context, projectID uint64, pageParams models.PageParams,
+		) (*models.TaskActivityPage, error)
+	}
+	ProjectAccess interface {
+		CheckProjectPermission(
+			ctx context.Context, projectID, userID uint64, permission models.ProjectPermission,
+		) error
+		CheckProjectUserManagement(
+			ctx context.Context, projectID, actorID, userID uint64, role models.ProjectRole,
+		) error
+		GetProjectIDByBoardEntities(ctx context.Context, progressStatusIDs []int64, taskIDs []uint64) (uint64, error)
+		CheckTaskProjectEntities(
+			ctx context.Context, projectID, assigneeID uint64, importanceStatusID, progressStatusID int64,
+		) error
+	}
+	Notification interface {
+		GetNotificationSettings(ctx context.Context, userID uint64) (*models.NotificationSettings, error)
+		UpdateNotificationSettings(ctx context.Context, settings models.NotificationSettings) error
+		SendDailyDigests(ctx context.Context, since, until time.Time) error
+		SendDueSoonReminders(ctx context.Context) error
+	}
+	Webhook interface {
+		CreateWebhook(ctx context.Context, webhook models.WebhookToCreate) (uint64, error)
+		GetWebhookByID(ctx context.Context, id uint64) (*models.Webhook, error)
+		UpdateWebhook(ctx context.Context, webhook models.WebhookToUpdate) error
+		GetAllProjectWebhooks(ctx context.Context, projectID uint64) ([]models.Webhook, error)
+		DeleteWebhook(ctx context.Context, id uint64) error
+		GetWebhookDeliveries(ctx context.Context, webhookID uint64, limit int) ([]models.WebhookDelivery, error)
+		NotifyProjectEvent(ctx context.Context, projectID uint64, event models.WebhookEvent, data interface{})
+	}
+	TaskFilter interface {
+		CreateTaskFilter(ctx context.Context, filter models.TaskFilterToCreate, ownerID uint64) (uint64, error)
+		GetTaskFilterByID(ctx context.Context, id uint64) (*models.TaskFilter, error)
+		GetAllProjectTaskFiltersToUser(ctx context.Context, projectID, userID uint64) ([]models.TaskFilter, error)
+		UpdateTaskFilter(ctx context.Context, filter models.TaskFilterToUpdate) error
+		DeleteTaskFilter(ctx context.Context, id uint64) error
+	}
+	Search interface {
+		Search(ctx context.Context, userID uint64, params models.SearchParams) (*models.SearchResults, error)
+	}
+	BoardEvents interface {
+		PublishBoardEvent(projectID, actorID uint64, eventType models.BoardEventType, data interface{})
+		SubscribeBoardEvents(projectID uint64) (events <-chan []byte, unsubscribe func())
+		Run(ctx context.Context)
 	}
 	UserAuthentication interface {
 		AuthenticateUserByEmail(ctx context.Context, email, password, fingerprint string) (userID uint64, err error)
@@ -87,6 +258,7 @@ type (
 		ValidateAccessToken(accessToken string) (*jwt.StandardClaims, error)
 		RefreshSession(currentRefreshToken string) (accessToken, refreshToken string, err error)
 		RevokeSession(accessToken string) error
+		RevokeAllUserSessions(userID string) error
 		GetAccessTokenClaims(accessToken string) (*jwt.StandardClaims, error)
 	}
 	Verification interface {
@@ -94,18 +266,45 @@ type (
 		VerifyEmailConfirmToken(emailConfirmToken string) (userID uint64, err error)
 		CreatePasswordResetConfirmToken(userID uint64) (string, error)
 		VerifyPasswordResetConfirmToken(confirmToken string) (userID uint64, err error)
+		CreateEmailChangeToken(change models.EmailChange) (string, error)
+		VerifyEmailChangeToken(changeToken string) (*models.EmailChange, error)
+		CreateProjectInvitationToken(invitation models.ProjectInvitation) (string, error)
+		GetProjectInvitation(invitationToken string) (*models.ProjectInvitation, error)
+		DeleteProjectInvitationToken(invitationToken string) error
 	}
 	Mailer interface {
 		SendEmailConfirm(toEmail, token string)
 		SendResetPasswordConfirm(toEmail, token string)
+		SendEmailChangeConfirm(toEmail, token string)
+		SendProjectInvitation(toEmail, projectName, token string)
+		SendCommentMention(toEmail, authorName, taskTitle, text string)
+		SendDailyDigest(toEmail, firstName string, tasks []models.TaskNotification)
+		SendDueSoonReminder(toEmail, firstName string, tasks []models.TaskNotification)
 	}
 	Service struct {
 		User
+		Avatar
 		Project
 		ProjectBoard
 		ImportanceStatus
 		ProgressStatus
+		StatusTransition
+		Label
 		Task
+		TaskLink
+		Sprint
+		Worklog
+		Attachment
+		Blob
+		Report
+		Comment
+		Activity
+		ProjectAccess
+		Notification
+		Webhook
+		TaskFilter
+		Search
+		BoardEvents
 		UserAuthentication
 		UserAuthorization
 		Verification
@@ -115,7 +314,7 @@ type (
 
 func NewService(
 	cfg *config.Config, log *logrus.Logger,
-	repo *repository.Repository, mailer mailer.Mailer,
+	repo *repository.Repository, mailer mailer.Mailer, webhookSender webhook.Sender, blobStore blobstore.BlobStore,
 ) (*Service, error) {
 	var generator RandomTokenGenerator
 	var err error
@@ -130,22 +329,63 @@ func NewService(
 
 	authenticationLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "authentication-svc"})
 	verificationLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "verification-svc"})
+	commentLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "comment-svc"})
+	notificationLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "notification-svc"})
+	webhookLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "webhook-svc"})
+	boardEventsLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "board-events-svc"})
+	attachmentLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "attachment-svc"})
+	blobLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "blob-svc"})
+	avatarLogEntry := logrus.NewEntry(log).WithFields(logrus.Fields{"source": "avatar-svc"})
 
 	mailerCfg := MailerServiceConfig{
 		From:      cfg.Mailer.Username,
 		AppDomain: cfg.Mailer.AppDomain,
 	}
 
+	mailerSvc := NewMailerService(mailerCfg, mailer)
+	notificationSvc := NewNotificationService(
+		notificationLogEntry, repo.Notification, mailerSvc, cfg.Scheduler.DueReminderDays,
+	)
+
+	attachmentCfg := AttachmentServiceConfig{
+		MaxSize:             cfg.Attachment.MaxSize,
+		AllowedContentTypes: cfg.Attachment.AllowedContentTypes,
+	}
+
+	avatarCfg := AvatarServiceConfig{
+		MaxSize:      cfg.Avatar.MaxSize,
+		MaxDimension: cfg.Avatar.MaxDimension,
+	}
+
+	statusTransitionSvc := NewStatusTransitionService(repo)
+
 	return &Service{
-		User:               NewUserService(repo.User, cfg.JWT.AccessTokenLifetime.Duration()),
+		User:               NewUserService(repo.User, cfg.JWT.AccessTokenLifetime.Duration(), cfg.Admin.Email),
+		Avatar:             NewAvatarService(avatarCfg, avatarLogEntry, repo.User, blobStore),
 		Project:            NewProjectService(repo.Project),
-		ProjectBoard:       NewProjectBoardService(repo.ProjectBoard),
+		ProjectBoard:       NewProjectBoardService(repo.ProjectBoard, statusTransitionSvc),
 		ImportanceStatus:   NewImportanceStatusService(repo.ImportanceStatus),
 		ProgressStatus:     NewProgressStatusService(repo.ProgressStatus),
-		Task:               NewTaskService(repo.Task),
+		StatusTransition:   statusTransitionSvc,
+		Label:              NewLabelService(repo.Label),
+		Task:               NewTaskService(repo.Task, statusTransitionSvc),
+		TaskLink:           NewTaskLinkService(repo.TaskLink),
+		Sprint:             NewSprintService(repo.Sprint),
+		Worklog:            NewWorklogService(repo.Worklog),
+		Attachment:         NewAttachmentService(attachmentCfg, attachmentLogEntry, repo.Attachment, blobStore),
+		Blob:               NewBlobService(blobLogEntry, repo.Blob, blobStore),
+		Report:             NewReportService(repo),
+		Comment:            NewCommentService(commentLogEntry, repo, mailerSvc),
+		Activity:           NewActivityService(repo.Activity),
+		ProjectAccess:      NewProjectAccessService(repo),
+		Notification:       notificationSvc,
+		Webhook:            NewWebhookService(webhookLogEntry, repo.Webhook, webhookSender),
+		TaskFilter:         NewTaskFilterService(repo.TaskFilter),
+		Search:             NewSearchService(repo.Search),
+		BoardEvents:        NewBoardEventsService(boardEventsLogEntry, repo.BoardEventBroker),
 		UserAuthentication: NewAuthenticationService(cfg, authenticationLogEntry, repo),
 		UserAuthorization:  NewAuthorizationService(cfg, repo),
 		Verification:       NewVerificationService(verificationLogEntry, repo.VerificationCache, generator),
-		Mailer:             NewMailerService(mailerCfg, mailer),
+		Mailer:             mailerSvc,
 	}, nil
 }
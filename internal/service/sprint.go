@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotValidSprintDates = errors.New("not valid sprint dates")
+	ErrNotValidSprintMove  = errors.New("not valid sprint move")
+	ErrSprintNotPlanned    = errors.New("only planned sprint can be started")
+	ErrSprintNotActive     = errors.New("only active sprint can be closed")
+	ErrActiveSprintExists  = errors.New("project already has active sprint")
+)
+
+type SprintService struct {
+	repo repository.Sprint
+}
+
+func NewSprintService(repo repository.Sprint) *SprintService {
+	return &SprintService{repo: repo}
+}
+
+func (s *SprintService) CreateSprint(ctx context.Context, sprint models.SprintToCreate) (uint64, error) {
+	if err := validateSprintDates(sprint.StartDate, sprint.EndDate); err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateSprint(ctx, sprint)
+}
+
+func (s *SprintService) GetSprintByID(ctx context.Context, id uint64) (*models.Sprint, error) {
+	return s.repo.GetSprintByID(ctx, id)
+}
+
+func (s *SprintService) UpdateSprint(ctx context.Context, sprint models.SprintToUpdate) error {
+	if err := validateSprintDates(sprint.StartDate, sprint.EndDate); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateSprint(ctx, sprint)
+}
+
+func (s *SprintService) GetAllProjectSprints(ctx context.Context, projectID uint64) ([]models.Sprint, error) {
+	return s.repo.GetAllProjectSprints(ctx, projectID)
+}
+
+func (s *SprintService) DeleteSprint(ctx context.Context, id uint64) error {
+	return s.repo.DeleteSprint(ctx, id)
+}
+
+// StartSprint makes planned sprint active. Project can have only one active sprint.
+func (s *SprintService) StartSprint(ctx context.Context, sprint models.Sprint) error {
+	if sprint.Status != models.SprintStatusPlanned {
+		return ierrors.NewBusiness(ErrSprintNotPlanned, "")
+	}
+
+	sprints, err := s.repo.GetAllProjectSprints(ctx, sprint.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, projectSprint := range sprints {
+		if projectSprint.Status == models.SprintStatusActive {
+			return ierrors.NewBusiness(
+				ErrActiveSprintExists, fmt.Sprintf("sprint %q should be closed first", projectSprint.Name),
+			)
+		}
+	}
+
+	started, err := s.repo.StartSprint(ctx, sprint.ID)
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		return ierrors.NewBusiness(ErrSprintNotPlanned, "")
+	}
+
+	return nil
+}
+
+// CloseSprint closes active sprint and moves its unfinished tasks to the next sprint or to the backlog.
+func (s *SprintService) CloseSprint(
+	ctx context.Context, sprint models.Sprint, moveTo models.SprintMoveTo,
+) (*models.SprintCloseResult, error) {
+	if moveTo != models.SprintMoveToNext && moveTo != models.SprintMoveToBacklog {
+		return nil, ierrors.NewBusiness(ErrNotValidSprintMove, fmt.Sprintf(
+			"unfinished tasks can be moved to: %s, %s", models.SprintMoveToNext, models.SprintMoveToBacklog,
+		))
+	}
+
+	if sprint.Status != models.SprintStatusActive {
+		return nil, ierrors.NewBusiness(ErrSprintNotActive, "")
+	}
+
+	result, err := s.repo.CloseSprint(ctx, sprint.ID, moveTo)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ierrors.NewBusiness(ErrSprintNotActive, "")
+	}
+
+	if result.MovedTaskIDs == nil {
+		result.MovedTaskIDs = []uint64{}
+	}
+
+	return result, nil
+}
+
+func (s *SprintService) GetAllSprintTasks(ctx context.Context, sprintID uint64) ([]models.Task, error) {
+	return s.repo.GetAllSprintTasks(ctx, sprintID)
+}
+
+// GetSprintBurndown returns burndown of sprint with ideal line from tasks of the first sprint day to zero.
+func (s *SprintService) GetSprintBurndown(ctx context.Context, sprint models.Sprint) (*models.SprintBurndown, error) {
+	counts, err := s.repo.GetSprintBurndown(ctx, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayCounts := make(map[string]models.SprintBurndownCount, len(counts))
+	for _, count := range counts {
+		dayCounts[count.Date.String()] = count
+	}
+
+	var scope float64
+	if len(counts) != 0 {
+		scope = float64(counts[0].Total)
+	}
+
+	days := int(sprint.EndDate.Sub(sprint.StartDate.Time).Hours()/24) + 1
+
+	burndown := &models.SprintBurndown{
+		SprintID: sprint.ID,
+		Days:     make([]models.SprintBurndownDay, 0, days),
+	}
+
+	for i := 0; i < days; i++ {
+		day := models.NewDate(sprint.StartDate.AddDate(0, 0, i))
+
+		burndownDay := models.SprintBurndownDay{
+			Date: day,
+		}
+
+		if days > 1 {
+			burndownDay.Ideal = scope * float64(days-1-i) / float64(days-1)
+		}
+
+		if count, ok := dayCounts[day.String()]; ok {
+			total, remaining := count.Total, count.Remaining
+			burndownDay.Total, burndownDay.Remaining = &total, &remaining
+		}
+
+		burndown.Days = append(burndown.Days, burndownDay)
+	}
+
+	return burndown, nil
+}
+
+func validateSprintDates(startDate, endDate models.Date) error {
+	if startDate.IsZero() || endDate.IsZero() {
+		return ierrors.NewBusiness(ErrNotValidSprintDates, "start date and end date are required")
+	}
+
+	if startDate.After(endDate.Time) {
+		return ierrors.NewBusiness(ErrNotValidSprintDates, "start date should not be after end date")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+type fakeSprintRepo struct {
+	repository.Sprint
+	counts      []models.SprintBurndownCount
+	closeResult *models.SprintCloseResult
+}
+
+func (r *fakeSprintRepo) CloseSprint(
+	_ context.Context, _ uint64, _ models.SprintMoveTo,
+) (*models.SprintCloseResult, error) {
+	return r.closeResult, nil
+}
+
+func (r *fakeSprintRepo) GetSprintBurndown(_ context.Context, _ uint64) ([]models.SprintBurndownCount, error) {
+	return r.counts, nil
+}
+
+func TestSprintService_CloseSprint(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      models.SprintStatus
+		moveTo      models.SprintMoveTo
+		closeResult *models.SprintCloseResult
+		wantErr     error
+	}{
+		{
+			name:        "unfinished tasks are moved to next sprint",
+			status:      models.SprintStatusActive,
+			moveTo:      models.SprintMoveToNext,
+			closeResult: &models.SprintCloseResult{},
+		},
+		{
+			name:        "unfinished tasks are moved to backlog",
+			status:      models.SprintStatusActive,
+			moveTo:      models.SprintMoveToBacklog,
+			closeResult: &models.SprintCloseResult{},
+		},
+		{
+			name:    "not valid move",
+			status:  models.SprintStatusActive,
+			moveTo:  "trash",
+			wantErr: ErrNotValidSprintMove,
+		},
+		{
+			name:    "planned sprint",
+			status:  models.SprintStatusPlanned,
+			moveTo:  models.SprintMoveToBacklog,
+			wantErr: ErrSprintNotActive,
+		},
+		{
+			name:    "sprint is closed by another request",
+			status:  models.SprintStatusActive,
+			moveTo:  models.SprintMoveToBacklog,
+			wantErr: ErrSprintNotActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSprintService(&fakeSprintRepo{closeResult: tt.closeResult})
+
+			result, err := s.CloseSprint(context.Background(), models.Sprint{ID: 1, Status: tt.status}, tt.moveTo)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("CloseSprint() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// moved tasks are empty list in response
+			if tt.wantErr == nil && (result == nil || result.MovedTaskIDs == nil) {
+				t.Errorf("CloseSprint() = %+v, want moved tasks", result)
+			}
+		})
+	}
+}
+
+func TestSprintService_GetSprintBurndown(t *testing.T) {
+	// sprint of 5 days has 4 tasks on the first day, one more task is added on the second day
+	// and it is closed on the third day
+	s := NewSprintService(&fakeSprintRepo{counts: []models.SprintBurndownCount{
+		{Date: testDate(t, "2026-10-05"), Total: 4, Remaining: 4},
+		{Date: testDate(t, "2026-10-06"), Total: 5, Remaining: 3},
+		{Date: testDate(t, "2026-10-07"), Total: 5, Remaining: 1},
+	}})
+
+	burndown, err := s.GetSprintBurndown(context.Background(), models.Sprint{
+		ID: 1, StartDate: testDate(t, "2026-10-05"), EndDate: testDate(t, "2026-10-09"),
+	})
+	if err != nil {
+		t.Fatalf("GetSprintBurndown() error = %v", err)
+	}
+
+	int64Ptr := func(v int64) *int64 { return &v }
+	want := []struct {
+		date      string
+		total     *int64
+		remaining *int64
+		ideal     float64
+	}{
+		{date: "2026-10-05", total: int64Ptr(4), remaining: int64Ptr(4), ideal: 4},
+		{date: "2026-10-06", total: int64Ptr(5), remaining: int64Ptr(3), ideal: 3},
+		{date: "2026-10-07", total: int64Ptr(5), remaining: int64Ptr(1), ideal: 2},
+		{date: "2026-10-08", ideal: 1},
+		{date: "2026-10-09", ideal: 0},
+	}
+
+	if burndown.SprintID != 1 || len(burndown.Days) != len(want) {
+		t.Fatalf("burndown = %+v, want %d days of sprint 1", burndown, len(want))
+	}
+
+	equal := func(got, want *int64) bool {
+		return got == nil && want == nil || got != nil && want != nil && *got == *want
+	}
+
+	for i, day := range burndown.Days {
+		if day.Date.String() != want[i].date || !equal(day.Total, want[i].total) ||
+			!equal(day.Remaining, want[i].remaining) || day.Ideal != want[i].ideal {
+			t.Errorf("day %d = %s total %v remaining %v ideal %v, want %+v",
+				i, day.Date, day.Total, day.Remaining, day.Ideal, want[i])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStatusTransitionNotAllowed       = errors.New("progress status transition is not allowed")
+	ErrStatusTransitionNotAllowedToRole = errors.New("progress status transition is not allowed to user role")
+)
+
+type StatusTransitionService struct {
+	repo *repository.Repository
+}
+
+func NewStatusTransitionService(repo *repository.Repository) *StatusTransitionService {
+	return &StatusTransitionService{repo: repo}
+}
+
+func (s *StatusTransitionService) Create(
+	ctx context.Context, transition models.StatusTransitionToCreate,
+) (int64, error) {
+	for _, statusID := range []int64{transition.FromStatusID, transition.ToStatusID} {
+		status, err := s.repo.ProgressStatus.GetByID(ctx, statusID)
+		if err != nil {
+			return 0, err
+		}
+
+		if status == nil || status.ProjectID != transition.ProjectID {
+			return 0, ierrors.NewBusiness(ErrProgressStatusNotFound, "")
+		}
+	}
+
+	return s.repo.StatusTransition.Create(ctx, transition)
+}
+
+func (s *StatusTransitionService) GetByID(ctx context.Context, id int64) (*models.StatusTransition, error) {
+	return s.repo.StatusTransition.GetByID(ctx, id)
+}
+
+func (s *StatusTransitionService) Update(ctx context.Context, transition models.StatusTransitionToUpdate) error {
+	return s.repo.StatusTransition.Update(ctx, transition)
+}
+
+func (s *StatusTransitionService) GetAllToProject(
+	ctx context.Context, projectID uint64,
+) ([]models.StatusTransition, error) {
+	return s.repo.StatusTransition.GetAllToProject(ctx, projectID)
+}
+
+func (s *StatusTransitionService) Delete(ctx context.Context, id int64) error {
+	return s.repo.StatusTransition.Delete(ctx, id)
+}
+
+// CheckTaskTransitions checks that actor can move the project tasks to progress statuses
+// by the project transitions. statusIDs are new progress status ids by task ids.
+// Any transition is allowed in project without transitions.
+func (s *StatusTransitionService) CheckTaskTransitions(
+	ctx context.Context, projectID, actorID uint64, statusIDs map[uint64]int64,
+) error {
+	if len(statusIDs) == 0 {
+		return nil
+	}
+
+	transitions, err := s.repo.StatusTransition.GetAllToProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	taskIDs := make([]uint64, 0, len(statusIDs))
+	for taskID := range statusIDs {
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	currentStatusIDs, err := s.repo.Task.GetTasksProgressStatusIDs(ctx, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	actor, err := s.repo.Project.GetProjectUser(ctx, projectID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if actor == nil {
+		return ierrors.NewForbidden(ErrNotProjectMember, "")
+	}
+
+	for taskID, toStatusID := range statusIDs {
+		fromStatusID, ok := currentStatusIDs[taskID]
+		if !ok || fromStatusID == toStatusID {
+			continue
+		}
+
+		transition := findStatusTransition(transitions, fromStatusID, toStatusID)
+		if transition == nil {
+			return s.newTransitionError(ctx, projectID, ErrStatusTransitionNotAllowed, fromStatusID, toStatusID, "")
+		}
+
+		if !isRoleAllowed(transition.Roles, actor.Role) {
+			return s.newTransitionError(
+				ctx, projectID, ErrStatusTransitionNotAllowedToRole, fromStatusID, toStatusID, actor.Role,
+			)
+		}
+	}
+
+	return nil
+}
+
+// newTransitionError returns error with names of statuses of the forbidden transition in details.
+func (s *StatusTransitionService) newTransitionError(
+	ctx context.Context, projectID uint64, err error, fromStatusID, toStatusID int64, role models.ProjectRole,
+) error {
+	statuses, getErr := s.repo.ProgressStatus.GetAllToProject(ctx, projectID)
+	if getErr != nil {
+		return getErr
+	}
+
+	names := make(map[int64]string, len(statuses))
+	for _, status := range statuses {
+		names[status.ID] = status.Name
+	}
+
+	detail := fmt.Sprintf("transition from %q to %q is not allowed", names[fromStatusID], names[toStatusID])
+	if role != "" {
+		detail += fmt.Sprintf(" to role %s", role)
+	}
+
+	return ierrors.NewForbidden(err, detail)
+}
+
+func findStatusTransition(
+	transitions []models.StatusTransition, fromStatusID, toStatusID int64,
+) *models.StatusTransition {
+	for i := range transitions {
+		if transitions[i].FromStatusID == fromStatusID && transitions[i].ToStatusID == toStatusID {
+			return &transitions[i]
+		}
+	}
+
+	return nil
+}
+
+// isRoleAllowed checks that role is in roles. Empty roles allow any role.
+func isRoleAllowed(roles []models.ProjectRole, role models.ProjectRole) bool {
+	if len(roles) == 0 {
+		return true
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
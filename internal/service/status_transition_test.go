@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+const (
+	testStatusToDo       = 1
+	testStatusInProgress = 2
+	testStatusDone       = 3
+	testStatusOther      = 4
+
+	testTaskToDo       = 10
+	testTaskInProgress = 11
+
+	testProjectWithoutTransitionsID = 2
+)
+
+type fakeStatusTransitionRepo struct {
+	repository.StatusTransition
+	transitions []models.StatusTransition
+}
+
+func (r *fakeStatusTransitionRepo) Create(_ context.Context, _ models.StatusTransitionToCreate) (int64, error) {
+	return 1, nil
+}
+
+func (r *fakeStatusTransitionRepo) GetAllToProject(
+	_ context.Context, projectID uint64,
+) ([]models.StatusTransition, error) {
+	var transitions []models.StatusTransition
+	for _, transition := range r.transitions {
+		if transition.ProjectID == projectID {
+			transitions = append(transitions, transition)
+		}
+	}
+
+	return transitions, nil
+}
+
+type fakeProgressStatusRepo struct {
+	repository.ProgressStatus
+	statuses []models.ProgressStatus
+}
+
+func (r *fakeProgressStatusRepo) GetByID(_ context.Context, id int64) (*models.ProgressStatus, error) {
+	for _, status := range r.statuses {
+		if status.ID == id {
+			return &status, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeProgressStatusRepo) GetAllToProject(
+	_ context.Context, projectID uint64,
+) ([]models.ProgressStatus, error) {
+	var statuses []models.ProgressStatus
+	for _, status := range r.statuses {
+		if status.ProjectID == projectID {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+type fakeTaskStatusRepo struct {
+	repository.Task
+	// statusIDs are progress status ids by task ids
+	statusIDs map[uint64]int64
+}
+
+func (r *fakeTaskStatusRepo) GetTasksProgressStatusIDs(_ context.Context, ids []uint64) (map[uint64]int64, error) {
+	statusIDs := make(map[uint64]int64, len(ids))
+	for _, id := range ids {
+		if statusID, ok := r.statusIDs[id]; ok {
+			statusIDs[id] = statusID
+		}
+	}
+
+	return statusIDs, nil
+}
+
+// newTestStatusTransitionService returns service for test project with workflow
+// TO DO -> IN PROGRESS for any role and IN PROGRESS -> DONE for admins and owner.
+func newTestStatusTransitionService() *StatusTransitionService {
+	repo := newTestProjectAccessService().repo
+	repo.ProgressStatus = &fakeProgressStatusRepo{statuses: []models.ProgressStatus{
+		{ID: testStatusToDo, ProjectID: testProjectID, Name: "TO DO"},
+		{ID: testStatusInProgress, ProjectID: testProjectID, Name: "IN PROGRESS"},
+		{ID: testStatusDone, ProjectID: testProjectID, Name: "DONE"},
+		{ID: testStatusOther, ProjectID: testProjectWithoutTransitionsID, Name: "TO DO"},
+	}}
+	repo.StatusTransition = &fakeStatusTransitionRepo{transitions: []models.StatusTransition{
+		{ProjectID: testProjectID, FromStatusID: testStatusToDo, ToStatusID: testStatusInProgress},
+		{
+			ProjectID:    testProjectID,
+			FromStatusID: testStatusInProgress,
+			ToStatusID:   testStatusDone,
+			Roles:        []models.ProjectRole{models.ProjectRoleAdmin, models.ProjectRoleOwner},
+		},
+	}}
+	repo.Task = &fakeTaskStatusRepo{statusIDs: map[uint64]int64{
+		testTaskToDo:       testStatusToDo,
+		testTaskInProgress: testStatusInProgress,
+	}}
+
+	return NewStatusTransitionService(repo)
+}
+
+func TestStatusTransitionService_CheckTaskTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		projectID  uint64
+		actorID    uint64
+		statusIDs  map[uint64]int64
+		wantErr    error
+		wantDetail string
+	}{
+		{
+			name:      "any transition in project without transitions",
+			projectID: testProjectWithoutTransitionsID,
+			actorID:   testMemberID,
+			statusIDs: map[uint64]int64{testTaskToDo: testStatusDone},
+		},
+		{
+			name:      "status is not changed",
+			projectID: testProjectID,
+			actorID:   testViewerID,
+			statusIDs: map[uint64]int64{testTaskToDo: testStatusToDo},
+		},
+		{
+			name:      "transition for any role",
+			projectID: testProjectID,
+			actorID:   testMemberID,
+			statusIDs: map[uint64]int64{testTaskToDo: testStatusInProgress},
+		},
+		{
+			name:      "transition for role of actor",
+			projectID: testProjectID,
+			actorID:   testAdminID,
+			statusIDs: map[uint64]int64{testTaskInProgress: testStatusDone},
+		},
+		{
+			name:       "transition is not in workflow",
+			projectID:  testProjectID,
+			actorID:    testOwnerID,
+			statusIDs:  map[uint64]int64{testTaskToDo: testStatusDone},
+			wantErr:    ErrStatusTransitionNotAllowed,
+			wantDetail: `transition from "TO DO" to "DONE" is not allowed`,
+		},
+		{
+			name:       "transition is not allowed to role of actor",
+			projectID:  testProjectID,
+			actorID:    testMemberID,
+			statusIDs:  map[uint64]int64{testTaskInProgress: testStatusDone},
+			wantErr:    ErrStatusTransitionNotAllowedToRole,
+			wantDetail: `transition from "IN PROGRESS" to "DONE" is not allowed to role member`,
+		},
+		{
+			name:      "one of tasks has not allowed transition",
+			projectID: testProjectID,
+			actorID:   testMemberID,
+			statusIDs: map[uint64]int64{
+				testTaskToDo:       testStatusInProgress,
+				testTaskInProgress: testStatusToDo,
+			},
+			wantErr:    ErrStatusTransitionNotAllowed,
+			wantDetail: `transition from "IN PROGRESS" to "TO DO" is not allowed`,
+		},
+		{
+			name:      "actor is not project member",
+			projectID: testProjectID,
+			actorID:   testOutsiderID,
+			statusIDs: map[uint64]int64{testTaskToDo: testStatusInProgress},
+			wantErr:   ErrNotProjectMember,
+		},
+	}
+
+	s := newTestStatusTransitionService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckTaskTransitions(context.Background(), tt.projectID, tt.actorID, tt.statusIDs)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("CheckTaskTransitions() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantDetail != "" {
+				customErr, ok := err.(*ierrors.Error)
+				if !ok || customErr.Detail != tt.wantDetail {
+					t.Errorf("CheckTaskTransitions() error = %#v, want detail %q", err, tt.wantDetail)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusTransitionService_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition models.StatusTransitionToCreate
+		wantErr    error
+	}{
+		{
+			name: "statuses of project",
+			transition: models.StatusTransitionToCreate{
+				ProjectID: testProjectID, FromStatusID: testStatusDone, ToStatusID: testStatusToDo,
+			},
+		},
+		{
+			name: "status of other project",
+			transition: models.StatusTransitionToCreate{
+				ProjectID: testProjectID, FromStatusID: testStatusToDo, ToStatusID: testStatusOther,
+			},
+			wantErr: ErrProgressStatusNotFound,
+		},
+		{
+			name: "not existing status",
+			transition: models.StatusTransitionToCreate{
+				ProjectID: testProjectID, FromStatusID: 100, ToStatusID: testStatusToDo,
+			},
+			wantErr: ErrProgressStatusNotFound,
+		},
+	}
+
+	s := newTestStatusTransitionService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Create(context.Background(), tt.transition)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
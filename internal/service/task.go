@@ -3,19 +3,35 @@ package service
 import (
 	"context"
 
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
 	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrTaskVersionConflict    = errors.New("task was changed by another request")
+	ErrNotValidSubtasksPolicy = errors.New("not valid subtasks policy")
+	ErrNotValidTaskDates      = errors.New("not valid task dates")
 )
 
 type TaskService struct {
-	repo repository.Task
+	repo             repository.Task
+	statusTransition StatusTransition
 }
 
-func NewTaskService(repo repository.Task) *TaskService {
-	return &TaskService{repo: repo}
+func NewTaskService(repo repository.Task, statusTransition StatusTransition) *TaskService {
+	return &TaskService{
+		repo:             repo,
+		statusTransition: statusTransition,
+	}
 }
 
 func (s *TaskService) CreateTaskToProject(ctx context.Context, task models.TaskToCreate) (uint64, error) {
+	if err := validateTaskDates(task.StartDate, task.DueDate); err != nil {
+		return 0, err
+	}
+
 	return s.repo.CreateTaskToProject(ctx, task)
 }
 
@@ -23,22 +39,198 @@ func (s *TaskService) GetTaskByID(ctx context.Context, id uint64) (*models.Task,
 	return s.repo.GetTaskByID(ctx, id)
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, task models.Task) error {
-	return s.repo.UpdateTask(ctx, task)
+// UpdateTask updates task with the expected version and returns new version of task.
+// Change of progress status is checked by the project transitions.
+func (s *TaskService) UpdateTask(ctx context.Context, task models.Task, actorID uint64) (uint64, error) {
+	if err := validateTaskDates(task.StartDate, task.DueDate); err != nil {
+		return 0, err
+	}
+
+	if err := s.statusTransition.CheckTaskTransitions(
+		ctx, task.ProjectID, actorID, map[uint64]int64{task.ID: task.ProgressStatusID},
+	); err != nil {
+		return 0, err
+	}
+
+	version, err := s.repo.UpdateTask(ctx, task, actorID)
+	if err != nil {
+		return 0, err
+	}
+
+	if version == 0 {
+		return 0, ierrors.NewConflict(ErrTaskVersionConflict, "")
+	}
+
+	return version, nil
+}
+
+func (s *TaskService) GetAllTasksToProject(
+	ctx context.Context, id uint64, pageParams models.PageParams,
+) (*models.TaskPage, error) {
+	req, err := newPageRequest(pageParams, taskSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, total, err := s.repo.GetAllTasksToProject(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskPage(tasks, total, req), nil
+}
+
+func (s *TaskService) GetAllTasksWithParameters(
+	ctx context.Context, params models.TaskParams, pageParams models.PageParams,
+) (*models.TaskPage, error) {
+	req, err := newPageRequest(pageParams, taskSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, total, err := s.repo.GetAllTasksWithParameters(ctx, params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskPage(tasks, total, req), nil
+}
+
+func (s *TaskService) GetOverdueTasksToUser(ctx context.Context, userID uint64) ([]models.Task, error) {
+	return s.repo.GetOverdueTasksToUser(ctx, userID)
+}
+
+// GetTasksByFilter returns page of the project tasks matching the filter query.
+func (s *TaskService) GetTasksByFilter(
+	ctx context.Context, projectID, userID uint64, query string, pageParams models.PageParams,
+) (*models.TaskPage, error) {
+	filter, err := parseTaskFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newPageRequest(pageParams, taskSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, total, err := s.repo.GetTasksByFilter(ctx, projectID, userID, filter, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskPage(tasks, total, req), nil
+}
+
+func (s *TaskService) GetAllTasks(ctx context.Context, pageParams models.PageParams) (*models.TaskPage, error) {
+	req, err := newPageRequest(pageParams, taskSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, total, err := s.repo.GetAllTasks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTaskPage(tasks, total, req), nil
+}
+
+// GetTaskTree returns the task with its subtasks of all levels. Nil is returned if task is not found.
+func (s *TaskService) GetTaskTree(ctx context.Context, id uint64) (*models.TaskTree, error) {
+	tasks, err := s.repo.GetTaskWithSubtasks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtasks := make(map[uint64][]models.Task, len(tasks))
+	var root *models.Task
+	for i := range tasks {
+		if tasks[i].ID == id {
+			root = &tasks[i]
+			continue
+		}
+
+		if tasks[i].ParentID != nil {
+			subtasks[*tasks[i].ParentID] = append(subtasks[*tasks[i].ParentID], tasks[i])
+		}
+	}
+
+	if root == nil {
+		return nil, nil
+	}
+
+	tree := newTaskTree(*root, subtasks)
+
+	return &tree, nil
+}
+
+// DeleteTask deletes task with its subtasks by the policy and returns ids of deleted tasks.
+func (s *TaskService) DeleteTask(ctx context.Context, id uint64, policy models.SubtasksPolicy) ([]uint64, error) {
+	if policy != models.SubtasksPolicyOrphan && policy != models.SubtasksPolicyCascade {
+		return nil, ierrors.NewBusiness(ErrNotValidSubtasksPolicy, "")
+	}
+
+	return s.repo.DeleteTask(ctx, id, policy)
 }
 
-func (s *TaskService) GetAllTasksToProject(ctx context.Context, id uint64) ([]models.Task, error) {
-	return s.repo.GetAllTasksToProject(ctx, id)
+func newTaskTree(task models.Task, subtasks map[uint64][]models.Task) models.TaskTree {
+	tree := models.TaskTree{
+		Task:     task,
+		Subtasks: make([]models.TaskTree, 0, len(subtasks[task.ID])),
+	}
+
+	for _, subtask := range subtasks[task.ID] {
+		tree.Subtasks = append(tree.Subtasks, newTaskTree(subtask, subtasks))
+	}
+
+	return tree
 }
 
-func (s *TaskService) GetAllTasksWithParameters(ctx context.Context, params models.TaskParams) ([]models.Task, error) {
-	return s.repo.GetAllTasksWithParameters(ctx, params)
+func newTaskPage(tasks []models.Task, total int64, req models.PageRequest) *models.TaskPage {
+	page := &models.TaskPage{
+		Items:      tasks,
+		TotalCount: pageTotalCount(req, total),
+	}
+
+	if len(tasks) > req.Limit {
+		page.Items = tasks[:req.Limit]
+		last := page.Items[req.Limit-1]
+		page.NextCursor = encodePageCursor(req, taskSortValue(last, req.SortField), last.ID)
+	}
+
+	if page.Items == nil {
+		page.Items = []models.Task{}
+	}
+
+	return page
 }
 
-func (s *TaskService) GetAllTasks(ctx context.Context) ([]models.Task, error) {
-	return s.repo.GetAllTasks(ctx)
+func taskSortValue(task models.Task, field models.SortField) string {
+	switch field {
+	case models.SortFieldCreatedAt:
+		return models.FormatSortTime(task.CreatedAt)
+	case models.SortFieldUpdatedAt:
+		return models.FormatSortTime(task.UpdatedAt)
+	case models.SortFieldTitle:
+		return task.Title
+	case models.SortFieldDueDate:
+		// tasks without due date are sorted as having infinite due date
+		if task.DueDate == nil {
+			return sortValueInfinity
+		}
+
+		return task.DueDate.String()
+	}
+
+	return ""
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, id uint64) error {
-	return s.repo.DeleteTask(ctx, id)
+// validateTaskDates checks that task does not start after its due date. Both dates are optional.
+func validateTaskDates(startDate, dueDate *models.Date) error {
+	if startDate != nil && dueDate != nil && startDate.After(dueDate.Time) {
+		return ierrors.NewBusiness(ErrNotValidTaskDates, "start date should not be after due date")
+	}
+
+	return nil
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"unicode/utf8"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/internal/taskfilter"
+	"github.com/pkg/errors"
+)
+
+const taskFilterQueryMaxLength = 1000
+
+var ErrTooLongTaskFilterQuery = errors.New("too long task filter query")
+
+type TaskFilterService struct {
+	repo repository.TaskFilter
+}
+
+func NewTaskFilterService(repo repository.TaskFilter) *TaskFilterService {
+	return &TaskFilterService{repo: repo}
+}
+
+func (s *TaskFilterService) CreateTaskFilter(
+	ctx context.Context, filter models.TaskFilterToCreate, ownerID uint64,
+) (uint64, error) {
+	if _, err := parseTaskFilter(filter.Query); err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateTaskFilter(ctx, filter, ownerID)
+}
+
+func (s *TaskFilterService) GetTaskFilterByID(ctx context.Context, id uint64) (*models.TaskFilter, error) {
+	return s.repo.GetTaskFilterByID(ctx, id)
+}
+
+func (s *TaskFilterService) GetAllProjectTaskFiltersToUser(
+	ctx context.Context, projectID, userID uint64,
+) ([]models.TaskFilter, error) {
+	return s.repo.GetAllProjectTaskFiltersToUser(ctx, projectID, userID)
+}
+
+func (s *TaskFilterService) UpdateTaskFilter(ctx context.Context, filter models.TaskFilterToUpdate) error {
+	if _, err := parseTaskFilter(filter.Query); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateTaskFilter(ctx, filter)
+}
+
+func (s *TaskFilterService) DeleteTaskFilter(ctx context.Context, id uint64) error {
+	return s.repo.DeleteTaskFilter(ctx, id)
+}
+
+// parseTaskFilter parses task filter query. Syntax errors are returned as business errors with details.
+func parseTaskFilter(query string) (*taskfilter.Filter, error) {
+	if utf8.RuneCountInString(query) > taskFilterQueryMaxLength {
+		return nil, ierrors.NewBusiness(ErrTooLongTaskFilterQuery, "")
+	}
+
+	filter, err := taskfilter.Parse(query)
+	if err != nil {
+		return nil, ierrors.NewBusiness(err, err.Error())
+	}
+
+	return filter, nil
+}
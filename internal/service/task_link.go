@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+)
+
+type TaskLinkService struct {
+	repo repository.TaskLink
+}
+
+func NewTaskLinkService(repo repository.TaskLink) *TaskLinkService {
+	return &TaskLinkService{repo: repo}
+}
+
+func (s *TaskLinkService) CreateTaskLink(ctx context.Context, link models.TaskLinkToCreate) (uint64, error) {
+	return s.repo.CreateTaskLink(ctx, link)
+}
+
+func (s *TaskLinkService) GetTaskLinkByID(ctx context.Context, id uint64) (*models.TaskLink, error) {
+	return s.repo.GetTaskLinkByID(ctx, id)
+}
+
+func (s *TaskLinkService) GetAllTaskLinks(ctx context.Context, taskID uint64) ([]models.TaskLink, error) {
+	return s.repo.GetAllTaskLinks(ctx, taskID)
+}
+
+func (s *TaskLinkService) DeleteTaskLink(ctx context.Context, id uint64) error {
+	return s.repo.DeleteTaskLink(ctx, id)
+}
+
+func (s *TaskLinkService) GetBlockedProjectTasks(ctx context.Context, projectID uint64) ([]models.BlockedTask, error) {
+	return s.repo.GetBlockedProjectTasks(ctx, projectID)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+// fakeTaskRepo counts saved tasks. Not implemented methods panic.
+type fakeTaskRepo struct {
+	repository.Task
+	savedNum int
+}
+
+func (r *fakeTaskRepo) CreateTaskToProject(_ context.Context, _ models.TaskToCreate) (uint64, error) {
+	r.savedNum++
+	return 1, nil
+}
+
+func (r *fakeTaskRepo) UpdateTask(_ context.Context, _ models.Task, _ uint64) (uint64, error) {
+	r.savedNum++
+	return 2, nil
+}
+
+func TestTaskService_TaskDates(t *testing.T) {
+	date := func(s string) *models.Date {
+		d := testDate(t, s)
+		return &d
+	}
+
+	tests := []struct {
+		name      string
+		startDate *models.Date
+		dueDate   *models.Date
+		wantErr   error
+	}{
+		{
+			name: "without dates",
+		},
+		{
+			name:    "only due date",
+			dueDate: date("2026-10-01"),
+		},
+		{
+			name:      "start date is due date",
+			startDate: date("2026-10-01"),
+			dueDate:   date("2026-10-01"),
+		},
+		{
+			name:      "start date is after due date",
+			startDate: date("2026-10-02"),
+			dueDate:   date("2026-10-01"),
+			wantErr:   ErrNotValidTaskDates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTaskRepo{}
+			s := NewTaskService(repo, newTestStatusTransitionService())
+
+			_, createErr := s.CreateTaskToProject(context.Background(), models.TaskToCreate{
+				ProjectID: testProjectID, StartDate: tt.startDate, DueDate: tt.dueDate,
+			})
+			_, updateErr := s.UpdateTask(context.Background(), models.Task{
+				ID: testTaskToDo, ProjectID: testProjectID, ProgressStatusID: testStatusToDo,
+				StartDate: tt.startDate, DueDate: tt.dueDate,
+			}, testMemberID)
+
+			for _, err := range []error{createErr, updateErr} {
+				if !errors.Is(errorCause(err), tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+
+				// not valid dates are error of client
+				if tt.wantErr != nil {
+					if customErr, ok := err.(*ierrors.Error); !ok || customErr.Level != ierrors.Business {
+						t.Errorf("error = %#v, want business error", err)
+					}
+				}
+			}
+
+			// task is created and updated only with valid dates
+			wantSavedNum := 0
+			if tt.wantErr == nil {
+				wantSavedNum = 2
+			}
+
+			if repo.savedNum != wantSavedNum {
+				t.Errorf("saved tasks = %d, want %d", repo.savedNum, wantSavedNum)
+			}
+		})
+	}
+}
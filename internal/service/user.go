@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	ierrors "github.com/l-orlov/task-tracker/internal/errors"
@@ -14,6 +16,10 @@ var (
 	ErrUserNotFound              = errors.New("user not found")
 	ErrEmailIsTaken              = errors.New("user with this email already exists")
 	ErrWrongPassword             = errors.New("wrong password")
+	ErrUserIsDisabled            = errors.New("user is disabled")
+	ErrAdminEmailNotConfirmed    = errors.New("email of administrator is not confirmed")
+	ErrUserOwnsProjects          = errors.New("user owns projects")
+	ErrUserHasAssignedTasks      = errors.New("user has assigned tasks")
 	ErrWrongProjectBoardPartsNum = errors.New("wrong number of project board parts. should be 2")
 )
 
@@ -21,26 +27,52 @@ type (
 	UserService struct {
 		repo                repository.User
 		accessTokenLifetime time.Duration
+		// adminEmail is email of user who is made system administrator when the email is confirmed
+		adminEmail string
 	}
 )
 
 func NewUserService(
-	repo repository.User, tokenLifetime time.Duration,
+	repo repository.User, tokenLifetime time.Duration, adminEmail string,
 ) *UserService {
 	return &UserService{
 		repo:                repo,
 		accessTokenLifetime: tokenLifetime,
+		adminEmail:          adminEmail,
 	}
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user models.UserToCreate) (uint64, error) {
-	existingUser, err := s.repo.GetUserByEmail(ctx, user.Email)
-	if err != nil {
+	if err := s.CheckEmailIsFree(ctx, user.Email); err != nil {
 		return 0, err
 	}
 
-	if existingUser != nil {
-		return 0, ierrors.NewBusiness(ErrEmailIsTaken, "")
+	hashedPassword, err := models.HashPassword(user.Password)
+	if err != nil {
+		return 0, ierrors.New(err)
+	}
+
+	user.Password = hashedPassword
+
+	return s.repo.CreateUser(ctx, user)
+}
+
+// CreateInvitedUser creates user who signs up by project invitation. Email of the user is confirmed
+// by the invitation link and the user is added to the project with invited role together with creation.
+func (s *UserService) CreateInvitedUser(
+	ctx context.Context, user models.UserToCreate, invitation models.ProjectInvitation,
+) (uint64, error) {
+	role := invitation.Role
+	if role == "" {
+		role = models.ProjectRoleMember
+	}
+
+	if !role.IsValid() || role == models.ProjectRoleOwner {
+		return 0, ierrors.NewBusiness(ErrNotValidProjectRole, "")
+	}
+
+	if err := s.CheckEmailIsFree(ctx, user.Email); err != nil {
+		return 0, err
 	}
 
 	hashedPassword, err := models.HashPassword(user.Password)
@@ -50,7 +82,18 @@ func (s *UserService) CreateUser(ctx context.Context, user models.UserToCreate)
 
 	user.Password = hashedPassword
 
-	return s.repo.CreateUser(ctx, user)
+	id, err := s.repo.CreateProjectMember(ctx, user, invitation.ProjectID, role)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.promoteConfiguredAdmin(ctx, models.User{
+		ID: id, Email: user.Email, IsEmailConfirmed: true,
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
 func (s *UserService) GetUserByID(ctx context.Context, id uint64) (*models.User, error) {
@@ -105,18 +148,206 @@ func (s *UserService) ChangeUserPassword(ctx context.Context, userID uint64, old
 	return s.repo.UpdateUserPassword(ctx, userID, hashedPassword)
 }
 
-func (s *UserService) GetAllUsers(ctx context.Context) ([]models.User, error) {
-	return s.repo.GetAllUsers(ctx)
+func (s *UserService) GetAllUsers(ctx context.Context, pageParams models.PageParams) (*models.UserPage, error) {
+	req, err := newPageRequest(pageParams, userSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.repo.GetAllUsers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserPage(users, total, req), nil
 }
 
-func (s *UserService) GetAllUsersWithParameters(ctx context.Context, params models.UserParams) ([]models.User, error) {
-	return s.repo.GetAllUsersWithParameters(ctx, params)
+func (s *UserService) GetAllUsersWithParameters(
+	ctx context.Context, params models.UserParams, pageParams models.PageParams,
+) (*models.UserPage, error) {
+	req, err := newPageRequest(pageParams, userSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.repo.GetAllUsersWithParameters(ctx, params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserPage(users, total, req), nil
 }
 
+// DeleteUser deletes user who owns no projects and has no assigned tasks,
+// so projects are not left without owner and tasks without assignee.
 func (s *UserService) DeleteUser(ctx context.Context, id uint64) error {
+	ownedProjectsNum, err := s.repo.CountUserOwnedProjects(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if ownedProjectsNum > 0 {
+		return ierrors.NewBusiness(ErrUserOwnsProjects,
+			fmt.Sprintf("user owns %d projects, transfer ownership first", ownedProjectsNum))
+	}
+
+	assignedTasksNum, err := s.repo.CountUserAssignedTasks(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if assignedTasksNum > 0 {
+		return ierrors.NewBusiness(ErrUserHasAssignedTasks,
+			fmt.Sprintf("user has %d assigned tasks, reassign them first", assignedTasksNum))
+	}
+
 	return s.repo.DeleteUser(ctx, id)
 }
 
 func (s *UserService) ConfirmEmail(ctx context.Context, id uint64) error {
-	return s.repo.ConfirmEmail(ctx, id)
+	if err := s.repo.ConfirmEmail(ctx, id); err != nil {
+		return err
+	}
+
+	if s.adminEmail == "" {
+		return nil
+	}
+
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil || user == nil {
+		return err
+	}
+
+	return s.promoteConfiguredAdmin(ctx, *user)
+}
+
+// CheckEmailIsFree checks that there is no user with the email.
+func (s *UserService) CheckEmailIsFree(ctx context.Context, email string) error {
+	existingUser, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if existingUser != nil {
+		return ierrors.NewBusiness(ErrEmailIsTaken, "")
+	}
+
+	return nil
+}
+
+// ChangeUserEmail sets confirmed new email of user if it was not taken while waiting for confirmation.
+func (s *UserService) ChangeUserEmail(ctx context.Context, change models.EmailChange) error {
+	user, err := s.repo.GetUserByID(ctx, change.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ierrors.NewBusiness(ErrUserNotFound, "")
+	}
+
+	if err = s.CheckEmailIsFree(ctx, change.Email); err != nil {
+		return err
+	}
+
+	if err = s.repo.UpdateUserEmail(ctx, change.UserID, change.Email); err != nil {
+		return err
+	}
+
+	user.Email = change.Email
+	user.IsEmailConfirmed = true
+
+	return s.promoteConfiguredAdmin(ctx, *user)
+}
+
+func (s *UserService) SetUserAdmin(ctx context.Context, id uint64, isAdmin bool) error {
+	if _, err := s.getExistingUser(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.SetUserAdmin(ctx, id, isAdmin)
+}
+
+// SetUserDisabled disables or enables user. Sessions of disabled user have to be revoked by caller.
+func (s *UserService) SetUserDisabled(ctx context.Context, id uint64, isDisabled bool) error {
+	if _, err := s.getExistingUser(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.SetUserDisabled(ctx, id, isDisabled)
+}
+
+// BootstrapAdmin makes user with configured admin email system administrator if the email is confirmed.
+// If there is no such user or the email is not confirmed yet, the user is made administrator
+// on confirmation of the email, so the address can not be taken over by signing up with it first.
+func (s *UserService) BootstrapAdmin(ctx context.Context) error {
+	user, err := s.repo.GetUserByEmail(ctx, s.adminEmail)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ierrors.NewBusiness(ErrUserNotFound, "")
+	}
+
+	if !user.IsEmailConfirmed {
+		return ierrors.NewBusiness(ErrAdminEmailNotConfirmed, "")
+	}
+
+	return s.promoteConfiguredAdmin(ctx, *user)
+}
+
+// promoteConfiguredAdmin makes user system administrator if the user has confirmed configured admin email.
+func (s *UserService) promoteConfiguredAdmin(ctx context.Context, user models.User) error {
+	if s.adminEmail == "" || user.IsAdmin || !user.IsEmailConfirmed ||
+		!strings.EqualFold(user.Email, s.adminEmail) {
+		return nil
+	}
+
+	return s.repo.SetUserAdmin(ctx, user.ID, true)
+}
+
+func (s *UserService) getExistingUser(ctx context.Context, id uint64) (*models.User, error) {
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ierrors.NewBusiness(ErrUserNotFound, "")
+	}
+
+	return user, nil
+}
+
+func newUserPage(users []models.User, total int64, req models.PageRequest) *models.UserPage {
+	page := &models.UserPage{
+		Items:      users,
+		TotalCount: pageTotalCount(req, total),
+	}
+
+	if len(users) > req.Limit {
+		page.Items = users[:req.Limit]
+		last := page.Items[req.Limit-1]
+		page.NextCursor = encodePageCursor(req, userSortValue(last, req.SortField), last.ID)
+	}
+
+	if page.Items == nil {
+		page.Items = []models.User{}
+	}
+
+	return page
+}
+
+func userSortValue(user models.User, field models.SortField) string {
+	switch field {
+	case models.SortFieldCreatedAt:
+		return models.FormatSortTime(user.CreatedAt)
+	case models.SortFieldUpdatedAt:
+		return models.FormatSortTime(user.UpdatedAt)
+	case models.SortFieldEmail:
+		return user.Email
+	}
+
+	return ""
 }
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+const testAdminEmail = "admin@example.com"
+
+// fakeUserRepo keeps users in memory. Not implemented methods panic.
+type fakeUserRepo struct {
+	repository.User
+	users map[uint64]*models.User
+	// ownedProjects and assignedTasks are numbers of projects and tasks of users
+	ownedProjects map[uint64]int64
+	assignedTasks map[uint64]int64
+	// projectRoles are roles of users in projects added on creation of users
+	projectRoles map[uint64]map[uint64]models.ProjectRole
+}
+
+func newFakeUserRepo(users ...models.User) *fakeUserRepo {
+	r := &fakeUserRepo{users: make(map[uint64]*models.User)}
+	for i := range users {
+		user := users[i]
+		r.users[user.ID] = &user
+	}
+
+	return r
+}
+
+func (r *fakeUserRepo) CreateProjectMember(
+	_ context.Context, user models.UserToCreate, projectID uint64, role models.ProjectRole,
+) (uint64, error) {
+	id := uint64(len(r.users) + 1)
+	r.users[id] = &models.User{
+		ID:               id,
+		Email:            user.Email,
+		Password:         user.Password,
+		IsEmailConfirmed: true,
+	}
+
+	if r.projectRoles == nil {
+		r.projectRoles = make(map[uint64]map[uint64]models.ProjectRole)
+	}
+	r.projectRoles[id] = map[uint64]models.ProjectRole{projectID: role}
+
+	return id, nil
+}
+
+func (r *fakeUserRepo) GetUserByID(_ context.Context, id uint64) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *user
+
+	return &copied, nil
+}
+
+func (r *fakeUserRepo) GetUserByEmail(_ context.Context, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeUserRepo) ConfirmEmail(_ context.Context, id uint64) error {
+	r.users[id].IsEmailConfirmed = true
+	return nil
+}
+
+func (r *fakeUserRepo) UpdateUserEmail(_ context.Context, userID uint64, email string) error {
+	r.users[userID].Email = email
+	r.users[userID].IsEmailConfirmed = true
+	return nil
+}
+
+func (r *fakeUserRepo) DeleteUser(_ context.Context, id uint64) error {
+	delete(r.users, id)
+	return nil
+}
+
+func (r *fakeUserRepo) CountUserOwnedProjects(_ context.Context, userID uint64) (int64, error) {
+	return r.ownedProjects[userID], nil
+}
+
+func (r *fakeUserRepo) CountUserAssignedTasks(_ context.Context, userID uint64) (int64, error) {
+	return r.assignedTasks[userID], nil
+}
+
+func (r *fakeUserRepo) SetUserAdmin(_ context.Context, id uint64, isAdmin bool) error {
+	r.users[id].IsAdmin = isAdmin
+	return nil
+}
+
+func TestUserService_BootstrapAdmin(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *models.User
+		wantErr     error
+		wantIsAdmin bool
+	}{
+		{
+			name:    "no user",
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name:    "not confirmed email",
+			user:    &models.User{ID: 1, Email: testAdminEmail},
+			wantErr: ErrAdminEmailNotConfirmed,
+		},
+		{
+			name:        "confirmed email",
+			user:        &models.User{ID: 1, Email: testAdminEmail, IsEmailConfirmed: true},
+			wantIsAdmin: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeUserRepo()
+			if tt.user != nil {
+				repo = newFakeUserRepo(*tt.user)
+			}
+
+			s := NewUserService(repo, 0, testAdminEmail)
+
+			err := s.BootstrapAdmin(context.Background())
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("BootstrapAdmin() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.user != nil && repo.users[tt.user.ID].IsAdmin != tt.wantIsAdmin {
+				t.Errorf("IsAdmin = %v, want %v", repo.users[tt.user.ID].IsAdmin, tt.wantIsAdmin)
+			}
+		})
+	}
+}
+
+func TestUserService_ConfirmEmailPromotesAdmin(t *testing.T) {
+	repo := newFakeUserRepo(
+		models.User{ID: 1, Email: testAdminEmail},
+		models.User{ID: 2, Email: "user@example.com"},
+	)
+	s := NewUserService(repo, 0, testAdminEmail)
+
+	for _, id := range []uint64{1, 2} {
+		if err := s.ConfirmEmail(context.Background(), id); err != nil {
+			t.Fatalf("ConfirmEmail(%d) error = %v", id, err)
+		}
+	}
+
+	if !repo.users[1].IsAdmin {
+		t.Error("user with admin email is not made administrator after confirmation")
+	}
+
+	if repo.users[2].IsAdmin {
+		t.Error("user with other email is made administrator")
+	}
+}
+
+func TestUserService_ChangeUserEmailPromotesAdmin(t *testing.T) {
+	repo := newFakeUserRepo(models.User{ID: 1, Email: "user@example.com", IsEmailConfirmed: true})
+	s := NewUserService(repo, 0, testAdminEmail)
+
+	if err := s.ChangeUserEmail(context.Background(), models.EmailChange{
+		UserID: 1,
+		Email:  testAdminEmail,
+	}); err != nil {
+		t.Fatalf("ChangeUserEmail() error = %v", err)
+	}
+
+	if !repo.users[1].IsAdmin {
+		t.Error("user is not made administrator after confirmation of admin email")
+	}
+}
+
+func TestUserService_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		ownedProjects int64
+		assignedTasks int64
+		wantErr       error
+	}{
+		{
+			name: "user without projects and tasks",
+		},
+		{
+			name:          "project owner",
+			ownedProjects: 1,
+			wantErr:       ErrUserOwnsProjects,
+		},
+		{
+			name:          "assignee of tasks",
+			assignedTasks: 2,
+			wantErr:       ErrUserHasAssignedTasks,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeUserRepo(models.User{ID: 1, Email: "user@example.com"})
+			repo.ownedProjects = map[uint64]int64{1: tt.ownedProjects}
+			repo.assignedTasks = map[uint64]int64{1: tt.assignedTasks}
+
+			err := NewUserService(repo, 0, "").DeleteUser(context.Background(), 1)
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("DeleteUser() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if _, isKept := repo.users[1]; isKept != (tt.wantErr != nil) {
+				t.Errorf("user is kept = %v, want %v", isKept, tt.wantErr != nil)
+			}
+		})
+	}
+}
+
+func TestUserService_CreateInvitedUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		email       string
+		role        models.ProjectRole
+		wantErr     error
+		wantRole    models.ProjectRole
+		wantIsAdmin bool
+	}{
+		{
+			name:     "default role",
+			email:    "user@example.com",
+			wantRole: models.ProjectRoleMember,
+		},
+		{
+			name:     "invited role",
+			email:    "user@example.com",
+			role:     models.ProjectRoleViewer,
+			wantRole: models.ProjectRoleViewer,
+		},
+		{
+			name:    "owner role",
+			email:   "user@example.com",
+			role:    models.ProjectRoleOwner,
+			wantErr: ErrNotValidProjectRole,
+		},
+		{
+			name:    "taken email",
+			email:   "existing@example.com",
+			wantErr: ErrEmailIsTaken,
+		},
+		{
+			name:        "admin email",
+			email:       testAdminEmail,
+			wantRole:    models.ProjectRoleMember,
+			wantIsAdmin: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeUserRepo(models.User{ID: 1, Email: "existing@example.com"})
+			s := NewUserService(repo, 0, testAdminEmail)
+
+			id, err := s.CreateInvitedUser(context.Background(), models.UserToCreate{
+				Email:    tt.email,
+				Password: "password",
+			}, models.ProjectInvitation{ProjectID: 10, Email: tt.email, Role: tt.role})
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("CreateInvitedUser() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(repo.users) != 1 {
+					t.Errorf("user is created on error")
+				}
+				return
+			}
+
+			user := repo.users[id]
+			if !user.IsEmailConfirmed || user.IsAdmin != tt.wantIsAdmin {
+				t.Errorf("user is email confirmed = %v, is admin = %v", user.IsEmailConfirmed, user.IsAdmin)
+			}
+
+			if !models.CheckPasswordHash(user.Password, "password") {
+				t.Error("password is not hashed")
+			}
+
+			if role := repo.projectRoles[id][10]; role != tt.wantRole {
+				t.Errorf("project role = %q, want %q", role, tt.wantRole)
+			}
+		})
+	}
+}
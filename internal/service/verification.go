@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/l-orlov/task-tracker/internal/models"
 	"github.com/l-orlov/task-tracker/internal/repository"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -13,6 +14,8 @@ const (
 
 	emailConfirmationTokenPrefix       = "ec"
 	passwordResetConfirmTokenKeyPrefix = "rpc"
+	projectInvitationTokenPrefix       = "pi"
+	emailChangeTokenPrefix             = "ech"
 )
 
 type (
@@ -91,6 +94,69 @@ func (s *VerificationService) VerifyPasswordResetConfirmToken(confirmToken strin
 	return userID, nil
 }
 
+func (s *VerificationService) CreateEmailChangeToken(change models.EmailChange) (string, error) {
+	token, err := s.generateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	changeToken := emailChangeTokenPrefix + token
+
+	err = s.repo.PutEmailChangeToken(change, changeToken)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to put email change token to cache")
+	}
+
+	return changeToken, nil
+}
+
+func (s *VerificationService) VerifyEmailChangeToken(changeToken string) (*models.EmailChange, error) {
+	change, err := s.repo.GetEmailChangeTokenData(changeToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get email change token data from cache")
+	}
+
+	if err = s.repo.DeleteEmailChangeToken(changeToken); err != nil {
+		s.log.Error(errors.Wrap(err, "failed to delete email change token from cache"))
+	}
+
+	return change, nil
+}
+
+func (s *VerificationService) CreateProjectInvitationToken(invitation models.ProjectInvitation) (string, error) {
+	token, err := s.generateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	invitationToken := projectInvitationTokenPrefix + token
+
+	err = s.repo.PutProjectInvitationToken(invitation, invitationToken)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to put project invitation token to cache")
+	}
+
+	return invitationToken, nil
+}
+
+// GetProjectInvitation returns invitation by token. Token stays valid until it is deleted.
+func (s *VerificationService) GetProjectInvitation(invitationToken string) (*models.ProjectInvitation, error) {
+	invitation, err := s.repo.GetProjectInvitationTokenData(invitationToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get project invitation token data from cache")
+	}
+
+	return invitation, nil
+}
+
+func (s *VerificationService) DeleteProjectInvitationToken(invitationToken string) error {
+	if err := s.repo.DeleteProjectInvitationToken(invitationToken); err != nil {
+		return errors.Wrap(err, "failed to delete project invitation token from cache")
+	}
+
+	return nil
+}
+
 func (s *VerificationService) generateRandomToken() (string, error) {
 	randomToken, err := s.generator.Generate(
 		randomTokenLength, randomTokenDigitsNum, randomTokenSymbolsNum, false, false,
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/l-orlov/task-tracker/pkg/webhook"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	webhookDeliveriesDefaultLimit = 50
+	webhookDeliveriesMaxLimit     = 200
+)
+
+type (
+	WebhookService struct {
+		log    *logrus.Entry
+		repo   repository.Webhook
+		sender webhook.Sender
+	}
+)
+
+func NewWebhookService(log *logrus.Entry, repo repository.Webhook, sender webhook.Sender) *WebhookService {
+	return &WebhookService{
+		log:    log,
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook models.WebhookToCreate) (uint64, error) {
+	return s.repo.CreateWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) GetWebhookByID(ctx context.Context, id uint64) (*models.Webhook, error) {
+	return s.repo.GetWebhookByID(ctx, id)
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook models.WebhookToUpdate) error {
+	return s.repo.UpdateWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) GetAllProjectWebhooks(ctx context.Context, projectID uint64) ([]models.Webhook, error) {
+	return s.repo.GetAllProjectWebhooks(ctx, projectID)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uint64) error {
+	return s.repo.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookService) GetWebhookDeliveries(
+	ctx context.Context, webhookID uint64, limit int,
+) ([]models.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = webhookDeliveriesDefaultLimit
+	} else if limit > webhookDeliveriesMaxLimit {
+		limit = webhookDeliveriesMaxLimit
+	}
+
+	return s.repo.GetWebhookDeliveries(ctx, webhookID, limit)
+}
+
+// NotifyProjectEvent queues delivery of the event to webhooks of the project.
+// Errors are only logged because notification should not fail the event action.
+func (s *WebhookService) NotifyProjectEvent(
+	ctx context.Context, projectID uint64, event models.WebhookEvent, data interface{},
+) {
+	webhooks, err := s.repo.GetProjectWebhooksByEvent(ctx, projectID, event)
+	if err != nil {
+		s.log.Errorf("failed to get webhooks of project %d for event %s: %v", projectID, event, err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(models.WebhookPayload{
+		Event:      event,
+		ProjectID:  projectID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		s.log.Errorf("failed to marshal webhook payload for event %s: %v", event, err)
+		return
+	}
+
+	for _, wh := range webhooks {
+		s.sender.Send(webhook.Delivery{
+			URL:       wh.URL,
+			Secret:    wh.Secret,
+			Event:     string(event),
+			Payload:   payload,
+			OnAttempt: s.saveDeliveryAttemptFunc(wh.ID, event, payload),
+		})
+	}
+}
+
+// saveDeliveryAttemptFunc returns function saving delivery attempts to the delivery log.
+func (s *WebhookService) saveDeliveryAttemptFunc(
+	webhookID uint64, event models.WebhookEvent, payload []byte,
+) func(result webhook.AttemptResult) {
+	return func(result webhook.AttemptResult) {
+		delivery := models.WebhookDelivery{
+			WebhookID: webhookID,
+			Event:     event,
+			Payload:   payload,
+			Attempt:   result.Attempt,
+			IsSuccess: result.IsSuccess,
+		}
+
+		if result.StatusCode != 0 {
+			delivery.StatusCode = &result.StatusCode
+		}
+
+		if result.Err != nil {
+			delivery.Error = result.Err.Error()
+		}
+
+		if err := s.repo.CreateWebhookDelivery(context.Background(), delivery); err != nil {
+			s.log.Errorf("failed to save delivery of webhook %d: %v", webhookID, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	ierrors "github.com/l-orlov/task-tracker/internal/errors"
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrWorklogNotFound  = errors.New("worklog not found")
+	ErrNotWorklogAuthor = errors.New("user is not an author of the worklog")
+)
+
+type WorklogService struct {
+	repo repository.Worklog
+}
+
+func NewWorklogService(repo repository.Worklog) *WorklogService {
+	return &WorklogService{repo: repo}
+}
+
+func (s *WorklogService) CreateWorklog(
+	ctx context.Context, worklog models.WorklogToCreate, userID uint64,
+) (uint64, error) {
+	return s.repo.CreateWorklog(ctx, worklog, userID)
+}
+
+func (s *WorklogService) GetWorklogByID(ctx context.Context, id uint64) (*models.Worklog, error) {
+	return s.repo.GetWorklogByID(ctx, id)
+}
+
+// UpdateWorklog updates worklog if user is its author.
+func (s *WorklogService) UpdateWorklog(
+	ctx context.Context, id, userID uint64, worklog models.WorklogToUpdate,
+) error {
+	current, err := s.repo.GetWorklogByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return ierrors.NewBusiness(ErrWorklogNotFound, "")
+	}
+
+	if !current.IsLoggedBy(userID) {
+		return ierrors.NewForbidden(ErrNotWorklogAuthor, "")
+	}
+
+	return s.repo.UpdateWorklog(ctx, id, worklog)
+}
+
+func (s *WorklogService) GetAllTaskWorklogs(ctx context.Context, taskID uint64) ([]models.Worklog, error) {
+	return s.repo.GetAllTaskWorklogs(ctx, taskID)
+}
+
+func (s *WorklogService) DeleteWorklog(ctx context.Context, id uint64) error {
+	return s.repo.DeleteWorklog(ctx, id)
+}
+
+// GetTimesheet returns time logged per user per day in tasks of projects where viewer is a member.
+// Only time of the user is returned if user id is not nil.
+func (s *WorklogService) GetTimesheet(
+	ctx context.Context, viewerID uint64, userID *uint64, period models.ReportPeriod,
+) (*models.Timesheet, error) {
+	period, err := normalizeReportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.GetTimesheet(ctx, viewerID, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = []models.TimesheetEntry{}
+	}
+
+	return &models.Timesheet{
+		ReportPeriod: period,
+		Entries:      entries,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/l-orlov/task-tracker/internal/models"
+	"github.com/l-orlov/task-tracker/internal/repository"
+	"github.com/pkg/errors"
+)
+
+// fakeWorklogRepo keeps worklogs in memory. Not implemented methods panic.
+type fakeWorklogRepo struct {
+	repository.Worklog
+	worklogs map[uint64]*models.Worklog
+}
+
+func (r *fakeWorklogRepo) GetWorklogByID(_ context.Context, id uint64) (*models.Worklog, error) {
+	worklog, ok := r.worklogs[id]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := *worklog
+
+	return &copied, nil
+}
+
+func (r *fakeWorklogRepo) UpdateWorklog(_ context.Context, id uint64, worklog models.WorklogToUpdate) error {
+	r.worklogs[id].Duration = worklog.Duration
+	r.worklogs[id].Note = worklog.Note
+	return nil
+}
+
+func TestWorklogService_UpdateWorklog(t *testing.T) {
+	authorID := uint64(1)
+
+	tests := []struct {
+		name    string
+		worklog *models.Worklog
+		userID  uint64
+		wantErr error
+	}{
+		{
+			name:    "author",
+			worklog: &models.Worklog{ID: 1, UserID: &authorID},
+			userID:  authorID,
+		},
+		{
+			name:    "not author",
+			worklog: &models.Worklog{ID: 1, UserID: &authorID},
+			userID:  2,
+			wantErr: ErrNotWorklogAuthor,
+		},
+		{
+			name:    "worklog of deleted user",
+			worklog: &models.Worklog{ID: 1},
+			userID:  authorID,
+			wantErr: ErrNotWorklogAuthor,
+		},
+		{
+			name:    "no worklog",
+			userID:  authorID,
+			wantErr: ErrWorklogNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeWorklogRepo{worklogs: make(map[uint64]*models.Worklog)}
+			if tt.worklog != nil {
+				repo.worklogs[tt.worklog.ID] = tt.worklog
+			}
+
+			err := NewWorklogService(repo).UpdateWorklog(context.Background(), 1, tt.userID, models.WorklogToUpdate{
+				Duration: 30,
+				Note:     "updated",
+			})
+			if !errors.Is(errorCause(err), tt.wantErr) {
+				t.Fatalf("UpdateWorklog() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.worklog != nil && (repo.worklogs[1].Note == "updated") != (tt.wantErr == nil) {
+				t.Errorf("worklog is updated = %v, want %v", repo.worklogs[1].Note == "updated", tt.wantErr == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Package taskfilter parses filter queries of tasks, for example:
+//
+//	assignee:me status:"IN PROGRESS" importance:HIGH due<2026-11-01 -label:blocked login bug
+//
+// Query consists of conditions `field<operator>value` separated by spaces. Condition prefixed with "-" is negated.
+// Value with spaces is quoted, quote and backslash inside quoted value are escaped with backslash.
+// Words which are not conditions are searched in task title and description.
+package taskfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	FieldAssignee   Field = "assignee"
+	FieldStatus     Field = "status"
+	FieldImportance Field = "importance"
+	FieldLabel      Field = "label"
+	FieldDue        Field = "due"
+	FieldStart      Field = "start"
+	FieldCreated    Field = "created"
+	FieldUpdated    Field = "updated"
+	FieldIs         Field = "is"
+
+	OperatorEqual          Operator = ":"
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+
+	// ValueMe is assignee value meaning user running the filter
+	ValueMe = "me"
+	// ValueNone is date value meaning date is not set
+	ValueNone    = "none"
+	ValueOverdue = "overdue"
+	ValueOpen    = "open"
+	ValueDone    = "done"
+
+	DateLayout = "2006-01-02"
+
+	maxConditions = 20
+)
+
+type (
+	Field     string
+	Operator  string
+	Condition struct {
+		Field    Field
+		Operator Operator
+		// Value is validated value of condition. Keywords are in lower case.
+		Value   string
+		Negated bool
+	}
+	Filter struct {
+		Conditions []Condition
+		// Text is free text searched in task title and description in web search syntax.
+		Text string
+	}
+	// SyntaxError is error of query parsing with position of wrong term in query.
+	SyntaxError struct {
+		Pos int
+		Msg string
+	}
+)
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("not valid filter: %s at position %d", e.Msg, e.Pos)
+}
+
+// Parse parses filter query. Empty query matches all tasks.
+func Parse(query string) (*Filter, error) {
+	p := &parser{input: []rune(query)}
+
+	return p.parse()
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) parse() (*Filter, error) {
+	filter := &Filter{}
+	var words []string
+
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			break
+		}
+
+		start := p.pos
+		negated := false
+		if p.peek() == '-' {
+			negated = true
+			p.pos++
+		}
+
+		name := p.readIdent()
+		if op := p.readOperator(); name != "" && op != "" {
+			value, _, err := p.readValue()
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: err.Error()}
+			}
+
+			cond, err := newCondition(Field(strings.ToLower(name)), op, value, negated)
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: err.Error()}
+			}
+
+			if len(filter.Conditions) == maxConditions {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("more than %d conditions", maxConditions)}
+			}
+
+			filter.Conditions = append(filter.Conditions, cond)
+			continue
+		}
+
+		// not a condition, so it is a word of free text
+		p.pos = start
+		if negated {
+			p.pos++
+		}
+
+		word, quoted, err := p.readValue()
+		if err != nil {
+			return nil, &SyntaxError{Pos: start, Msg: err.Error()}
+		}
+
+		words = append(words, textWord(word, quoted, negated))
+	}
+
+	filter.Text = strings.Join(words, " ")
+
+	return filter, nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) readIdent() string {
+	start := p.pos
+	for !p.eof() && unicode.IsLetter(p.peek()) {
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+func (p *parser) readOperator() Operator {
+	if p.eof() {
+		return ""
+	}
+
+	switch p.peek() {
+	case ':':
+		p.pos++
+		return OperatorEqual
+	case '<', '>':
+		op := string(p.peek())
+		p.pos++
+		if !p.eof() && p.peek() == '=' {
+			op += "="
+			p.pos++
+		}
+
+		return Operator(op)
+	}
+
+	return ""
+}
+
+// readValue reads quoted or bare value and returns it unquoted.
+func (p *parser) readValue() (value string, quoted bool, err error) {
+	if p.eof() || unicode.IsSpace(p.peek()) {
+		return "", false, fmt.Errorf("missing value")
+	}
+
+	if p.peek() != '"' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.peek()) {
+			p.pos++
+		}
+
+		return string(p.input[start:p.pos]), false, nil
+	}
+
+	p.pos++ // opening quote
+
+	var sb strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+
+		switch {
+		case r == '\\' && !p.eof():
+			sb.WriteRune(p.peek())
+			p.pos++
+		case r == '"':
+			if sb.Len() == 0 {
+				return "", false, fmt.Errorf("empty value")
+			}
+
+			return sb.String(), true, nil
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return "", false, fmt.Errorf("unterminated quoted value")
+}
+
+func newCondition(field Field, op Operator, value string, negated bool) (Condition, error) {
+	cond := Condition{
+		Field:    field,
+		Operator: op,
+		Value:    value,
+		Negated:  negated,
+	}
+
+	switch field {
+	case FieldAssignee:
+		if op != OperatorEqual {
+			return Condition{}, operatorError(field, op)
+		}
+
+		if strings.EqualFold(value, ValueMe) {
+			cond.Value = ValueMe
+		} else if !strings.Contains(value, "@") {
+			// id is compared as BIGINT, so it should fit into int64
+			if id, err := strconv.ParseInt(value, 10, 64); err != nil || id <= 0 {
+				return Condition{}, fmt.Errorf("assignee should be %q, user id or email", ValueMe)
+			}
+		}
+	case FieldStatus, FieldImportance, FieldLabel:
+		if op != OperatorEqual {
+			return Condition{}, operatorError(field, op)
+		}
+	case FieldIs:
+		if op != OperatorEqual {
+			return Condition{}, operatorError(field, op)
+		}
+
+		cond.Value = strings.ToLower(value)
+		if cond.Value != ValueOverdue && cond.Value != ValueOpen && cond.Value != ValueDone {
+			return Condition{}, fmt.Errorf("%s should be one of: %s, %s, %s", field, ValueOverdue, ValueOpen, ValueDone)
+		}
+	case FieldDue, FieldStart:
+		if strings.EqualFold(value, ValueNone) {
+			if op != OperatorEqual {
+				return Condition{}, operatorError(field, op)
+			}
+
+			cond.Value = ValueNone
+			break
+		}
+
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			return Condition{}, fmt.Errorf("%s should be date in format YYYY-MM-DD or %q", field, ValueNone)
+		}
+	case FieldCreated, FieldUpdated:
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			return Condition{}, fmt.Errorf("%s should be date in format YYYY-MM-DD", field)
+		}
+	default:
+		return Condition{}, fmt.Errorf("unknown field %q", field)
+	}
+
+	return cond, nil
+}
+
+func operatorError(field Field, op Operator) error {
+	return fmt.Errorf("operator %q is not supported by %s", op, field)
+}
+
+// textWord returns word of free text in web search syntax.
+func textWord(word string, quoted, negated bool) string {
+	// quotes inside word would change meaning of web search query
+	word = strings.ReplaceAll(word, `"`, "")
+	if quoted {
+		word = `"` + word + `"`
+	}
+
+	if negated {
+		word = "-" + word
+	}
+
+	return word
+}
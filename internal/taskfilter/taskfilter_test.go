@@ -0,0 +1,218 @@
+package taskfilter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *Filter
+	}{
+		{
+			name:  "empty query",
+			query: "  ",
+			want:  &Filter{},
+		},
+		{
+			name:  "conditions and text",
+			query: `assignee:me status:"IN PROGRESS" importance:HIGH due<2026-11-01 -label:blocked login bug`,
+			want: &Filter{
+				Conditions: []Condition{
+					{Field: FieldAssignee, Operator: OperatorEqual, Value: ValueMe},
+					{Field: FieldStatus, Operator: OperatorEqual, Value: "IN PROGRESS"},
+					{Field: FieldImportance, Operator: OperatorEqual, Value: "HIGH"},
+					{Field: FieldDue, Operator: OperatorLess, Value: "2026-11-01"},
+					{Field: FieldLabel, Operator: OperatorEqual, Value: "blocked", Negated: true},
+				},
+				Text: "login bug",
+			},
+		},
+		{
+			name:  "keywords and field names in any case",
+			query: "Assignee:ME IS:Overdue due:NONE",
+			want: &Filter{
+				Conditions: []Condition{
+					{Field: FieldAssignee, Operator: OperatorEqual, Value: ValueMe},
+					{Field: FieldIs, Operator: OperatorEqual, Value: ValueOverdue},
+					{Field: FieldDue, Operator: OperatorEqual, Value: ValueNone},
+				},
+			},
+		},
+		{
+			name:  "assignee by id and email",
+			query: "assignee:42 -assignee:john@example.com",
+			want: &Filter{
+				Conditions: []Condition{
+					{Field: FieldAssignee, Operator: OperatorEqual, Value: "42"},
+					{Field: FieldAssignee, Operator: OperatorEqual, Value: "john@example.com", Negated: true},
+				},
+			},
+		},
+		{
+			name:  "date operators",
+			query: "start>=2026-01-01 created>2026-01-02 updated<=2026-01-03",
+			want: &Filter{
+				Conditions: []Condition{
+					{Field: FieldStart, Operator: OperatorGreaterOrEqual, Value: "2026-01-01"},
+					{Field: FieldCreated, Operator: OperatorGreater, Value: "2026-01-02"},
+					{Field: FieldUpdated, Operator: OperatorLessOrEqual, Value: "2026-01-03"},
+				},
+			},
+		},
+		{
+			name:  "escaped quote and backslash",
+			query: `label:"say \"hi\" \\ bye"`,
+			want: &Filter{
+				Conditions: []Condition{
+					{Field: FieldLabel, Operator: OperatorEqual, Value: `say "hi" \ bye`},
+				},
+			},
+		},
+		{
+			name:  "quoted and negated text",
+			query: `"exact phrase" -draft`,
+			want:  &Filter{Text: `"exact phrase" -draft`},
+		},
+		{
+			name:  "quotes are removed from bare text word",
+			query: `log"in`,
+			want:  &Filter{Text: "login"},
+		},
+		{
+			name:  "word with colon but without field name is text",
+			query: "10:30 :x",
+			want:  &Filter{Text: "10:30 :x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantPos int
+		wantMsg string
+	}{
+		{
+			name:    "unknown field",
+			query:   "status:open color:red",
+			wantPos: 12,
+			wantMsg: `unknown field "color"`,
+		},
+		{
+			name:    "missing value",
+			query:   "status: bug",
+			wantPos: 0,
+			wantMsg: "missing value",
+		},
+		{
+			name:    "missing value at end",
+			query:   "bug -label:",
+			wantPos: 4,
+			wantMsg: "missing value",
+		},
+		{
+			name:    "unterminated quoted value",
+			query:   `status:"in progress`,
+			wantPos: 0,
+			wantMsg: "unterminated quoted value",
+		},
+		{
+			name:    "empty quoted value",
+			query:   `label:""`,
+			wantPos: 0,
+			wantMsg: "empty value",
+		},
+		{
+			name:    "unterminated quoted text",
+			query:   `bug "login`,
+			wantPos: 4,
+			wantMsg: "unterminated quoted value",
+		},
+		{
+			name:    "not supported operator",
+			query:   "status>open",
+			wantPos: 0,
+			wantMsg: `operator ">" is not supported by status`,
+		},
+		{
+			name:    "not valid date",
+			query:   "due<2026-13-01",
+			wantPos: 0,
+			wantMsg: "due should be date in format YYYY-MM-DD",
+		},
+		{
+			name:    "none with comparison",
+			query:   "due<none",
+			wantPos: 0,
+			wantMsg: `operator "<" is not supported by due`,
+		},
+		{
+			name:    "not valid assignee",
+			query:   "assignee:john",
+			wantPos: 0,
+			wantMsg: "assignee should be",
+		},
+		{
+			name:    "assignee id is out of range",
+			query:   "assignee:18446744073709551615",
+			wantPos: 0,
+			wantMsg: "assignee should be",
+		},
+		{
+			name:    "assignee id is not positive",
+			query:   "assignee:0",
+			wantPos: 0,
+			wantMsg: "assignee should be",
+		},
+		{
+			name:    "not valid is value",
+			query:   "is:blocked",
+			wantPos: 0,
+			wantMsg: "is should be one of",
+		},
+		{
+			name:    "too many conditions",
+			query:   strings.Repeat("is:open ", maxConditions+1),
+			wantPos: maxConditions * len("is:open "),
+			wantMsg: "more than 20 conditions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want syntax error", tt.query, err)
+			}
+
+			if syntaxErr.Pos != tt.wantPos {
+				t.Errorf("position = %d, want %d", syntaxErr.Pos, tt.wantPos)
+			}
+
+			if !strings.Contains(syntaxErr.Msg, tt.wantMsg) {
+				t.Errorf("message = %q, want it to contain %q", syntaxErr.Msg, tt.wantMsg)
+			}
+		})
+	}
+}
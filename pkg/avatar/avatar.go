@@ -0,0 +1,179 @@
+// Package avatar prepares user avatars: decodes uploaded images, crops them to square,
+// resizes them and generates initials avatars for users without uploaded image.
+package avatar
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	// decoders of supported formats
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	ContentTypePNG = "image/png"
+	ContentTypeSVG = "image/svg+xml"
+)
+
+var (
+	ErrNotValidImage = errors.New("not valid image")
+	ErrTooLargeImage = errors.New("image is too large")
+)
+
+// backgroundColors are colors of initials avatars chosen by user id.
+var backgroundColors = []string{
+	"#e57373", "#f06292", "#ba68c8", "#7986cb", "#4fc3f7", "#4db6ac", "#81c784", "#ffb74d", "#a1887f", "#90a4ae",
+}
+
+// Decode decodes image in PNG, JPEG or GIF format. Image with side larger than maxDimension
+// is rejected before decoding of its pixels.
+func Decode(data []byte, maxDimension int) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotValidImage
+	}
+
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, ErrNotValidImage
+	}
+
+	if cfg.Width > maxDimension || cfg.Height > maxDimension {
+		return nil, ErrTooLargeImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotValidImage
+	}
+
+	return img, nil
+}
+
+// Square crops the largest centered square of image and resizes it to every size.
+// Resized images are encoded to PNG and returned in order of sizes.
+func Square(img image.Image, sizes []int) ([][]byte, error) {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+
+	cropRect := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), img, cropRect.Min, draw.Src)
+
+	// large image is resized once to the largest size and smaller sizes are made from it
+	maxSize := 0
+	for _, size := range sizes {
+		if size > maxSize {
+			maxSize = size
+		}
+	}
+
+	if side > maxSize {
+		square = resize(square, maxSize)
+	}
+
+	images := make([][]byte, 0, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, resize(square, size)); err != nil {
+			return nil, err
+		}
+
+		images = append(images, buf.Bytes())
+	}
+
+	return images, nil
+}
+
+// resize scales square image to size x size. Every destination pixel is average of source pixels
+// covered by it weighted by covered area, so downscaling does not produce aliasing.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	srcSize := src.Bounds().Dx()
+	scale := float64(srcSize) / float64(size)
+
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := float64(dy)*scale, float64(dy+1)*scale
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := float64(dx)*scale, float64(dx+1)*scale
+
+			var sum [4]float64
+			var weightSum float64
+			for sy := int(sy0); sy < srcSize && float64(sy) < sy1; sy++ {
+				wy := coverage(sy0, sy1, sy)
+				for sx := int(sx0); sx < srcSize && float64(sx) < sx1; sx++ {
+					w := wy * coverage(sx0, sx1, sx)
+					i := src.PixOffset(sx, sy)
+					for c := 0; c < 4; c++ {
+						sum[c] += float64(src.Pix[i+c]) * w
+					}
+					weightSum += w
+				}
+			}
+
+			// pixels are alpha-premultiplied, so channels are averaged independently
+			i := dst.PixOffset(dx, dy)
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8(sum[c]/weightSum + 0.5)
+			}
+		}
+	}
+
+	return dst
+}
+
+// coverage returns length of intersection of segment [lo, hi) with pixel [i, i+1).
+func coverage(lo, hi float64, i int) float64 {
+	start, end := float64(i), float64(i+1)
+	if lo > start {
+		start = lo
+	}
+
+	if hi < end {
+		end = hi
+	}
+
+	return end - start
+}
+
+// Initials returns up to two upper case initials of user name.
+func Initials(firstName, lastName string) string {
+	var initials string
+	for _, name := range []string{firstName, lastName} {
+		name = strings.TrimSpace(name)
+		if r, _ := utf8.DecodeRuneInString(name); r != utf8.RuneError {
+			initials += string(unicode.ToUpper(r))
+		}
+	}
+
+	if initials == "" {
+		return "?"
+	}
+
+	return initials
+}
+
+// InitialsSVG returns square SVG avatar of the size with initials on background color chosen by seed.
+func InitialsSVG(initials string, size int, seed uint64) []byte {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(initials))
+
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 100 100">`+
+		`<rect width="100" height="100" fill="%s"/>`+
+		`<text x="50" y="50" dy=".35em" fill="#ffffff" font-family="Helvetica, Arial, sans-serif" font-size="40" `+
+		`text-anchor="middle">%s</text></svg>`,
+		size, size, backgroundColors[seed%uint64(len(backgroundColors))], escaped.String()))
+}
@@ -0,0 +1,199 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+var (
+	red   = color.RGBA{R: 255, A: 255}
+	green = color.RGBA{G: 255, A: 255}
+	blue  = color.RGBA{B: 255, A: 255}
+)
+
+// newStripesImage returns image of equal vertical stripes of the colors.
+func newStripesImage(stripeWidth, height int, colors ...color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, stripeWidth*len(colors), height))
+	for x := 0; x < img.Bounds().Dx(); x++ {
+		for y := 0; y < height; y++ {
+			img.SetRGBA(x, y, colors[x/stripeWidth])
+		}
+	}
+
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "valid image",
+			data: encodePNG(t, image.NewRGBA(image.Rect(0, 0, 16, 8))),
+		},
+		{
+			name:    "too wide image",
+			data:    encodePNG(t, image.NewRGBA(image.Rect(0, 0, 17, 8))),
+			wantErr: ErrTooLargeImage,
+		},
+		{
+			name:    "too high image",
+			data:    encodePNG(t, image.NewRGBA(image.Rect(0, 0, 8, 17))),
+			wantErr: ErrTooLargeImage,
+		},
+		{
+			name:    "not image",
+			data:    []byte("<svg></svg>"),
+			wantErr: ErrNotValidImage,
+		},
+		{
+			name:    "truncated image",
+			data:    encodePNG(t, image.NewRGBA(image.Rect(0, 0, 16, 8)))[:60],
+			wantErr: ErrNotValidImage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Decode(tt.data, 16)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && img == nil {
+				t.Error("Decode() image is nil")
+			}
+		})
+	}
+}
+
+func TestSquare(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{
+			name: "wide image",
+			img:  newStripesImage(100, 100, red, blue, green),
+		},
+		{
+			name: "wide image smaller than sizes",
+			img:  newStripesImage(10, 10, red, blue, green),
+		},
+		{
+			name: "high image",
+			img:  newStripesImage(100, 300, blue),
+		},
+		{
+			name: "image with not zero bounds origin",
+			img:  newStripesImage(50, 50, red, blue, green).SubImage(image.Rect(40, 0, 110, 50)),
+		},
+	}
+
+	sizes := []int{32, 64, 16}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images, err := Square(tt.img, sizes)
+			if err != nil {
+				t.Fatalf("Square() error = %v", err)
+			}
+
+			if len(images) != len(sizes) {
+				t.Fatalf("Square() returned %d images, want %d", len(images), len(sizes))
+			}
+
+			for i, data := range images {
+				img, err := png.Decode(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("image %d is not valid png: %v", i, err)
+				}
+
+				if got := img.Bounds(); got.Dx() != sizes[i] || got.Dy() != sizes[i] {
+					t.Fatalf("image %d has size %dx%d, want %dx%d", i, got.Dx(), got.Dy(), sizes[i], sizes[i])
+				}
+
+				// centered square is cropped, so only color of the middle is left
+				for x := 0; x < sizes[i]; x++ {
+					for y := 0; y < sizes[i]; y++ {
+						if got := color.RGBAModel.Convert(img.At(x, y)); got != blue {
+							t.Fatalf("image %d pixel (%d, %d) = %v, want %v", i, x, y, got, blue)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	// 2x2 checkerboard is averaged to gray
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.SetRGBA(0, 0, color.RGBA{A: 255})
+	src.SetRGBA(1, 0, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	src.SetRGBA(0, 1, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	src.SetRGBA(1, 1, color.RGBA{A: 255})
+
+	want := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	if got := resize(src, 1).RGBAAt(0, 0); got != want {
+		t.Errorf("resize() pixel = %v, want %v", got, want)
+	}
+
+	// 3 pixels are downscaled to 2 pixels, each covering pixel and half of middle pixel
+	src = newStripesImage(1, 3, color.RGBA{A: 255}, color.RGBA{R: 90, A: 255}, color.RGBA{R: 180, A: 255})
+	dst := resize(src, 2)
+	if got := dst.RGBAAt(0, 0).R; got != 30 {
+		t.Errorf("resize() left pixel red = %d, want 30", got)
+	}
+
+	if got := dst.RGBAAt(1, 0).R; got != 150 {
+		t.Errorf("resize() right pixel red = %d, want 150", got)
+	}
+}
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		firstName string
+		lastName  string
+		want      string
+	}{
+		{firstName: "john", lastName: "smith", want: "JS"},
+		{firstName: " Anna ", lastName: "", want: "A"},
+		{firstName: "", lastName: "ørsted", want: "Ø"},
+		{firstName: "  ", lastName: "", want: "?"},
+	}
+
+	for _, tt := range tests {
+		if got := Initials(tt.firstName, tt.lastName); got != tt.want {
+			t.Errorf("Initials(%q, %q) = %q, want %q", tt.firstName, tt.lastName, got, tt.want)
+		}
+	}
+}
+
+func TestInitialsSVG(t *testing.T) {
+	svg := string(InitialsSVG("<&", 64, uint64(len(backgroundColors))+1))
+
+	for _, want := range []string{`width="64"`, `height="64"`, `fill="` + backgroundColors[1] + `"`, ">&lt;&amp;</text>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("InitialsSVG() = %s, want it to contain %s", svg, want)
+		}
+	}
+}
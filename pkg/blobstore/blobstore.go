@@ -0,0 +1,20 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores binary objects by keys. Key is a slash separated path like "tasks/1/file".
+type BlobStore interface {
+	// Put stores size bytes read from r. Existing blob with the same key is replaced.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns reader of blob content which should be closed by caller.
+	// ErrNotFound is returned if there is no blob with the key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes blob. Deleting of missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
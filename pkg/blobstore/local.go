@@ -0,0 +1,103 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	localDirPerm  = 0o750
+	tmpFilePrefix = ".tmp-"
+)
+
+// localStore stores blobs as files in directory.
+type localStore struct {
+	dir string
+}
+
+// NewLocal creates BlobStore keeping blobs in the directory. Directory is created if it does not exist.
+func NewLocal(dir string) (BlobStore, error) {
+	if err := os.MkdirAll(dir, localDirPerm); err != nil {
+		return nil, err
+	}
+
+	return &localStore{dir: dir}, nil
+}
+
+func (s *localStore) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), localDirPerm); err != nil {
+		return err
+	}
+
+	// write to temporary file first, so readers never see partially written blob
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), tmpFilePrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	written, err := io.Copy(tmpFile, r)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if written != size {
+		return fmt.Errorf("blob size %d does not match expected size %d", written, size)
+	}
+
+	return os.Rename(tmpFile.Name(), filePath)
+}
+
+func (s *localStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *localStore) Delete(_ context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// filePath returns path of blob file. Key can not point outside of the directory.
+func (s *localStore) filePath(key string) (string, error) {
+	cleanKey := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleanKey == "" || cleanKey != key {
+		return "", fmt.Errorf("not valid blob key %q", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(cleanKey)), nil
+}
@@ -0,0 +1,254 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm        = "AWS4-HMAC-SHA256"
+	s3Service          = "s3"
+	s3DateTimeLayout   = "20060102T150405Z"
+	s3DateLayout       = "20060102"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3SignedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+	s3MaxErrorBodySize = 1024
+)
+
+type (
+	S3Config struct {
+		// Endpoint is base URL of S3-compatible service, for example "https://s3.amazonaws.com".
+		Endpoint        string
+		Region          string
+		Bucket          string
+		AccessKeyID     string
+		SecretAccessKey string
+		// UsePathStyle enables addressing bucket in URL path instead of host name.
+		// It is usually required by self-hosted services like MinIO.
+		UsePathStyle bool
+		Timeout      time.Duration
+	}
+	// s3Store stores blobs in bucket of S3-compatible service. Requests are signed with AWS Signature Version 4.
+	s3Store struct {
+		cfg      S3Config
+		endpoint *url.URL
+		client   *http.Client
+	}
+)
+
+// NewS3 creates BlobStore keeping blobs in S3 bucket.
+func NewS3(cfg S3Config) (BlobStore, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("not valid s3 endpoint: %w", err)
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("not valid s3 endpoint %q", cfg.Endpoint)
+	}
+
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("empty s3 bucket")
+	}
+
+	return &s3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		// timeout is not set to client because it would limit time of streaming download
+		client: &http.Client{},
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	body := ioutil.NopCloser(r)
+	if size == 0 {
+		body = http.NoBody
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// withTimeout limits time of request if timeout is set.
+func (s *s3Store) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, s.cfg.Timeout)
+}
+
+// newRequest creates signed request to object with the key.
+func (s *s3Store) newRequest(ctx context.Context, method, key string, body io.ReadCloser) (*http.Request, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty blob key")
+	}
+
+	objectURL := *s.endpoint
+	basePath := strings.TrimSuffix(objectURL.Path, "/")
+	if s.cfg.UsePathStyle {
+		objectURL.Path = basePath + "/" + s.cfg.Bucket + "/" + key
+		objectURL.RawPath = basePath + "/" + s3Escape(s.cfg.Bucket) + "/" + s3Escape(key)
+	} else {
+		objectURL.Host = s.cfg.Bucket + "." + objectURL.Host
+		objectURL.Path = basePath + "/" + key
+		objectURL.RawPath = basePath + "/" + s3Escape(key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sign(req, time.Now().UTC())
+
+	return req, nil
+}
+
+// sign adds authorization headers of AWS Signature Version 4. Payload is not signed to stream it.
+func (s *s3Store) sign(req *http.Request, now time.Time) {
+	dateTime := now.Format(s3DateTimeLayout)
+	date := now.Format(s3DateLayout)
+
+	req.Header.Set("X-Amz-Date", dateTime)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + dateTime,
+		"",
+		s3SignedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3Algorithm, dateTime, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKeyID, scope, s3SignedHeaders, signature))
+}
+
+// do sends request and returns response with successful status. Body of response should be closed by caller.
+func (s *s3Store) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	errBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, s3MaxErrorBodySize))
+
+	return nil, fmt.Errorf("s3 %s request failed with status %d: %s",
+		req.Method, resp.StatusCode, strings.TrimSpace(string(errBody)))
+}
+
+// s3Escape escapes path as it is required by AWS Signature Version 4: all characters except
+// unreserved ones and slash are percent-encoded.
+func s3Escape(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+
+	return sb.String()
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,242 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testS3Region    = "eu-central-1"
+	testS3Bucket    = "attachments"
+	testS3AccessKey = "AKIDEXAMPLE"
+	testS3SecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+type s3Object struct {
+	content     []byte
+	contentType string
+}
+
+// s3Stub is S3-compatible server keeping objects of one bucket in memory.
+// It checks signatures of requests like S3 does.
+type s3Stub struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]s3Object
+}
+
+func newS3Stub(t *testing.T) (*s3Stub, *httptest.Server) {
+	stub := &s3Stub{t: t, objects: make(map[string]s3Object)}
+
+	return stub, httptest.NewServer(stub)
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.checkSignature(r); err != nil {
+		s.t.Errorf("%s %s: %v", r.Method, r.URL.Path, err)
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	prefix := "/" + testS3Bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if int64(len(content)) != r.ContentLength {
+			http.Error(w, "IncompleteBody", http.StatusBadRequest)
+			return
+		}
+
+		s.objects[key] = s3Object{content: content, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", object.contentType)
+		_, _ = w.Write(object.content)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// checkSignature checks AWS Signature Version 4 of request with unsigned payload.
+func (s *s3Stub) checkSignature(r *http.Request) error {
+	dateTime := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse(s3DateTimeLayout, dateTime)
+	if err != nil {
+		return fmt.Errorf("not valid X-Amz-Date %q", dateTime)
+	}
+
+	if time.Since(signedAt) > time.Minute {
+		return fmt.Errorf("request is signed too long ago: %s", dateTime)
+	}
+
+	if payloadHash := r.Header.Get("X-Amz-Content-Sha256"); payloadHash != "UNSIGNED-PAYLOAD" {
+		return fmt.Errorf("X-Amz-Content-Sha256 = %q, want UNSIGNED-PAYLOAD", payloadHash)
+	}
+
+	date := dateTime[:8]
+	scope := date + "/" + testS3Region + "/s3/aws4_request"
+
+	canonicalRequest := r.Method + "\n" +
+		r.URL.EscapedPath() + "\n" +
+		r.URL.RawQuery + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:UNSIGNED-PAYLOAD\n" +
+		"x-amz-date:" + dateTime + "\n" +
+		"\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" +
+		"UNSIGNED-PAYLOAD"
+
+	stringToSign := "AWS4-HMAC-SHA256\n" + dateTime + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+testS3SecretKey), date)
+	key = hmacSHA256(key, testS3Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	want := fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		testS3AccessKey, scope, hex.EncodeToString(hmacSHA256(key, stringToSign)),
+	)
+	if got := r.Header.Get("Authorization"); got != want {
+		return fmt.Errorf("Authorization = %q, want %q", got, want)
+	}
+
+	return nil
+}
+
+func (s *s3Stub) getObject(key string) (s3Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[key]
+
+	return object, ok
+}
+
+func newTestS3Store(t *testing.T, endpoint string) BlobStore {
+	store, err := NewS3(S3Config{
+		Endpoint:        endpoint,
+		Region:          testS3Region,
+		Bucket:          testS3Bucket,
+		AccessKeyID:     testS3AccessKey,
+		SecretAccessKey: testS3SecretKey,
+		UsePathStyle:    true,
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+func TestS3Store_PutGetDelete(t *testing.T) {
+	stub, srv := newS3Stub(t)
+	defer srv.Close()
+
+	store := newTestS3Store(t, srv.URL)
+	ctx := context.Background()
+
+	for _, key := range []string{"tasks/1/file", "tasks/1/file name+(1).txt", "tasks/1/файл"} {
+		t.Run(key, func(t *testing.T) {
+			content := "content of " + key
+
+			if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			object, ok := stub.getObject(key)
+			if !ok {
+				t.Fatalf("object %q is not stored", key)
+			}
+
+			if string(object.content) != content || object.contentType != "text/plain" {
+				t.Errorf("stored object = %q of type %q", object.content, object.contentType)
+			}
+
+			r, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			got, err := ioutil.ReadAll(r)
+			_ = r.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != content {
+				t.Errorf("Get() content = %q, want %q", got, content)
+			}
+
+			if err = store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			if _, ok = stub.getObject(key); ok {
+				t.Errorf("object %q is not deleted", key)
+			}
+		})
+	}
+}
+
+func TestS3Store_NotFound(t *testing.T) {
+	_, srv := newS3Stub(t)
+	defer srv.Close()
+
+	store := newTestS3Store(t, srv.URL)
+
+	if _, err := store.Get(context.Background(), "tasks/1/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := store.Delete(context.Background(), "tasks/1/missing"); err != nil {
+		t.Errorf("Delete() of missing blob error = %v", err)
+	}
+}
+
+func TestS3Store_RequestError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	store := newTestS3Store(t, srv.URL)
+
+	err := store.Put(context.Background(), "tasks/1/file", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put() error = %v, want error with status and body of response", err)
+	}
+}
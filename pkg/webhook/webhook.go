@@ -0,0 +1,354 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	AttemptHeader   = "X-Webhook-Attempt"
+	signaturePrefix = "sha256="
+	maxRedirects    = 10
+)
+
+var (
+	ErrNotAllowedAddress = errors.New("webhook address is not allowed")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+	ErrQueueIsFull       = errors.New("webhook delivery queue is full")
+	ErrSenderIsShutDown  = errors.New("webhook sender is shut down")
+)
+
+// notPublicNetworks are loopback, private, link-local, unspecified, translation, benchmarking and multicast networks.
+// Webhooks can not be sent to them, so project members can not reach internal services.
+var notPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+type (
+	Logger interface {
+		Errorf(format string, args ...interface{})
+	}
+	Sender interface {
+		Send(delivery Delivery)
+		Init()
+		Shutdown()
+	}
+	Config struct {
+		Timeout          time.Duration
+		DeliveryChanSize int
+		WorkersNum       int
+		MaxAttempts      int
+		InitialBackoff   time.Duration
+	}
+	Delivery struct {
+		URL     string
+		Secret  string
+		Event   string
+		Payload []byte
+		// OnAttempt is called after every delivery attempt if it is set.
+		OnAttempt func(result AttemptResult)
+
+		attempt int
+		backoff time.Duration
+	}
+	AttemptResult struct {
+		Attempt    int
+		StatusCode int
+		Err        error
+		IsSuccess  bool
+	}
+	sender struct {
+		cfg    Config
+		log    Logger
+		client *http.Client
+		// isAllowedIP checks addresses of webhook receivers after DNS resolution
+		isAllowedIP func(ip net.IP) bool
+
+		// use workers pool for sending webhooks
+		workersWaitGroup *sync.WaitGroup
+		deliveriesToSend chan Delivery
+		// mu guards sending to deliveriesToSend and retries against closing on shutdown
+		mu         sync.Mutex
+		isShutDown bool
+		retries    map[*time.Timer]struct{}
+	}
+)
+
+// New creates new Sender. You should call Init() for properly work.
+func New(cfg Config, log Logger) Sender {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	s := &sender{
+		cfg:         cfg,
+		log:         log,
+		isAllowedIP: IsPublicIP,
+	}
+
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: s.checkDialAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// proxy would be dialed instead of the receiver, so address of the receiver would not be checked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	s.client = &http.Client{
+		Timeout:       cfg.Timeout,
+		Transport:     transport,
+		CheckRedirect: s.checkRedirect,
+	}
+
+	return s
+}
+
+// Init initializes workers.
+func (s *sender) Init() {
+	s.deliveriesToSend = make(chan Delivery, s.cfg.DeliveryChanSize)
+	s.retries = make(map[*time.Timer]struct{})
+	s.workersWaitGroup = &sync.WaitGroup{}
+	s.workersWaitGroup.Add(s.cfg.WorkersNum)
+
+	for i := 0; i < s.cfg.WorkersNum; i++ {
+		go s.workerFunc()
+	}
+}
+
+// Shutdown gracefully shuts down workers. Retries of failed deliveries are cancelled.
+func (s *sender) Shutdown() {
+	s.mu.Lock()
+	s.isShutDown = true
+	for retry := range s.retries {
+		retry.Stop()
+	}
+	s.retries = nil
+	close(s.deliveriesToSend)
+	s.mu.Unlock()
+
+	s.workersWaitGroup.Wait()
+}
+
+// Send queues delivery for sending. It does not block, so if the queue is full
+// the delivery is dropped and reported as failed attempt.
+func (s *sender) Send(delivery Delivery) {
+	delivery.attempt = 1
+	delivery.backoff = s.cfg.InitialBackoff
+
+	if err := s.enqueue(delivery); err != nil {
+		s.log.Errorf("failed to queue webhook %s to %s: %v", delivery.Event, delivery.URL, err)
+		if delivery.OnAttempt != nil {
+			delivery.OnAttempt(AttemptResult{Attempt: delivery.attempt, Err: err})
+		}
+	}
+}
+
+// enqueue adds delivery to the queue without blocking.
+func (s *sender) enqueue(delivery Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isShutDown {
+		return ErrSenderIsShutDown
+	}
+
+	select {
+	case s.deliveriesToSend <- delivery:
+		return nil
+	default:
+		return ErrQueueIsFull
+	}
+}
+
+// scheduleRetry queues next attempt of the delivery after backoff, so workers do not wait for it.
+func (s *sender) scheduleRetry(delivery Delivery, backoff time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isShutDown {
+		return
+	}
+
+	var retry *time.Timer
+	retry = time.AfterFunc(backoff, func() {
+		s.mu.Lock()
+		delete(s.retries, retry)
+		s.mu.Unlock()
+
+		if err := s.enqueue(delivery); err != nil {
+			s.log.Errorf("failed to queue attempt %d of webhook %s to %s: %v",
+				delivery.attempt, delivery.Event, delivery.URL, err)
+		}
+	})
+	s.retries[retry] = struct{}{}
+}
+
+// Sign returns HMAC-SHA256 signature of the payload in format "sha256=<hex>".
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature of the payload. It can be used by webhook receivers.
+func Verify(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
+
+// IsPublicIP checks that ip is not loopback, private, link-local or unspecified address.
+func IsPublicIP(ip net.IP) bool {
+	for _, network := range notPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkDialAddress is called with resolved address before connecting, so host names
+// pointing to internal addresses are rejected too.
+func (s *sender) checkDialAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !s.isAllowedIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNotAllowedAddress, host)
+	}
+
+	return nil
+}
+
+// checkRedirect refuses redirects to not allowed addresses.
+func (s *sender) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return ErrTooManyRedirects
+	}
+
+	host := req.URL.Hostname()
+	ips, err := net.DefaultResolver.LookupIPAddr(req.Context(), host)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range ips {
+		if !s.isAllowedIP(ip.IP) {
+			return fmt.Errorf("%w: redirect to %s", ErrNotAllowedAddress, host)
+		}
+	}
+
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func (s *sender) workerFunc() {
+	defer s.workersWaitGroup.Done()
+
+	for delivery := range s.deliveriesToSend {
+		s.deliver(delivery)
+	}
+}
+
+// deliver makes attempt to send delivery. Failed attempt is retried with exponential backoff.
+func (s *sender) deliver(delivery Delivery) {
+	result := s.send(delivery, delivery.attempt)
+	if delivery.OnAttempt != nil {
+		delivery.OnAttempt(result)
+	}
+
+	if result.IsSuccess {
+		return
+	}
+
+	if delivery.attempt >= s.cfg.MaxAttempts {
+		s.log.Errorf("failed to deliver webhook %s to %s after %d attempts: %v",
+			delivery.Event, delivery.URL, delivery.attempt, result.Err)
+		return
+	}
+
+	backoff := delivery.backoff
+	delivery.attempt++
+	delivery.backoff *= 2
+	s.scheduleRetry(delivery, backoff)
+}
+
+func (s *sender) send(delivery Delivery, attempt int) AttemptResult {
+	result := AttemptResult{Attempt: attempt}
+
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(AttemptHeader, strconv.Itoa(attempt))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+
+	// read body to reuse connection
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		result.Err = fmt.Errorf("unexpected response status %d", resp.StatusCode)
+		return result
+	}
+
+	result.IsSuccess = true
+
+	return result
+}
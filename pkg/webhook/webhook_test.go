@@ -0,0 +1,302 @@
+package webhook
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testLogger struct {
+	t *testing.T
+}
+
+func (l testLogger) Errorf(format string, args ...interface{}) {
+	l.t.Logf(format, args...)
+}
+
+// newTestSender creates sender which can deliver webhooks to loopback test servers.
+func newTestSender(t *testing.T, maxAttempts int) *sender {
+	s := New(Config{
+		Timeout:          time.Second,
+		DeliveryChanSize: 1,
+		WorkersNum:       1,
+		MaxAttempts:      maxAttempts,
+		InitialBackoff:   time.Millisecond,
+	}, testLogger{t: t}).(*sender)
+
+	s.isAllowedIP = func(ip net.IP) bool {
+		return ip.IsLoopback() || IsPublicIP(ip)
+	}
+
+	return s
+}
+
+// sendAndCollect sends delivery and returns results of all attempts after the last one is made.
+func sendAndCollect(t *testing.T, s *sender, delivery Delivery) []AttemptResult {
+	t.Helper()
+
+	var mu sync.Mutex
+	var results []AttemptResult
+	done := make(chan struct{})
+	delivery.OnAttempt = func(result AttemptResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, result)
+
+		if result.IsSuccess || result.Attempt == s.cfg.MaxAttempts {
+			close(done)
+		}
+	}
+
+	s.Init()
+	defer s.Shutdown()
+
+	s.Send(delivery)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery is not finished")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	return results
+}
+
+func TestSignAndVerify(t *testing.T) {
+	// known HMAC-SHA256 test vector
+	payload := []byte("The quick brown fox jumps over the lazy dog")
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+
+	signature := Sign("key", payload)
+	if signature != want {
+		t.Fatalf("Sign() = %q, want %q", signature, want)
+	}
+
+	if !Verify("key", payload, signature) {
+		t.Error("Verify() = false for valid signature")
+	}
+
+	if Verify("other-key", payload, signature) {
+		t.Error("Verify() = true for signature with other secret")
+	}
+
+	if Verify("key", []byte("changed payload"), signature) {
+		t.Error("Verify() = true for changed payload")
+	}
+}
+
+func TestSender_Delivery(t *testing.T) {
+	payload := []byte(`{"id":1}`)
+
+	var gotEvent, gotSignature, gotAttempt, gotContentType string
+	var gotPayload []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEvent = r.Header.Get(EventHeader)
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotAttempt = r.Header.Get(AttemptHeader)
+		gotContentType = r.Header.Get("Content-Type")
+		gotPayload, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	results := sendAndCollect(t, newTestSender(t, 3), Delivery{
+		URL:     srv.URL,
+		Secret:  "secret",
+		Event:   "task.created",
+		Payload: payload,
+	})
+
+	if len(results) != 1 || !results[0].IsSuccess || results[0].StatusCode != http.StatusNoContent {
+		t.Fatalf("results = %+v, want one successful attempt", results)
+	}
+
+	if gotEvent != "task.created" || gotAttempt != "1" || gotContentType != "application/json" {
+		t.Errorf("headers: event %q, attempt %q, content type %q", gotEvent, gotAttempt, gotContentType)
+	}
+
+	if string(gotPayload) != string(payload) {
+		t.Errorf("payload = %s, want %s", gotPayload, payload)
+	}
+
+	if !Verify("secret", gotPayload, gotSignature) {
+		t.Errorf("signature %q is not valid", gotSignature)
+	}
+}
+
+func TestSender_Retry(t *testing.T) {
+	tests := []struct {
+		name          string
+		failsNum      int
+		maxAttempts   int
+		wantAttempts  int
+		wantIsSuccess bool
+	}{
+		{
+			name:          "success after failures",
+			failsNum:      2,
+			maxAttempts:   3,
+			wantAttempts:  3,
+			wantIsSuccess: true,
+		},
+		{
+			name:         "attempts are exhausted",
+			failsNum:     5,
+			maxAttempts:  3,
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var attempts []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				attempts = append(attempts, r.Header.Get(AttemptHeader))
+				attemptsNum := len(attempts)
+				mu.Unlock()
+
+				if attemptsNum <= tt.failsNum {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			results := sendAndCollect(t, newTestSender(t, tt.maxAttempts), Delivery{
+				URL:     srv.URL,
+				Secret:  "secret",
+				Event:   "task.updated",
+				Payload: []byte(`{}`),
+			})
+
+			if len(results) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", len(results), tt.wantAttempts)
+			}
+
+			for i, attempt := range attempts {
+				if attempt != strconv.Itoa(i+1) {
+					t.Errorf("attempt header = %s, want %d", attempt, i+1)
+				}
+			}
+
+			last := results[len(results)-1]
+			if last.IsSuccess != tt.wantIsSuccess {
+				t.Errorf("last attempt success = %v, want %v", last.IsSuccess, tt.wantIsSuccess)
+			}
+
+			if !tt.wantIsSuccess && last.StatusCode != http.StatusInternalServerError {
+				t.Errorf("last attempt status = %d, want %d", last.StatusCode, http.StatusInternalServerError)
+			}
+		})
+	}
+}
+
+func TestSender_NotAllowedAddress(t *testing.T) {
+	var isCalled bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isCalled = true
+	}))
+	defer srv.Close()
+
+	// default sender does not allow loopback addresses
+	s := New(Config{Timeout: time.Second, DeliveryChanSize: 1, WorkersNum: 1}, testLogger{t: t}).(*sender)
+
+	results := sendAndCollect(t, s, Delivery{URL: srv.URL, Payload: []byte(`{}`)})
+	if len(results) != 1 || !errors.Is(results[0].Err, ErrNotAllowedAddress) {
+		t.Fatalf("results = %+v, want error %v", results, ErrNotAllowedAddress)
+	}
+
+	if isCalled {
+		t.Error("webhook is delivered to loopback address")
+	}
+}
+
+func TestSender_NotAllowedRedirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	results := sendAndCollect(t, newTestSender(t, 1), Delivery{URL: srv.URL, Payload: []byte(`{}`)})
+	if len(results) != 1 || !errors.Is(results[0].Err, ErrNotAllowedAddress) {
+		t.Fatalf("results = %+v, want error %v", results, ErrNotAllowedAddress)
+	}
+}
+
+func TestSender_QueueIsFull(t *testing.T) {
+	// sender without workers does not take deliveries from the queue
+	s := New(Config{DeliveryChanSize: 1}, testLogger{t: t}).(*sender)
+	s.Init()
+
+	var results []AttemptResult
+	delivery := Delivery{
+		URL:       "http://example.com",
+		Payload:   []byte(`{}`),
+		OnAttempt: func(result AttemptResult) { results = append(results, result) },
+	}
+
+	s.Send(delivery)
+	if len(results) != 0 {
+		t.Fatalf("results = %+v, want delivery to be queued", results)
+	}
+
+	s.Send(delivery)
+	if len(results) != 1 || results[0].IsSuccess || !errors.Is(results[0].Err, ErrQueueIsFull) {
+		t.Fatalf("results = %+v, want error %v", results, ErrQueueIsFull)
+	}
+
+	s.Shutdown()
+
+	s.Send(delivery)
+	if len(results) != 2 || !errors.Is(results[1].Err, ErrSenderIsShutDown) {
+		t.Fatalf("results = %+v, want error %v", results, ErrSenderIsShutDown)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "8.8.8.8", want: true},
+		{ip: "2001:4860:4860::8888", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "::1"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "198.18.0.1"},
+		{ip: "198.19.255.255"},
+		{ip: "224.0.0.1"},
+		{ip: "239.255.255.250"},
+		{ip: "64:ff9b::a9fe:a9fe"},
+		{ip: "ff02::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS uidx_nn_project_user_owner;
+
+ALTER TABLE nn_project_user
+    ADD COLUMN is_owner BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE nn_project_user
+SET is_owner = TRUE
+WHERE role = 'owner';
+
+ALTER TABLE nn_project_user
+    DROP COLUMN role;
@@ -0,0 +1,14 @@
+-- roles of users working on projects
+ALTER TABLE nn_project_user
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member'
+        CHECK (role IN ('owner', 'admin', 'member', 'viewer'));
+
+UPDATE nn_project_user
+SET role = 'owner'
+WHERE is_owner;
+
+ALTER TABLE nn_project_user
+    DROP COLUMN is_owner;
+
+-- project can have only one owner
+CREATE UNIQUE INDEX uidx_nn_project_user_owner ON nn_project_user (project_id) WHERE role = 'owner';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS
+    h_task_comment_edit,
+    r_task_comment
+    CASCADE;
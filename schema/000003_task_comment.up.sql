@@ -0,0 +1,27 @@
+-- comments to tasks
+CREATE TABLE r_task_comment
+(
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    BIGINT REFERENCES r_task (id) ON DELETE CASCADE         NOT NULL,
+    parent_id  BIGINT REFERENCES r_task_comment (id) ON DELETE CASCADE,
+    author_id  BIGINT REFERENCES r_user (id) ON DELETE CASCADE         NOT NULL,
+    text       TEXT                                                    NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ                                             NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                             NOT NULL DEFAULT NOW()
+);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_task_comment
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+CREATE INDEX idx_r_task_comment_task_id ON r_task_comment (task_id);
+
+-- previous versions of edited comments
+CREATE TABLE h_task_comment_edit
+(
+    id         BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT REFERENCES r_task_comment (id) ON DELETE CASCADE NOT NULL,
+    text       TEXT                                                    NOT NULL DEFAULT '',
+    edited_at  TIMESTAMPTZ                                             NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_h_task_comment_edit_comment_id ON h_task_comment_edit (comment_id);
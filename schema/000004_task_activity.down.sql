@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS log_r_task_activity ON r_task;
+
+DROP TABLE IF EXISTS
+    h_task_activity
+    CASCADE;
+
+DROP FUNCTION IF EXISTS
+    trigger_log_r_task_activity()
+    CASCADE;
@@ -0,0 +1,56 @@
+-- append-only history of task changes
+CREATE TABLE h_task_activity
+(
+    id         BIGSERIAL PRIMARY KEY,
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    task_id    BIGINT                                             NOT NULL,
+    actor_id   BIGINT REFERENCES r_user (id) ON DELETE SET NULL,
+    field      VARCHAR(50)                                        NOT NULL,
+    old_value  TEXT,
+    new_value  TEXT,
+    created_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_h_task_activity_task_id ON h_task_activity (task_id, id);
+CREATE INDEX idx_h_task_activity_project_id ON h_task_activity (project_id, id);
+
+-- actor is set by application in transaction: SELECT set_config('app.actor_id', '1', true)
+CREATE OR REPLACE FUNCTION trigger_log_r_task_activity()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _actor_id BIGINT := NULLIF(current_setting('app.actor_id', TRUE), '')::BIGINT;
+BEGIN
+    IF OLD.assignee_id IS DISTINCT FROM NEW.assignee_id THEN
+        INSERT INTO h_task_activity (project_id, task_id, actor_id, field, old_value, new_value)
+        VALUES (NEW.project_id, NEW.id, _actor_id, 'assigneeId', OLD.assignee_id, NEW.assignee_id);
+    END IF;
+
+    IF OLD.importance_status_id IS DISTINCT FROM NEW.importance_status_id THEN
+        INSERT INTO h_task_activity (project_id, task_id, actor_id, field, old_value, new_value)
+        VALUES (NEW.project_id, NEW.id, _actor_id, 'importanceStatusId',
+                OLD.importance_status_id, NEW.importance_status_id);
+    END IF;
+
+    IF OLD.progress_status_id IS DISTINCT FROM NEW.progress_status_id THEN
+        INSERT INTO h_task_activity (project_id, task_id, actor_id, field, old_value, new_value)
+        VALUES (NEW.project_id, NEW.id, _actor_id, 'progressStatusId',
+                OLD.progress_status_id, NEW.progress_status_id);
+    END IF;
+
+    IF OLD.order_num_in_progress_status IS DISTINCT FROM NEW.order_num_in_progress_status THEN
+        INSERT INTO h_task_activity (project_id, task_id, actor_id, field, old_value, new_value)
+        VALUES (NEW.project_id, NEW.id, _actor_id, 'orderNum',
+                OLD.order_num_in_progress_status, NEW.order_num_in_progress_status);
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER log_r_task_activity
+    AFTER UPDATE
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_log_r_task_activity();
@@ -0,0 +1,44 @@
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
+
+DROP INDEX IF EXISTS idx_r_task_due_date;
+
+ALTER TABLE r_task
+    DROP CONSTRAINT IF EXISTS chk_r_task_start_date_due_date,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS due_date;
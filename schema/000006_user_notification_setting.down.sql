@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_r_task_assignee_id_updated_at;
+
+DROP TABLE IF EXISTS
+    r_user_notification_setting
+    CASCADE;
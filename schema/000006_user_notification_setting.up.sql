@@ -0,0 +1,16 @@
+-- notification settings of users, absence of the row means that all notifications are enabled
+CREATE TABLE r_user_notification_setting
+(
+    user_id                 BIGINT REFERENCES r_user (id) ON DELETE CASCADE PRIMARY KEY,
+    is_daily_digest_enabled BOOLEAN     NOT NULL DEFAULT TRUE,
+    is_due_reminder_enabled BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_user_notification_setting
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX idx_r_task_assignee_id_updated_at ON r_task (assignee_id, updated_at);
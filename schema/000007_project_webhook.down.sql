@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS
+    h_webhook_delivery,
+    r_project_webhook
+    CASCADE;
@@ -0,0 +1,33 @@
+-- webhook subscriptions of projects, empty events means subscription to all events
+CREATE TABLE r_project_webhook
+(
+    id         BIGSERIAL PRIMARY KEY,
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    url        VARCHAR(2000)                                      NOT NULL,
+    secret     VARCHAR(255)                                       NOT NULL,
+    events     JSONB                                              NOT NULL DEFAULT '[]'::JSONB,
+    is_active  BOOLEAN                                            NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW()
+);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_project_webhook
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+CREATE INDEX idx_r_project_webhook_project_id ON r_project_webhook (project_id);
+
+-- log of webhook delivery attempts
+CREATE TABLE h_webhook_delivery
+(
+    id          BIGSERIAL PRIMARY KEY,
+    webhook_id  BIGINT REFERENCES r_project_webhook (id) ON DELETE CASCADE NOT NULL,
+    event       VARCHAR(50)                                                NOT NULL,
+    payload     JSONB                                                      NOT NULL,
+    attempt     INT                                                        NOT NULL,
+    status_code INT,
+    error       TEXT                                                       NOT NULL DEFAULT '',
+    is_success  BOOLEAN                                                    NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ                                                NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_h_webhook_delivery_webhook_id ON h_webhook_delivery (webhook_id, id);
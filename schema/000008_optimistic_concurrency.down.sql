@@ -0,0 +1,87 @@
+DROP FUNCTION IF EXISTS update_project_board_parts(BIGINT, JSONB, BIGINT);
+DROP FUNCTION IF EXISTS update_project_board_progress_statuses(BIGINT, JSONB, BIGINT);
+DROP FUNCTION IF EXISTS update_project_board_progress_status_tasks(BIGINT, JSONB, BIGINT);
+
+CREATE OR REPLACE FUNCTION update_project_board_parts(_board JSONB)
+    RETURNS VOID
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    FOR rec IN SELECT board."progressStatusId", tasks."taskId", tasks."taskOrderNum"
+               FROM jsonb_to_recordset(_board) AS board("progressStatusId" INT, "tasks" JSONB)
+                        LEFT JOIN jsonb_to_recordset(board."tasks") AS tasks("taskId" BIGINT, "taskOrderNum" INT)
+                                  ON TRUE
+        LOOP
+            IF rec."progressStatusId" IS NOT NULL AND
+               rec."taskId" IS NOT NULL AND rec."taskOrderNum" IS NOT NULL THEN
+                UPDATE r_task
+                SET progress_status_id           = rec."progressStatusId",
+                    order_num_in_progress_status = rec."taskOrderNum"
+                WHERE id = rec."taskId";
+            END IF;
+        END LOOP;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION update_project_board_progress_statuses(_progress_statuses JSONB)
+    RETURNS VOID
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    FOR rec IN SELECT board."progressStatusId", board."progressStatusOrderNum"
+               FROM jsonb_to_recordset(_progress_statuses) AS board("progressStatusId" INT, "progressStatusOrderNum" INT)
+        LOOP
+            IF rec."progressStatusId" IS NOT NULL AND rec."progressStatusOrderNum" IS NOT NULL THEN
+                UPDATE s_project_progress_status
+                SET order_num = rec."progressStatusOrderNum"
+                WHERE id = rec."progressStatusId";
+            END IF;
+        END LOOP;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION update_project_board_progress_status_tasks(_tasks JSONB)
+    RETURNS VOID
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    FOR rec IN SELECT tasks."taskId", tasks."taskOrderNum"
+               FROM jsonb_to_recordset(_tasks) AS tasks("taskId" BIGINT, "taskOrderNum" INT)
+        LOOP
+            IF rec."taskId" IS NOT NULL AND rec."taskOrderNum" IS NOT NULL THEN
+                UPDATE r_task
+                SET order_num_in_progress_status = rec."taskOrderNum"
+                WHERE id = rec."taskId";
+            END IF;
+        END LOOP;
+END;
+$$;
+
+DROP FUNCTION IF EXISTS get_project_board_version(BIGINT);
+DROP FUNCTION IF EXISTS lock_project_board(BIGINT, BIGINT);
+
+DROP TRIGGER IF EXISTS increment_project_board_version ON s_project_progress_status;
+DROP TRIGGER IF EXISTS increment_project_board_version ON r_task;
+DROP FUNCTION IF EXISTS trigger_increment_project_board_version();
+
+DROP TRIGGER IF EXISTS insert_project_board ON r_project;
+DROP FUNCTION IF EXISTS trigger_insert_project_board();
+
+DROP TABLE IF EXISTS
+    r_project_board
+    CASCADE;
+
+DROP TRIGGER IF EXISTS increment_version ON r_task;
+DROP FUNCTION IF EXISTS trigger_increment_version();
+
+ALTER TABLE r_task
+    DROP COLUMN IF EXISTS version;
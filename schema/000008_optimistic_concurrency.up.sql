@@ -0,0 +1,199 @@
+-- version of task is incremented on every change
+ALTER TABLE r_task
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION trigger_increment_version()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER increment_version
+    BEFORE UPDATE
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_increment_version();
+
+-- version of project board is incremented on every change of project tasks or progress statuses
+CREATE TABLE r_project_board
+(
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE PRIMARY KEY,
+    version    BIGINT NOT NULL DEFAULT 1
+);
+INSERT INTO r_project_board (project_id)
+SELECT id
+FROM r_project;
+
+CREATE OR REPLACE FUNCTION trigger_insert_project_board()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO r_project_board (project_id) VALUES (NEW.id);
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER insert_project_board
+    AFTER INSERT
+    ON r_project
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_insert_project_board();
+
+CREATE OR REPLACE FUNCTION trigger_increment_project_board_version()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _project_id BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        _project_id = OLD.project_id;
+    ELSE
+        _project_id = NEW.project_id;
+    END IF;
+
+    UPDATE r_project_board
+    SET version = version + 1
+    WHERE project_id = _project_id;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER increment_project_board_version
+    AFTER INSERT OR UPDATE OR DELETE
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_increment_project_board_version();
+
+CREATE TRIGGER increment_project_board_version
+    AFTER INSERT OR UPDATE OR DELETE
+    ON s_project_progress_status
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_increment_project_board_version();
+
+-- lock_project_board locks project board until the end of transaction and checks its version.
+-- NULL version is not checked. Version conflict is raised with custom error code TT409.
+CREATE OR REPLACE FUNCTION lock_project_board(_project_id BIGINT, _version BIGINT)
+    RETURNS VOID
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _current_version BIGINT;
+BEGIN
+    SELECT version
+    INTO _current_version
+    FROM r_project_board
+    WHERE project_id = _project_id
+        FOR UPDATE;
+
+    IF _version IS NOT NULL AND _current_version IS DISTINCT FROM _version THEN
+        RAISE EXCEPTION 'project board was changed by another request'
+            USING ERRCODE = 'TT409',
+                DETAIL = format('current version is %s', _current_version);
+    END IF;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION get_project_board_version(_project_id BIGINT)
+    RETURNS BIGINT
+    LANGUAGE sql
+AS
+$$
+SELECT version
+FROM r_project_board
+WHERE project_id = _project_id;
+$$;
+
+DROP FUNCTION IF EXISTS update_project_board_parts(JSONB);
+DROP FUNCTION IF EXISTS update_project_board_progress_statuses(JSONB);
+DROP FUNCTION IF EXISTS update_project_board_progress_status_tasks(JSONB);
+
+CREATE OR REPLACE FUNCTION update_project_board_parts(_project_id BIGINT, _board JSONB, _version BIGINT)
+    RETURNS BIGINT
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    PERFORM lock_project_board(_project_id, _version);
+
+    FOR rec IN SELECT board."progressStatusId", tasks."taskId", tasks."taskOrderNum"
+               FROM jsonb_to_recordset(_board) AS board("progressStatusId" INT, "tasks" JSONB)
+                        LEFT JOIN jsonb_to_recordset(board."tasks") AS tasks("taskId" BIGINT, "taskOrderNum" INT)
+                                  ON TRUE
+        LOOP
+            IF rec."progressStatusId" IS NOT NULL AND
+               rec."taskId" IS NOT NULL AND rec."taskOrderNum" IS NOT NULL THEN
+                UPDATE r_task
+                SET progress_status_id           = rec."progressStatusId",
+                    order_num_in_progress_status = rec."taskOrderNum"
+                WHERE id = rec."taskId";
+            END IF;
+        END LOOP;
+
+    RETURN get_project_board_version(_project_id);
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION update_project_board_progress_statuses(
+    _project_id BIGINT, _progress_statuses JSONB, _version BIGINT
+)
+    RETURNS BIGINT
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    PERFORM lock_project_board(_project_id, _version);
+
+    FOR rec IN SELECT board."progressStatusId", board."progressStatusOrderNum"
+               FROM jsonb_to_recordset(_progress_statuses) AS board("progressStatusId" INT, "progressStatusOrderNum" INT)
+        LOOP
+            IF rec."progressStatusId" IS NOT NULL AND rec."progressStatusOrderNum" IS NOT NULL THEN
+                UPDATE s_project_progress_status
+                SET order_num = rec."progressStatusOrderNum"
+                WHERE id = rec."progressStatusId";
+            END IF;
+        END LOOP;
+
+    RETURN get_project_board_version(_project_id);
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION update_project_board_progress_status_tasks(
+    _project_id BIGINT, _tasks JSONB, _version BIGINT
+)
+    RETURNS BIGINT
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    rec RECORD;
+BEGIN
+    PERFORM lock_project_board(_project_id, _version);
+
+    FOR rec IN SELECT tasks."taskId", tasks."taskOrderNum"
+               FROM jsonb_to_recordset(_tasks) AS tasks("taskId" BIGINT, "taskOrderNum" INT)
+        LOOP
+            IF rec."taskId" IS NOT NULL AND rec."taskOrderNum" IS NOT NULL THEN
+                UPDATE r_task
+                SET order_num_in_progress_status = rec."taskOrderNum"
+                WHERE id = rec."taskId";
+            END IF;
+        END LOOP;
+
+    RETURN get_project_board_version(_project_id);
+END;
+$$;
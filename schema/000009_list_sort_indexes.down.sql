@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_r_task_project_id_due_date;
+DROP INDEX IF EXISTS idx_r_task_project_id_updated_at;
+DROP INDEX IF EXISTS idx_r_task_project_id_created_at;
@@ -0,0 +1,4 @@
+-- indexes for keyset pagination of project tasks sorted by supported fields
+CREATE INDEX idx_r_task_project_id_created_at ON r_task (project_id, created_at, id);
+CREATE INDEX idx_r_task_project_id_updated_at ON r_task (project_id, updated_at, id);
+CREATE INDEX idx_r_task_project_id_due_date ON r_task (project_id, (COALESCE(due_date, 'infinity'::DATE)), id);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_r_project_search_vector;
+ALTER TABLE r_project
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_r_task_comment_search_vector;
+ALTER TABLE r_task_comment
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_r_task_search_vector;
+ALTER TABLE r_task
+    DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS html_escape(TEXT);
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS task_tracker_search;
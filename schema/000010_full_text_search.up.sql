@@ -0,0 +1,33 @@
+-- search configuration for russian and english content:
+-- russian config stems cyrillic words with russian stemmer and latin words with english stemmer
+CREATE TEXT SEARCH CONFIGURATION task_tracker_search (COPY = russian);
+
+-- html_escape escapes text to be safely highlighted in html
+CREATE OR REPLACE FUNCTION html_escape(_text TEXT)
+    RETURNS TEXT
+    LANGUAGE sql
+    IMMUTABLE
+AS
+$$
+SELECT replace(replace(replace(_text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;');
+$$;
+
+ALTER TABLE r_task
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+            setweight(to_tsvector('task_tracker_search', title), 'A') ||
+            setweight(to_tsvector('task_tracker_search', description), 'B')
+        ) STORED;
+CREATE INDEX idx_r_task_search_vector ON r_task USING GIN (search_vector);
+
+ALTER TABLE r_task_comment
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('task_tracker_search', text)
+        ) STORED;
+CREATE INDEX idx_r_task_comment_search_vector ON r_task_comment USING GIN (search_vector);
+
+ALTER TABLE r_project
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+            setweight(to_tsvector('task_tracker_search', name), 'A') ||
+            setweight(to_tsvector('task_tracker_search', description), 'B')
+        ) STORED;
+CREATE INDEX idx_r_project_search_vector ON r_project USING GIN (search_vector);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS
+    r_task_filter
+    CASCADE;
@@ -0,0 +1,18 @@
+-- saved task filters of project users
+CREATE TABLE r_task_filter
+(
+    id         BIGSERIAL PRIMARY KEY,
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    owner_id   BIGINT REFERENCES r_user (id) ON DELETE CASCADE    NOT NULL,
+    name       VARCHAR(255)                                       NOT NULL,
+    query      TEXT                                               NOT NULL DEFAULT '',
+    is_shared  BOOLEAN                                            NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, owner_id, name)
+);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_task_filter
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
@@ -0,0 +1,67 @@
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION trigger_insert_default_project_statuses()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO s_project_importance_status (project_id, name)
+    VALUES (NEW.id, 'LOW'),
+           (NEW.id, 'MEDIUM'),
+           (NEW.id, 'HIGH');
+
+    INSERT INTO s_project_progress_status (project_id, name, order_num)
+    VALUES (NEW.id, 'TO DO', 0),
+           (NEW.id, 'IN PROGRESS', 1),
+           (NEW.id, 'DONE', 2);
+
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS increment_project_board_version ON s_project_label;
+DROP FUNCTION IF EXISTS trigger_increment_task_label_project_board_version() CASCADE;
+
+DROP TABLE IF EXISTS
+    nn_task_label,
+    s_project_label
+    CASCADE;
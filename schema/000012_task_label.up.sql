@@ -0,0 +1,140 @@
+-- labels for project tasks
+CREATE TABLE s_project_label
+(
+    id         SERIAL PRIMARY KEY,
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    name       VARCHAR(255)                                       NOT NULL DEFAULT '',
+    color      VARCHAR(7)                                         NOT NULL DEFAULT '#808080',
+    created_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, name)
+);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON s_project_label
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- labels of tasks
+CREATE TABLE nn_task_label
+(
+    task_id  BIGINT REFERENCES r_task (id) ON DELETE CASCADE       NOT NULL,
+    label_id INT REFERENCES s_project_label (id) ON DELETE CASCADE NOT NULL,
+    UNIQUE (task_id, label_id)
+);
+CREATE INDEX idx_nn_task_label_label_id ON nn_task_label (label_id);
+
+-- insert default statuses and labels for new project
+CREATE OR REPLACE FUNCTION trigger_insert_default_project_statuses()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO s_project_importance_status (project_id, name)
+    VALUES (NEW.id, 'LOW'),
+           (NEW.id, 'MEDIUM'),
+           (NEW.id, 'HIGH');
+
+    INSERT INTO s_project_progress_status (project_id, name, order_num)
+    VALUES (NEW.id, 'TO DO', 0),
+           (NEW.id, 'IN PROGRESS', 1),
+           (NEW.id, 'DONE', 2);
+
+    INSERT INTO s_project_label (project_id, name, color)
+    VALUES (NEW.id, 'bug', '#d73a4a'),
+           (NEW.id, 'feature', '#0e8a16'),
+           (NEW.id, 'blocked', '#b60205');
+
+    RETURN NEW;
+END;
+$$;
+
+-- labels are shown on project board
+CREATE TRIGGER increment_project_board_version
+    AFTER INSERT OR UPDATE OR DELETE
+    ON s_project_label
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_increment_project_board_version();
+
+CREATE OR REPLACE FUNCTION trigger_increment_task_label_project_board_version()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _task_id BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        _task_id = OLD.task_id;
+    ELSE
+        _task_id = NEW.task_id;
+    END IF;
+
+    UPDATE r_project_board
+    SET version = version + 1
+    WHERE project_id = (SELECT project_id FROM r_task WHERE id = _task_id);
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER increment_project_board_version
+    AFTER INSERT OR DELETE
+    ON nn_task_label
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_increment_task_label_project_board_version();
+
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB)
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
@@ -0,0 +1,60 @@
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB)
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
+
+DROP FUNCTION IF EXISTS get_project_done_progress_status_id(BIGINT);
+DROP TRIGGER IF EXISTS check_r_task_parent ON r_task;
+DROP FUNCTION IF EXISTS trigger_check_r_task_parent();
+
+ALTER TABLE r_task
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,130 @@
+-- parent task of subtask, subtasks become root tasks when parent is deleted
+ALTER TABLE r_task
+    ADD COLUMN parent_id BIGINT REFERENCES r_task (id) ON DELETE SET NULL;
+CREATE INDEX idx_r_task_parent_id ON r_task (parent_id) WHERE parent_id IS NOT NULL;
+
+-- parent task must be in the same project and must not be the task itself or its subtask
+CREATE OR REPLACE FUNCTION trigger_check_r_task_parent()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- concurrent changes of hierarchy in the project could make a cycle together
+    PERFORM pg_advisory_xact_lock(NEW.project_id);
+
+    IF NOT EXISTS(SELECT 1 FROM r_task WHERE id = NEW.parent_id AND project_id = NEW.project_id) THEN
+        RAISE EXCEPTION 'parent task not found'
+            USING ERRCODE = 'check_violation',
+                DETAIL = 'parent task should be in the same project';
+    END IF;
+
+    IF EXISTS(WITH RECURSIVE ancestors AS (
+        SELECT id, parent_id
+        FROM r_task
+        WHERE id = NEW.parent_id
+        UNION
+        SELECT t.id, t.parent_id
+        FROM r_task t
+                 INNER JOIN ancestors a ON a.parent_id = t.id
+    )
+              SELECT 1
+              FROM ancestors
+              WHERE id = NEW.id) THEN
+        RAISE EXCEPTION 'task hierarchy cycle'
+            USING ERRCODE = 'check_violation',
+                DETAIL = 'task can not be a subtask of itself or of its subtask';
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER check_r_task_parent
+    BEFORE INSERT OR UPDATE OF parent_id
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_check_r_task_parent();
+
+-- tasks in the last progress status of the project are done
+CREATE OR REPLACE FUNCTION get_project_done_progress_status_id(_project_id BIGINT)
+    RETURNS INT
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT id
+FROM s_project_progress_status
+WHERE project_id = _project_id
+ORDER BY order_num DESC, id DESC
+LIMIT 1;
+$$;
+
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB),
+                                       'parentId', rt.parent_id,
+                                       'subtasksTotal', s.total,
+                                       'subtasksDone', s.done
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                         LEFT JOIN LATERAL (
+                    SELECT COUNT(*) total,
+                           COUNT(*) FILTER (
+                               WHERE st.progress_status_id = get_project_done_progress_status_id(_project_id)
+                               ) done
+                    FROM r_task st
+                    WHERE st.parent_id = rt.id
+                    ) s ON TRUE
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
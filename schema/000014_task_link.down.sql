@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS check_r_task_blockers ON r_task;
+DROP FUNCTION IF EXISTS trigger_check_r_task_blockers();
+DROP FUNCTION IF EXISTS trigger_check_r_task_link() CASCADE;
+
+DROP TABLE IF EXISTS r_task_link CASCADE;
@@ -0,0 +1,104 @@
+-- typed links between tasks of the same project: task blocks, relates to or duplicates linked task
+CREATE TABLE r_task_link
+(
+    id             BIGSERIAL PRIMARY KEY,
+    task_id        BIGINT REFERENCES r_task (id) ON DELETE CASCADE NOT NULL,
+    linked_task_id BIGINT REFERENCES r_task (id) ON DELETE CASCADE NOT NULL,
+    type           VARCHAR(20)                                     NOT NULL,
+    created_at     TIMESTAMPTZ                                     NOT NULL DEFAULT NOW(),
+    UNIQUE (task_id, linked_task_id, type),
+    CONSTRAINT chk_r_task_link_type CHECK (type IN ('blocks', 'relates_to', 'duplicates')),
+    CONSTRAINT chk_r_task_link_task_id_linked_task_id CHECK (task_id <> linked_task_id)
+);
+CREATE INDEX idx_r_task_link_linked_task_id ON r_task_link (linked_task_id);
+
+-- linked tasks must be in the same project and blocking links must not make a cycle
+CREATE OR REPLACE FUNCTION trigger_check_r_task_link()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _project_id BIGINT;
+BEGIN
+    SELECT project_id INTO _project_id FROM r_task WHERE id = NEW.task_id;
+
+    IF NOT EXISTS(SELECT 1 FROM r_task WHERE id = NEW.linked_task_id AND project_id = _project_id) THEN
+        RAISE EXCEPTION 'linked task not found'
+            USING ERRCODE = 'check_violation',
+                DETAIL = 'linked task should be in the same project';
+    END IF;
+
+    IF NEW.type <> 'blocks' THEN
+        RETURN NEW;
+    END IF;
+
+    -- concurrent links in the project could make a cycle together
+    PERFORM pg_advisory_xact_lock(_project_id);
+
+    IF EXISTS(WITH RECURSIVE blocked AS (
+        SELECT linked_task_id AS id
+        FROM r_task_link
+        WHERE task_id = NEW.linked_task_id
+          AND type = 'blocks'
+        UNION
+        SELECT l.linked_task_id
+        FROM r_task_link l
+                 INNER JOIN blocked b ON b.id = l.task_id
+        WHERE l.type = 'blocks'
+    )
+              SELECT 1
+              FROM blocked
+              WHERE id = NEW.task_id) THEN
+        RAISE EXCEPTION 'task dependency cycle'
+            USING ERRCODE = 'check_violation',
+                DETAIL = 'task can not block the task which blocks it';
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER check_r_task_link
+    BEFORE INSERT OR UPDATE
+    ON r_task_link
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_check_r_task_link();
+
+-- task can not be moved to done progress status while it is blocked by not done tasks
+CREATE OR REPLACE FUNCTION trigger_check_r_task_blockers()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _blockers TEXT;
+BEGIN
+    IF NEW.progress_status_id IS NOT DISTINCT FROM OLD.progress_status_id OR
+       NEW.progress_status_id IS DISTINCT FROM get_project_done_progress_status_id(NEW.project_id) THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT string_agg(l.task_id::TEXT, ', ' ORDER BY l.task_id)
+    INTO _blockers
+    FROM r_task_link l
+             INNER JOIN r_task b ON b.id = l.task_id
+    WHERE l.linked_task_id = NEW.id
+      AND l.type = 'blocks'
+      AND b.progress_status_id IS DISTINCT FROM get_project_done_progress_status_id(b.project_id);
+
+    IF _blockers IS NOT NULL THEN
+        RAISE EXCEPTION 'task is blocked'
+            USING ERRCODE = 'check_violation',
+                DETAIL = format('task %s is blocked by not done tasks: %s', NEW.id, _blockers);
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER check_r_task_blockers
+    BEFORE UPDATE OF progress_status_id
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_check_r_task_blockers();
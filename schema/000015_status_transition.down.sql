@@ -0,0 +1 @@
+DROP TABLE IF EXISTS s_project_status_transition CASCADE;
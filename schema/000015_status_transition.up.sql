@@ -0,0 +1,20 @@
+-- allowed transitions between progress statuses of project tasks.
+-- Any transition is allowed in project without transitions. Empty roles allow transition to any role.
+CREATE TABLE s_project_status_transition
+(
+    id             SERIAL PRIMARY KEY,
+    project_id     BIGINT REFERENCES r_project (id) ON DELETE CASCADE                NOT NULL,
+    from_status_id INT REFERENCES s_project_progress_status (id) ON DELETE CASCADE NOT NULL,
+    to_status_id   INT REFERENCES s_project_progress_status (id) ON DELETE CASCADE NOT NULL,
+    roles          VARCHAR(20)[]                                                   NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMPTZ                                                     NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ                                                     NOT NULL DEFAULT NOW(),
+    UNIQUE (from_status_id, to_status_id),
+    CONSTRAINT chk_s_project_status_transition_statuses CHECK (from_status_id <> to_status_id)
+);
+CREATE INDEX idx_s_project_status_transition_project_id ON s_project_status_transition (project_id);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON s_project_status_transition
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
@@ -0,0 +1,148 @@
+-- tasks in the last progress status of the project are done
+CREATE OR REPLACE FUNCTION get_project_done_progress_status_id(_project_id BIGINT)
+    RETURNS INT
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT id
+FROM s_project_progress_status
+WHERE project_id = _project_id
+ORDER BY order_num DESC, id DESC
+LIMIT 1;
+$$;
+
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB),
+                                       'parentId', rt.parent_id,
+                                       'subtasksTotal', s.total,
+                                       'subtasksDone', s.done
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                         LEFT JOIN LATERAL (
+                    SELECT COUNT(*) total,
+                           COUNT(*) FILTER (
+                               WHERE st.progress_status_id = get_project_done_progress_status_id(_project_id)
+                               ) done
+                    FROM r_task st
+                    WHERE st.parent_id = rt.id
+                    ) s ON TRUE
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
+
+-- task can not be moved to done progress status while it is blocked by not done tasks
+CREATE OR REPLACE FUNCTION trigger_check_r_task_blockers()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _blockers TEXT;
+BEGIN
+    IF NEW.progress_status_id IS NOT DISTINCT FROM OLD.progress_status_id OR
+       NEW.progress_status_id IS DISTINCT FROM get_project_done_progress_status_id(NEW.project_id) THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT string_agg(l.task_id::TEXT, ', ' ORDER BY l.task_id)
+    INTO _blockers
+    FROM r_task_link l
+             INNER JOIN r_task b ON b.id = l.task_id
+    WHERE l.linked_task_id = NEW.id
+      AND l.type = 'blocks'
+      AND b.progress_status_id IS DISTINCT FROM get_project_done_progress_status_id(b.project_id);
+
+    IF _blockers IS NOT NULL THEN
+        RAISE EXCEPTION 'task is blocked'
+            USING ERRCODE = 'check_violation',
+                DETAIL = format('task %s is blocked by not done tasks: %s', NEW.id, _blockers);
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+-- insert default statuses and labels for new project
+CREATE OR REPLACE FUNCTION trigger_insert_default_project_statuses()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO s_project_importance_status (project_id, name)
+    VALUES (NEW.id, 'LOW'),
+           (NEW.id, 'MEDIUM'),
+           (NEW.id, 'HIGH');
+
+    INSERT INTO s_project_progress_status (project_id, name, order_num)
+    VALUES (NEW.id, 'TO DO', 0),
+           (NEW.id, 'IN PROGRESS', 1),
+           (NEW.id, 'DONE', 2);
+
+    INSERT INTO s_project_label (project_id, name, color)
+    VALUES (NEW.id, 'bug', '#d73a4a'),
+           (NEW.id, 'feature', '#0e8a16'),
+           (NEW.id, 'blocked', '#b60205');
+
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS update_r_task_completed_at ON s_project_progress_status;
+DROP FUNCTION IF EXISTS trigger_update_r_task_completed_at();
+DROP TRIGGER IF EXISTS set_r_task_completed_at ON r_task;
+DROP FUNCTION IF EXISTS trigger_set_r_task_completed_at();
+DROP FUNCTION IF EXISTS is_progress_status_done(INT);
+
+ALTER TABLE r_task
+    DROP COLUMN IF EXISTS completed_at;
+
+ALTER TABLE s_project_progress_status
+    DROP COLUMN IF EXISTS category;
@@ -0,0 +1,212 @@
+-- category of progress status: tasks in done category statuses are completed
+ALTER TABLE s_project_progress_status
+    ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT 'todo',
+    ADD CONSTRAINT chk_s_project_progress_status_category CHECK (category IN ('todo', 'in_progress', 'done'));
+
+-- the first status of existing project is todo, the last one is done and others are in progress
+UPDATE s_project_progress_status ps
+SET category = CASE
+                   WHEN ps.id = (SELECT id
+                                 FROM s_project_progress_status
+                                 WHERE project_id = ps.project_id
+                                 ORDER BY order_num DESC, id DESC
+                                 LIMIT 1) THEN 'done'
+                   WHEN ps.id = (SELECT id
+                                 FROM s_project_progress_status
+                                 WHERE project_id = ps.project_id
+                                 ORDER BY order_num ASC, id ASC
+                                 LIMIT 1) THEN 'todo'
+                   ELSE 'in_progress'
+    END;
+
+-- insert default statuses and labels for new project
+CREATE OR REPLACE FUNCTION trigger_insert_default_project_statuses()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO s_project_importance_status (project_id, name)
+    VALUES (NEW.id, 'LOW'),
+           (NEW.id, 'MEDIUM'),
+           (NEW.id, 'HIGH');
+
+    INSERT INTO s_project_progress_status (project_id, name, order_num, category)
+    VALUES (NEW.id, 'TO DO', 0, 'todo'),
+           (NEW.id, 'IN PROGRESS', 1, 'in_progress'),
+           (NEW.id, 'DONE', 2, 'done');
+
+    INSERT INTO s_project_label (project_id, name, color)
+    VALUES (NEW.id, 'bug', '#d73a4a'),
+           (NEW.id, 'feature', '#0e8a16'),
+           (NEW.id, 'blocked', '#b60205');
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION is_progress_status_done(_progress_status_id INT)
+    RETURNS BOOLEAN
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT EXISTS(SELECT 1 FROM s_project_progress_status WHERE id = _progress_status_id AND category = 'done');
+$$;
+
+-- time when task entered done category status, NULL for not done task
+ALTER TABLE r_task
+    ADD COLUMN completed_at TIMESTAMPTZ;
+
+-- backfill is not a change of tasks, so triggers are disabled
+ALTER TABLE r_task
+    DISABLE TRIGGER USER;
+UPDATE r_task
+SET completed_at = updated_at
+WHERE is_progress_status_done(progress_status_id);
+ALTER TABLE r_task
+    ENABLE TRIGGER USER;
+
+CREATE INDEX idx_r_task_project_id_completed_at ON r_task (project_id, completed_at);
+
+CREATE OR REPLACE FUNCTION trigger_set_r_task_completed_at()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF NOT is_progress_status_done(NEW.progress_status_id) THEN
+        NEW.completed_at = NULL;
+    ELSIF TG_OP = 'INSERT' OR NEW.completed_at IS NULL THEN
+        NEW.completed_at = NOW();
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER set_r_task_completed_at
+    BEFORE INSERT OR UPDATE OF progress_status_id, completed_at
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_r_task_completed_at();
+
+-- tasks are completed or reopened on change of their status category
+CREATE OR REPLACE FUNCTION trigger_update_r_task_completed_at()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF NEW.category IS DISTINCT FROM OLD.category THEN
+        UPDATE r_task
+        SET completed_at = NULL
+        WHERE progress_status_id = NEW.id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER update_r_task_completed_at
+    AFTER UPDATE OF category
+    ON s_project_progress_status
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_update_r_task_completed_at();
+
+-- task can not be moved to done progress status while it is blocked by not done tasks
+CREATE OR REPLACE FUNCTION trigger_check_r_task_blockers()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _blockers TEXT;
+BEGIN
+    IF NEW.progress_status_id IS NOT DISTINCT FROM OLD.progress_status_id OR
+       NOT is_progress_status_done(NEW.progress_status_id) THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT string_agg(l.task_id::TEXT, ', ' ORDER BY l.task_id)
+    INTO _blockers
+    FROM r_task_link l
+             INNER JOIN r_task b ON b.id = l.task_id
+    WHERE l.linked_task_id = NEW.id
+      AND l.type = 'blocks'
+      AND b.completed_at IS NULL;
+
+    IF _blockers IS NOT NULL THEN
+        RAISE EXCEPTION 'task is blocked'
+            USING ERRCODE = 'check_violation',
+                DETAIL = format('task %s is blocked by not done tasks: %s', NEW.id, _blockers);
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'progressStatusCategory', spps.category,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB),
+                                       'parentId', rt.parent_id,
+                                       'subtasksTotal', s.total,
+                                       'subtasksDone', s.done
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                         LEFT JOIN LATERAL (
+                    SELECT COUNT(*) total, COUNT(*) FILTER (WHERE st.completed_at IS NOT NULL) done
+                    FROM r_task st
+                    WHERE st.parent_id = rt.id
+                    ) s ON TRUE
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
+
+DROP FUNCTION IF EXISTS get_project_done_progress_status_id(BIGINT);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS log_r_task_status_change ON r_task;
+DROP FUNCTION IF EXISTS trigger_log_r_task_status_change();
+
+DROP TABLE IF EXISTS h_task_status_change CASCADE;
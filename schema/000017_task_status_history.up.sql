@@ -0,0 +1,58 @@
+-- history of progress status changes of tasks, the first change of task is its creation
+CREATE TABLE h_task_status_change
+(
+    id             BIGSERIAL PRIMARY KEY,
+    project_id     BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    task_id        BIGINT REFERENCES r_task (id) ON DELETE CASCADE    NOT NULL,
+    from_status_id INT,
+    to_status_id   INT                                                NOT NULL,
+    changed_at     TIMESTAMPTZ                                        NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_h_task_status_change_project_id ON h_task_status_change (project_id, changed_at);
+CREATE INDEX idx_h_task_status_change_task_id ON h_task_status_change (task_id, changed_at);
+
+-- initial statuses of existing tasks are restored from task activity
+INSERT INTO h_task_status_change (project_id, task_id, from_status_id, to_status_id, changed_at)
+SELECT t.project_id,
+       t.id,
+       NULL,
+       COALESCE((SELECT a.old_value::INT
+                 FROM h_task_activity a
+                 WHERE a.task_id = t.id
+                   AND a.field = 'progressStatusId'
+                 ORDER BY a.id
+                 LIMIT 1), t.progress_status_id),
+       t.created_at
+FROM r_task t;
+
+INSERT INTO h_task_status_change (project_id, task_id, from_status_id, to_status_id, changed_at)
+SELECT a.project_id, a.task_id, a.old_value::INT, a.new_value::INT, a.created_at
+FROM h_task_activity a
+WHERE a.field = 'progressStatusId'
+  AND a.new_value IS NOT NULL
+  AND EXISTS(SELECT 1 FROM r_task WHERE id = a.task_id)
+ORDER BY a.id;
+
+CREATE OR REPLACE FUNCTION trigger_log_r_task_status_change()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO h_task_status_change (project_id, task_id, from_status_id, to_status_id)
+        VALUES (NEW.project_id, NEW.id, NULL, NEW.progress_status_id);
+    ELSIF OLD.progress_status_id IS DISTINCT FROM NEW.progress_status_id THEN
+        INSERT INTO h_task_status_change (project_id, task_id, from_status_id, to_status_id)
+        VALUES (NEW.project_id, NEW.id, OLD.progress_status_id, NEW.progress_status_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER log_r_task_status_change
+    AFTER INSERT OR UPDATE OF progress_status_id
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_log_r_task_status_change();
@@ -0,0 +1,172 @@
+-- sprints of project, only one sprint of project can be active
+CREATE TABLE r_sprint
+(
+    id         BIGSERIAL PRIMARY KEY,
+    project_id BIGINT REFERENCES r_project (id) ON DELETE CASCADE NOT NULL,
+    name       VARCHAR(255)                                       NOT NULL,
+    goal       TEXT                                               NOT NULL DEFAULT '',
+    start_date DATE                                               NOT NULL,
+    end_date   DATE                                               NOT NULL,
+    status     VARCHAR(20)                                        NOT NULL DEFAULT 'planned'
+        CHECK (status IN ('planned', 'active', 'closed')),
+    started_at TIMESTAMPTZ,
+    closed_at  TIMESTAMPTZ,
+    created_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                        NOT NULL DEFAULT NOW(),
+    CHECK (start_date <= end_date)
+);
+CREATE INDEX idx_r_sprint_project_id ON r_sprint (project_id, start_date);
+CREATE UNIQUE INDEX idx_r_sprint_active_project_id ON r_sprint (project_id) WHERE status = 'active';
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_sprint
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- sprint of task, tasks without sprint are in the backlog
+ALTER TABLE r_task
+    ADD COLUMN sprint_id BIGINT REFERENCES r_sprint (id) ON DELETE SET NULL;
+CREATE INDEX idx_r_task_sprint_id ON r_task (sprint_id) WHERE sprint_id IS NOT NULL;
+
+-- sprint of task must be in the same project and must not be closed
+CREATE OR REPLACE FUNCTION trigger_check_r_task_sprint()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    _status VARCHAR(20);
+BEGIN
+    IF NEW.sprint_id IS NULL OR (TG_OP = 'UPDATE' AND OLD.sprint_id IS NOT DISTINCT FROM NEW.sprint_id) THEN
+        RETURN NEW;
+    END IF;
+
+    SELECT status
+    INTO _status
+    FROM r_sprint
+    WHERE id = NEW.sprint_id
+      AND project_id = NEW.project_id;
+
+    IF _status IS NULL THEN
+        RAISE EXCEPTION 'sprint not found'
+            USING ERRCODE = 'check_violation',
+                DETAIL = 'sprint should be in the same project';
+    END IF;
+
+    IF _status = 'closed' THEN
+        RAISE EXCEPTION 'sprint is closed'
+            USING ERRCODE = 'check_violation',
+                DETAIL = format('task can not be added to closed sprint %s', NEW.sprint_id);
+    END IF;
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER check_r_task_sprint
+    BEFORE INSERT OR UPDATE OF sprint_id
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_check_r_task_sprint();
+
+-- history of sprint changes of tasks is used for burndown, NULL sprint is the backlog
+CREATE TABLE h_task_sprint_change
+(
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    BIGINT REFERENCES r_task (id) ON DELETE CASCADE NOT NULL,
+    sprint_id  BIGINT,
+    changed_at TIMESTAMPTZ                                     NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_h_task_sprint_change_task_id ON h_task_sprint_change (task_id, changed_at);
+CREATE INDEX idx_h_task_sprint_change_sprint_id ON h_task_sprint_change (sprint_id);
+
+CREATE OR REPLACE FUNCTION trigger_log_r_task_sprint_change()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF (TG_OP = 'INSERT' AND NEW.sprint_id IS NOT NULL) OR
+       (TG_OP = 'UPDATE' AND OLD.sprint_id IS DISTINCT FROM NEW.sprint_id) THEN
+        INSERT INTO h_task_sprint_change (task_id, sprint_id)
+        VALUES (NEW.id, NEW.sprint_id);
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER log_r_task_sprint_change
+    AFTER INSERT OR UPDATE OF sprint_id
+    ON r_task
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_log_r_task_sprint_change();
+
+-- board can show tasks of one sprint
+DROP FUNCTION IF EXISTS get_project_board(BIGINT);
+
+CREATE OR REPLACE FUNCTION get_project_board(_project_id BIGINT, _sprint_id BIGINT DEFAULT NULL)
+    RETURNS JSONB
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    RETURN (SELECT COALESCE(jsonb_agg(
+                                    jsonb_build_object(
+                                            'progressStatusId', spps.id,
+                                            'progressStatusName', spps.name,
+                                            'progressStatusOrderNum', spps.order_num,
+                                            'progressStatusCategory', spps.category,
+                                            'tasks', COALESCE(t.tasks, '[]'::JSONB)
+                                        )
+                                    ORDER BY (spps.order_num)
+                                ), '[]'::JSONB) board
+            FROM s_project_progress_status spps
+                     LEFT JOIN LATERAL (
+                SELECT rt.progress_status_id,
+                       jsonb_agg(
+                               jsonb_build_object(
+                                       'taskId', rt.id,
+                                       'taskTitle', rt.title,
+                                       'taskOrderNum', rt.order_num_in_progress_status,
+                                       'assigneeId', rt.assignee_id,
+                                       'assigneeFirstname', ru.firstname,
+                                       'assigneeLastname', ru.lastname,
+                                       'assigneeAvatarURL', ru.avatar_url,
+                                       'startDate', rt.start_date,
+                                       'dueDate', rt.due_date,
+                                       'labels', COALESCE(l.labels, '[]'::JSONB),
+                                       'parentId', rt.parent_id,
+                                       'sprintId', rt.sprint_id,
+                                       'subtasksTotal', s.total,
+                                       'subtasksDone', s.done
+                                   )
+                               ORDER BY (rt.order_num_in_progress_status)
+                           ) tasks
+                FROM r_task rt
+                         INNER JOIN r_user ru ON ru.id = rt.assignee_id
+                         LEFT JOIN LATERAL (
+                    SELECT jsonb_agg(
+                                   jsonb_build_object(
+                                           'labelId', spl.id,
+                                           'labelName', spl.name,
+                                           'labelColor', spl.color
+                                       )
+                                   ORDER BY (spl.name)
+                               ) labels
+                    FROM nn_task_label ntl
+                             INNER JOIN s_project_label spl ON spl.id = ntl.label_id
+                    WHERE ntl.task_id = rt.id
+                    ) l ON TRUE
+                         LEFT JOIN LATERAL (
+                    SELECT COUNT(*) total, COUNT(*) FILTER (WHERE st.completed_at IS NOT NULL) done
+                    FROM r_task st
+                    WHERE st.parent_id = rt.id
+                    ) s ON TRUE
+                WHERE _sprint_id IS NULL
+                   OR rt.sprint_id = _sprint_id
+                GROUP BY rt.progress_status_id
+                ) t ON spps.id = t.progress_status_id
+            WHERE spps.project_id = _project_id);
+END;
+$$;
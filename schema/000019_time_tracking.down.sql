@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS r_task_worklog CASCADE;
+
+ALTER TABLE r_task
+    DROP COLUMN IF EXISTS original_estimate,
+    DROP COLUMN IF EXISTS remaining_estimate;
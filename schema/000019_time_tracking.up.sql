@@ -0,0 +1,24 @@
+-- estimates of task effort in minutes, NULL estimate is not set
+ALTER TABLE r_task
+    ADD COLUMN original_estimate  INT CHECK (original_estimate >= 0),
+    ADD COLUMN remaining_estimate INT CHECK (remaining_estimate >= 0);
+
+-- time logged by users on tasks
+CREATE TABLE r_task_worklog
+(
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    BIGINT REFERENCES r_task (id) ON DELETE CASCADE NOT NULL,
+    user_id    BIGINT REFERENCES r_user (id) ON DELETE CASCADE NOT NULL,
+    duration   INT                                             NOT NULL CHECK (duration > 0),
+    started_at TIMESTAMPTZ                                     NOT NULL,
+    note       TEXT                                            NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ                                     NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ                                     NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_r_task_worklog_task_id ON r_task_worklog (task_id, started_at);
+CREATE INDEX idx_r_task_worklog_user_id ON r_task_worklog (user_id, started_at);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE
+    ON r_task_worklog
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
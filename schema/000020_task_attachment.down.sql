@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS queue_r_task_attachment_blob ON r_task_attachment;
+DROP FUNCTION IF EXISTS trigger_queue_r_task_attachment_blob();
+
+DROP TABLE IF EXISTS h_deleted_attachment_blob CASCADE;
+DROP TABLE IF EXISTS r_task_attachment CASCADE;
@@ -0,0 +1,41 @@
+-- files attached to tasks, content of files is kept in blob storage
+CREATE TABLE r_task_attachment
+(
+    id           BIGSERIAL PRIMARY KEY,
+    task_id      BIGINT REFERENCES r_task (id) ON DELETE CASCADE NOT NULL,
+    uploader_id  BIGINT REFERENCES r_user (id) ON DELETE CASCADE NOT NULL,
+    file_name    VARCHAR(255)                                    NOT NULL,
+    content_type VARCHAR(255)                                    NOT NULL,
+    size         BIGINT                                          NOT NULL CHECK (size >= 0),
+    blob_key     VARCHAR(255)                                    NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ                                     NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_r_task_attachment_task_id ON r_task_attachment (task_id, id);
+
+-- keys of blobs of deleted attachments, blobs are deleted from storage by background job
+CREATE TABLE h_deleted_attachment_blob
+(
+    blob_key   VARCHAR(255) PRIMARY KEY,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- attachments are deleted with tasks and projects by cascade, so blobs are queued for deletion by trigger
+CREATE OR REPLACE FUNCTION trigger_queue_r_task_attachment_blob()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO h_deleted_attachment_blob (blob_key)
+    VALUES (OLD.blob_key)
+    ON CONFLICT DO NOTHING;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER queue_r_task_attachment_blob
+    AFTER DELETE
+    ON r_task_attachment
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_queue_r_task_attachment_blob();
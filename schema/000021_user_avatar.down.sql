@@ -0,0 +1,29 @@
+DROP TRIGGER IF EXISTS queue_r_user_avatar_blobs ON r_user;
+DROP FUNCTION IF EXISTS trigger_queue_r_user_avatar_blobs();
+
+DROP TRIGGER IF EXISTS set_r_user_avatar_url ON r_user;
+DROP FUNCTION IF EXISTS trigger_set_r_user_avatar_url();
+
+UPDATE r_user
+SET avatar_url = ''
+WHERE avatar_url LIKE '/api/v1/users/%/avatar%';
+
+ALTER TABLE r_user
+    DROP COLUMN IF EXISTS avatar_blob_keys;
+
+ALTER TABLE h_deleted_blob
+    RENAME TO h_deleted_attachment_blob;
+
+CREATE OR REPLACE FUNCTION trigger_queue_r_task_attachment_blob()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO h_deleted_attachment_blob (blob_key)
+    VALUES (OLD.blob_key)
+    ON CONFLICT DO NOTHING;
+
+    RETURN NULL;
+END;
+$$;
@@ -0,0 +1,69 @@
+-- queue of deleted blobs is shared by attachments and avatars
+ALTER TABLE h_deleted_attachment_blob
+    RENAME TO h_deleted_blob;
+
+CREATE OR REPLACE FUNCTION trigger_queue_r_task_attachment_blob()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    INSERT INTO h_deleted_blob (blob_key)
+    VALUES (OLD.blob_key)
+    ON CONFLICT DO NOTHING;
+
+    RETURN NULL;
+END;
+$$;
+
+-- keys of blobs of uploaded avatar in all sizes, empty if avatar is not uploaded
+ALTER TABLE r_user
+    ADD COLUMN avatar_blob_keys TEXT[] NOT NULL DEFAULT '{}';
+
+-- avatar is served by api: uploaded image or generated initials avatar
+UPDATE r_user
+SET avatar_url = '/api/v1/users/' || id || '/avatar';
+
+CREATE OR REPLACE FUNCTION trigger_set_r_user_avatar_url()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    NEW.avatar_url := '/api/v1/users/' || NEW.id || '/avatar';
+
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER set_r_user_avatar_url
+    BEFORE INSERT
+    ON r_user
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_r_user_avatar_url();
+
+CREATE OR REPLACE FUNCTION trigger_queue_r_user_avatar_blobs()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO h_deleted_blob (blob_key)
+        SELECT k FROM UNNEST(OLD.avatar_blob_keys) AS k
+        ON CONFLICT DO NOTHING;
+    ELSE
+        INSERT INTO h_deleted_blob (blob_key)
+        SELECT k FROM UNNEST(OLD.avatar_blob_keys) AS k WHERE NOT k = ANY (NEW.avatar_blob_keys)
+        ON CONFLICT DO NOTHING;
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER queue_r_user_avatar_blobs
+    AFTER DELETE OR UPDATE OF avatar_blob_keys
+    ON r_user
+    FOR EACH ROW
+EXECUTE PROCEDURE trigger_queue_r_user_avatar_blobs();
@@ -0,0 +1,5 @@
+ALTER TABLE r_user
+    DROP COLUMN IF EXISTS is_disabled;
+
+ALTER TABLE r_user
+    DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,7 @@
+-- system administrators manage all users and projects
+ALTER TABLE r_user
+    ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- disabled users can not sign in and their sessions are not accepted
+ALTER TABLE r_user
+    ADD COLUMN is_disabled BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,13 @@
+DELETE
+FROM r_task_attachment
+WHERE uploader_id IS NULL;
+
+ALTER TABLE r_task_attachment
+    DROP CONSTRAINT IF EXISTS r_task_attachment_uploader_id_fkey;
+
+ALTER TABLE r_task_attachment
+    ADD CONSTRAINT r_task_attachment_uploader_id_fkey
+        FOREIGN KEY (uploader_id) REFERENCES r_user (id) ON DELETE CASCADE;
+
+ALTER TABLE r_task_attachment
+    ALTER COLUMN uploader_id SET NOT NULL;
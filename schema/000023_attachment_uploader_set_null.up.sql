@@ -0,0 +1,10 @@
+-- attachments are kept when uploader is deleted
+ALTER TABLE r_task_attachment
+    ALTER COLUMN uploader_id DROP NOT NULL;
+
+ALTER TABLE r_task_attachment
+    DROP CONSTRAINT IF EXISTS r_task_attachment_uploader_id_fkey;
+
+ALTER TABLE r_task_attachment
+    ADD CONSTRAINT r_task_attachment_uploader_id_fkey
+        FOREIGN KEY (uploader_id) REFERENCES r_user (id) ON DELETE SET NULL;
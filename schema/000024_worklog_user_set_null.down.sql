@@ -0,0 +1,13 @@
+DELETE
+FROM r_task_worklog
+WHERE user_id IS NULL;
+
+ALTER TABLE r_task_worklog
+    DROP CONSTRAINT IF EXISTS r_task_worklog_user_id_fkey;
+
+ALTER TABLE r_task_worklog
+    ADD CONSTRAINT r_task_worklog_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES r_user (id) ON DELETE CASCADE;
+
+ALTER TABLE r_task_worklog
+    ALTER COLUMN user_id SET NOT NULL;
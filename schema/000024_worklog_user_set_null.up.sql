@@ -0,0 +1,10 @@
+-- logged time is kept in reports when user is deleted
+ALTER TABLE r_task_worklog
+    ALTER COLUMN user_id DROP NOT NULL;
+
+ALTER TABLE r_task_worklog
+    DROP CONSTRAINT IF EXISTS r_task_worklog_user_id_fkey;
+
+ALTER TABLE r_task_worklog
+    ADD CONSTRAINT r_task_worklog_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES r_user (id) ON DELETE SET NULL;